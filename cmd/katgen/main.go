@@ -0,0 +1,224 @@
+// Command katgen generates known answer tests for SIKE in the format of
+// PQCkemKAT_*.rsp files, as PQCgenKAT_kem.c from NIST PQC reference
+// implementations does. Keys are generated with sidh.PrivateKey.Generate and
+// encapsulation is done with sike.Encapsulate, both using NIST AES-256 CTR
+// DRBG as a source of randomness. With -hybrid, test vectors for hybrid KEM
+// combining SIKE with given KEM are generated instead.
+//
+// Usage:
+//
+//	katgen [-params SIKEp434] [-count 100] [-compressed | -hybrid x25519] [-o file]
+//
+// Files produced for SIKEp503 and SIKEp751 are the same as etc/PQCkemKAT_434.rsp
+// and etc/PQCkemKAT_644.rsp, files for SIKEp503r3 and SIKEp751r3 are the same
+// as etc/PQCkemKAT_434_r3.rsp and etc/PQCkemKAT_644_r3.rsp. By default output
+// is written to the PQCkemKAT_<size of secret key>.rsp file (with suffix _r3
+// for round 3 versions of SIKEp503 and SIKEp751), or
+// PQCkemKAT_<name of hybrid>.rsp if -hybrid is used.
+//
+// With -compressed, public keys and c0 are encoded with
+// sidh.PublicKey.ExportCompressed. This encoding is specific to this package,
+// output doesn't match known answer tests of SIKE-compressed and it is written
+// to the <name>_compressed_keys.rsp file.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudflare/sidh/hybrid/x25519sike"
+	"github.com/cloudflare/sidh/internal/drbg"
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+)
+
+// Returns id of the parameter set with given name
+func lookupName(name string) (uint8, error) {
+	for _, p := range sidh.SupportedParams() {
+		if strings.EqualFold(p.Name, name) {
+			return p.Id, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown parameter set %q", name)
+}
+
+// Generates keypair and encapsulates, reading randomness from rng. Returns
+// encodings of public key, secret key, ciphertext and shared secret.
+type kemFunc func(rng io.Reader) (pk, sk, ct, ss []byte, err error)
+
+// Returns kemFunc for SIKE with parameter set id
+func sikeKEM(id uint8, compressed bool) kemFunc {
+	return func(rng io.Reader) (pk, sk, ct, ss []byte, err error) {
+		prv := sidh.NewPrivateKey(id, sidh.KeyVariant_SIKE)
+		if err = prv.Generate(rng); err != nil {
+			return
+		}
+		pub := prv.GeneratePublicKey()
+		if compressed {
+			if pk, err = pub.ExportCompressed(); err != nil {
+				return
+			}
+			ct, ss, err = sike.EncapsulateCompressed(rng, pub)
+		} else {
+			pk = pub.Export()
+			ct, ss, err = sike.Encapsulate(rng, pub)
+		}
+		if err != nil {
+			return
+		}
+
+		// Sanity check, the same as in PQCgenKAT_kem.c
+		var ss2 []byte
+		if compressed {
+			ss2, err = sike.DecapsulateCompressed(prv, pub, ct)
+		} else {
+			ss2, err = sike.Decapsulate(prv, pub, ct)
+		}
+		if err == nil && !bytes.Equal(ss, ss2) {
+			err = errors.New("decapsulation failed")
+		}
+
+		// Secret key is a concatenation of MSG + SECRET_BOB_KEY + PUBLIC_BOB_KEY
+		sk = append(prv.Export(), pk...)
+		return
+	}
+}
+
+// Returns kemFunc for X25519+SIKE hybrid with SIKE parameter set id
+func x25519SIKEKEM(id uint8) kemFunc {
+	return func(rng io.Reader) (pk, sk, ct, ss []byte, err error) {
+		prv := x25519sike.NewPrivateKey(id)
+		if err = prv.Generate(rng); err != nil {
+			return
+		}
+		pub := prv.GeneratePublicKey()
+		if ct, ss, err = x25519sike.Encapsulate(rng, pub); err != nil {
+			return
+		}
+		ss2, err := x25519sike.Decapsulate(prv, pub, ct)
+		if err == nil && !bytes.Equal(ss, ss2) {
+			err = errors.New("decapsulation failed")
+		}
+		return pub.Export(), prv.Export(), ct, ss, err
+	}
+}
+
+// Writes n test vectors. Seeds are taken from DRBG initialized with entropy
+// input 0,1,...,47, and a new DRBG is initialized with each seed before
+// calling kem, as in PQCgenKAT_kem.c.
+func generateKAT(w io.Writer, name string, n int, kem kemFunc) error {
+	var entropy [drbg.SeedSize]byte
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+	master := drbg.New(entropy[:], nil)
+
+	if _, err := fmt.Fprintf(w, "# %s\n\n", name); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		var seed [drbg.SeedSize]byte
+		master.Read(seed[:])
+		pk, sk, ct, ss, err := kem(drbg.New(seed[:], nil))
+		if err != nil {
+			return fmt.Errorf("count %d: %s", i, err)
+		}
+		_, err = fmt.Fprintf(w, "count = %d\nseed = %X\npk = %X\nsk = %X\nct = %X\nss = %X\n\n",
+			i, seed, pk, sk, ct, ss)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("katgen", flag.ContinueOnError)
+	name := fs.String("params", "SIKEp434", "name of the parameter set")
+	count := fs.Int("count", 100, "number of test vectors")
+	compressed := fs.Bool("compressed", false, "use compressed public keys and ciphertexts")
+	hybrid := fs.String("hybrid", "", "generate KAT for hybrid with given KEM (x25519)")
+	out := fs.String("o", "", "output file, \"-\" for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *count < 0 {
+		return errors.New("negative number of test vectors")
+	}
+	id, err := lookupName(*name)
+	if err != nil {
+		return err
+	}
+
+	var kem kemFunc
+	var kemName, defaultOut string
+	switch *hybrid {
+	case "":
+		params := sidh.Params(id)
+		skSize := params.MsgLen + int(params.B.SecretByteLen)
+		kemName = params.Name
+		// Files of round 3 versions of SIKEp503 and SIKEp751 use names of
+		// the round 3 submission
+		var suffix string
+		if params.Id == sidh.FP_503_R3 || params.Id == sidh.FP_751_R3 {
+			kemName = strings.TrimSuffix(kemName, "r3")
+			suffix = "_r3"
+		}
+		skSize += sidh.NewPublicKey(id, sidh.KeyVariant_SIKE).Size()
+		defaultOut = fmt.Sprintf("PQCkemKAT_%d%s.rsp", skSize, suffix)
+		if *compressed {
+			// Not SIKE-compressed, hence neither its name nor file name
+			kemName = params.Name + " with compressed keys"
+			defaultOut = params.Name + "_compressed_keys.rsp"
+		}
+		kem = sikeKEM(id, *compressed)
+	case "x25519":
+		kem = x25519SIKEKEM(id)
+		kemName = x25519sike.Name(id)
+		defaultOut = fmt.Sprintf("PQCkemKAT_%s.rsp", kemName)
+	default:
+		return fmt.Errorf("unknown hybrid %q", *hybrid)
+	}
+	if *hybrid != "" && *compressed {
+		return errors.New("-compressed can't be used with -hybrid")
+	}
+	if *out == "" {
+		*out = defaultOut
+	}
+
+	if *out == "-" {
+		return writeKAT(os.Stdout, kemName, *count, kem)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	err = writeKAT(f, kemName, *count, kem)
+	// Error of Close is reported, data may not have been written
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Writes test vectors to w through a buffer
+func writeKAT(w io.Writer, kemName string, count int, kem kemFunc) error {
+	bw := bufio.NewWriter(w)
+	if err := generateKAT(bw, kemName, count, kem); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "katgen:", err)
+		os.Exit(1)
+	}
+}
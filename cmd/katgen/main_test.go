@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/cloudflare/sidh/sidh"
+)
+
+// Matches comment lines, files in sike/testdata have longer headers
+var commentLine = regexp.MustCompile(`(?m)^#.*\n`)
+
+// Generated test vectors are the same as the beginning of the KAT files
+func TestGenerateKAT(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		file string
+		kem  kemFunc
+	}{
+		{"SIKEp434", "../../etc/PQCkemKAT_374.rsp", sikeKEM(sidh.FP_434, false)},
+		{"SIKEp503", "../../etc/PQCkemKAT_434.rsp", sikeKEM(sidh.FP_503, false)},
+		{"SIKEp503", "../../etc/PQCkemKAT_434_r3.rsp", sikeKEM(sidh.FP_503_R3, false)},
+		{"SIKEp434 with compressed keys", "../../sike/testdata/SIKEp434_compressed_keys_regression.rsp", sikeKEM(sidh.FP_434, true)},
+		{"X25519-SIKEp434", "../../etc/PQCkemKAT_X25519-SIKEp434.rsp", x25519SIKEKEM(sidh.FP_434)},
+	} {
+		exp, err := os.ReadFile(v.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Some of the files have CRLF line endings
+		exp = bytes.Replace(exp, []byte("\r\n"), []byte("\n"), -1)
+		var buf bytes.Buffer
+		if err = generateKAT(&buf, v.name, 2, v.kem); err != nil {
+			t.Fatal(err)
+		}
+		exp = commentLine.ReplaceAll(exp, nil)
+		if !bytes.HasPrefix(exp, commentLine.ReplaceAll(buf.Bytes(), nil)) {
+			t.Errorf("%s: unexpected output:\n%s", v.file, buf.String())
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-params", "SIKEp1", "-o", "-"},
+		{"-count", "-1", "-o", "-"},
+		{"-hybrid", "p256", "-o", "-"},
+		{"-hybrid", "x25519", "-compressed", "-o", "-"},
+	} {
+		if err := run(args); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
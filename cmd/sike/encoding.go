@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Supported encodings of files
+const (
+	formatHex    = "hex"
+	formatBase64 = "base64"
+	formatPEM    = "pem"
+)
+
+// PEM block types of objects handled by the tool
+const (
+	kindPublicKey    = "SIKE PUBLIC KEY"
+	kindPrivateKey   = "SIKE PRIVATE KEY"
+	kindCiphertext   = "SIKE CIPHERTEXT"
+	kindSharedSecret = "SIKE SHARED SECRET"
+	kindPlaintext    = "SIKE PLAINTEXT"
+)
+
+// PEM header carrying name of the parameter set
+const pemParamsHeader = "Params"
+
+// Object read from or written to a file
+type object struct {
+	// One of kind* constants
+	kind string
+	// Name of the parameter set, may be empty if not known
+	params string
+	data   []byte
+}
+
+func checkFormat(format string) error {
+	switch format {
+	case formatHex, formatBase64, formatPEM:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, expected one of: hex, base64, pem", format)
+}
+
+// Encodes object in given format
+func encode(w io.Writer, format string, obj *object) error {
+	var err error
+	switch format {
+	case formatHex:
+		_, err = fmt.Fprintf(w, "%X\n", obj.data)
+	case formatBase64:
+		_, err = fmt.Fprintln(w, base64.StdEncoding.EncodeToString(obj.data))
+	case formatPEM:
+		block := &pem.Block{Type: obj.kind, Bytes: obj.data}
+		if obj.params != "" {
+			block.Headers = map[string]string{pemParamsHeader: obj.params}
+		}
+		err = pem.Encode(w, block)
+	default:
+		err = checkFormat(format)
+	}
+	return err
+}
+
+// Decodes object in given format. If kind is not empty, PEM block must be
+// of that type. For hex and base64, kind of the object is set to kind.
+func decode(in []byte, format, kind string) (*object, error) {
+	var err error
+	obj := &object{kind: kind}
+	switch format {
+	case formatHex:
+		obj.data, err = hex.DecodeString(strings.Join(strings.Fields(string(in)), ""))
+	case formatBase64:
+		obj.data, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(in)), ""))
+	case formatPEM:
+		block, rest := pem.Decode(in)
+		if block == nil {
+			return nil, errors.New("no PEM data found")
+		}
+		if len(bytes.TrimSpace(rest)) != 0 {
+			return nil, errors.New("trailing data after PEM block")
+		}
+		if kind != "" && block.Type != kind {
+			return nil, fmt.Errorf("expected PEM block %q, got %q", kind, block.Type)
+		}
+		obj.kind = block.Type
+		obj.params = block.Headers[pemParamsHeader]
+		obj.data = block.Bytes
+	default:
+		err = checkFormat(format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Reads file, "-" denotes standard input
+func readFile(name string, stdin io.Reader) ([]byte, error) {
+	if name == "" {
+		return nil, errors.New("input file not specified")
+	}
+	if name == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(name)
+}
+
+// Writes object to a file, "-" or empty name denotes standard output.
+// Files with private data are created with 0600 permissions.
+func writeObject(name, format string, obj *object, stdout io.Writer) error {
+	var buf bytes.Buffer
+	if err := encode(&buf, format, obj); err != nil {
+		return err
+	}
+	if name == "" || name == "-" {
+		_, err := stdout.Write(buf.Bytes())
+		return err
+	}
+	perm := os.FileMode(0644)
+	if obj.kind == kindPrivateKey || obj.kind == kindSharedSecret || obj.kind == kindPlaintext {
+		perm = 0600
+	}
+	return ioutil.WriteFile(name, buf.Bytes(), perm)
+}
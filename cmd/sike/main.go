@@ -0,0 +1,447 @@
+// Command sike exposes SIKE key generation, KEM and PKE operations to shell
+// scripts.
+//
+// Usage:
+//
+//	sike keygen -params SIKEp434 -pub key.pub -priv key.priv
+//	sike encaps -pub key.pub -ct ct -ss ss
+//	sike decaps -priv key.priv -ct ct -ss ss
+//	sike pkeenc -pub key.pub -in msg -ct ct
+//	sike pkedec -priv key.priv -ct ct -out msg
+//	sike inspect [-params SIKEp434] [file]
+//
+// All subcommands accept -format flag, which selects encoding of files: hex
+// (default), base64 or pem. PEM files carry name of the parameter set in
+// the "Params" header, so -params can be omitted when reading them. File
+// name "-" denotes standard input or output.
+//
+// Private key file contains concatenation of random value s, secret key
+// and public key, as in NIST KAT files.
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+)
+
+// Environment in which subcommands are run
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	rng    io.Reader
+}
+
+// Subcommand implementation
+type command struct {
+	name  string
+	usage string
+	run   func(e *env, args []string) error
+}
+
+var commands = []command{
+	{"keygen", "generate SIKE key pair", runKeygen},
+	{"encaps", "encapsulate shared secret with public key", runEncaps},
+	{"decaps", "decapsulate shared secret with private key", runDecaps},
+	{"pkeenc", "encrypt message with public key", runPkeEnc},
+	{"pkedec", "decrypt message with private key", runPkeDec},
+	{"inspect", "print sizes of parameter sets or describe a file", runInspect},
+}
+
+// Flags common to all subcommands
+type commonFlags struct {
+	params string
+	format string
+}
+
+func newFlagSet(e *env, name string, cf *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.StringVar(&cf.params, "params", "", "name of the parameter set, e.g. SIKEp434")
+	fs.StringVar(&cf.format, "format", formatHex, "encoding of files: hex, base64 or pem")
+	return fs
+}
+
+// Finds registered parameter set by name
+func paramsByName(name string) (*SidhParams, error) {
+	var names []string
+	for _, p := range sidh.SupportedParams() {
+		if strings.EqualFold(p.Name, name) {
+			return sidh.LookupParams(p.Id)
+		}
+		names = append(names, p.Name)
+	}
+	return nil, fmt.Errorf("unknown parameter set %q, supported: %s", name, strings.Join(names, ", "))
+}
+
+// Resolves parameter set from the -params flag and headers of PEM objects.
+func resolveParams(name string, objs ...*object) (*SidhParams, error) {
+	for _, o := range objs {
+		if o.params == "" {
+			continue
+		}
+		if name == "" {
+			name = o.params
+		} else if !strings.EqualFold(name, o.params) {
+			return nil, fmt.Errorf("parameter set mismatch: %s and %s", name, o.params)
+		}
+	}
+	if name == "" {
+		return nil, errors.New("parameter set not specified, use -params")
+	}
+	return paramsByName(name)
+}
+
+// Reads and decodes object from a file
+func readObject(e *env, name, format, kind string) (*object, error) {
+	in, err := readFile(name, e.stdin)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decode(in, format, kind)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	return obj, nil
+}
+
+// Size of private key file: s || secret key || public key
+func privateKeySize(params *SidhParams) int {
+	return sike.NewSIKEPrivateKey(params.Id).Size()
+}
+
+func loadPublicKey(params *SidhParams, data []byte) (*sidh.PublicKey, error) {
+	pub := sidh.NewPublicKey(params.Id, sidh.KeyVariant_SIKE)
+	if len(data) != pub.Size() {
+		return nil, fmt.Errorf("wrong size of public key: %d, expected %d", len(data), pub.Size())
+	}
+	if err := pub.Import(data); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+func loadPrivateKey(params *SidhParams, data []byte) (*sike.SIKEPrivateKey, error) {
+	key := sike.NewSIKEPrivateKey(params.Id)
+	if len(data) != key.Size() {
+		return nil, fmt.Errorf("wrong size of private key: %d, expected %d", len(data), key.Size())
+	}
+	if err := key.Import(data); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func runKeygen(e *env, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet(e, "keygen", &cf)
+	pubFile := fs.String("pub", "", "output file for public key")
+	privFile := fs.String("priv", "", "output file for private key")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *pubFile == "" || *privFile == "" {
+		return errors.New("keygen: -pub and -priv are required")
+	}
+	params, err := resolveParams(cf.params)
+	if err != nil {
+		return err
+	}
+
+	key := sike.NewSIKEPrivateKey(params.Id)
+	if err = key.Generate(e.rng); err != nil {
+		return err
+	}
+	pub := key.PublicKey().Export()
+	err = writeObject(*pubFile, cf.format, &object{kindPublicKey, params.Name, pub}, e.stdout)
+	if err != nil {
+		return err
+	}
+	return writeObject(*privFile, cf.format,
+		&object{kindPrivateKey, params.Name, key.Export()}, e.stdout)
+}
+
+func runEncaps(e *env, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet(e, "encaps", &cf)
+	pubFile := fs.String("pub", "", "public key file")
+	ctFile := fs.String("ct", "", "output file for ciphertext")
+	ssFile := fs.String("ss", "-", "output file for shared secret")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *ctFile == "" {
+		return errors.New("encaps: -ct is required")
+	}
+	obj, err := readObject(e, *pubFile, cf.format, kindPublicKey)
+	if err != nil {
+		return err
+	}
+	params, err := resolveParams(cf.params, obj)
+	if err != nil {
+		return err
+	}
+	pub, err := loadPublicKey(params, obj.data)
+	if err != nil {
+		return err
+	}
+
+	ct, ss, err := sike.Encapsulate(e.rng, pub)
+	if err != nil {
+		return err
+	}
+	err = writeObject(*ctFile, cf.format, &object{kindCiphertext, params.Name, ct}, e.stdout)
+	if err != nil {
+		return err
+	}
+	return writeObject(*ssFile, cf.format, &object{kindSharedSecret, params.Name, ss}, e.stdout)
+}
+
+func runDecaps(e *env, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet(e, "decaps", &cf)
+	privFile := fs.String("priv", "", "private key file")
+	ctFile := fs.String("ct", "", "ciphertext file")
+	ssFile := fs.String("ss", "-", "output file for shared secret")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	privObj, err := readObject(e, *privFile, cf.format, kindPrivateKey)
+	if err != nil {
+		return err
+	}
+	ctObj, err := readObject(e, *ctFile, cf.format, kindCiphertext)
+	if err != nil {
+		return err
+	}
+	params, err := resolveParams(cf.params, privObj, ctObj)
+	if err != nil {
+		return err
+	}
+	key, err := loadPrivateKey(params, privObj.data)
+	if err != nil {
+		return err
+	}
+	if len(ctObj.data) != params.PublicKeySize+params.MsgLen {
+		return fmt.Errorf("wrong size of ciphertext: %d, expected %d",
+			len(ctObj.data), params.PublicKeySize+params.MsgLen)
+	}
+
+	ss, err := key.Decapsulate(ctObj.data)
+	if err != nil {
+		return err
+	}
+	return writeObject(*ssFile, cf.format, &object{kindSharedSecret, params.Name, ss}, e.stdout)
+}
+
+func runPkeEnc(e *env, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet(e, "pkeenc", &cf)
+	pubFile := fs.String("pub", "", "public key file")
+	inFile := fs.String("in", "", "plaintext file")
+	ctFile := fs.String("ct", "-", "output file for ciphertext")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pubObj, err := readObject(e, *pubFile, cf.format, kindPublicKey)
+	if err != nil {
+		return err
+	}
+	msgObj, err := readObject(e, *inFile, cf.format, kindPlaintext)
+	if err != nil {
+		return err
+	}
+	params, err := resolveParams(cf.params, pubObj, msgObj)
+	if err != nil {
+		return err
+	}
+	pub, err := loadPublicKey(params, pubObj.data)
+	if err != nil {
+		return err
+	}
+	if len(msgObj.data) != params.MsgLen {
+		return fmt.Errorf("wrong size of plaintext: %d, expected %d", len(msgObj.data), params.MsgLen)
+	}
+
+	ct, err := sike.Encrypt(e.rng, pub, msgObj.data)
+	if err != nil {
+		return err
+	}
+	return writeObject(*ctFile, cf.format, &object{kindCiphertext, params.Name, ct}, e.stdout)
+}
+
+func runPkeDec(e *env, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet(e, "pkedec", &cf)
+	privFile := fs.String("priv", "", "private key file")
+	ctFile := fs.String("ct", "", "ciphertext file")
+	outFile := fs.String("out", "-", "output file for plaintext")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	privObj, err := readObject(e, *privFile, cf.format, kindPrivateKey)
+	if err != nil {
+		return err
+	}
+	ctObj, err := readObject(e, *ctFile, cf.format, kindCiphertext)
+	if err != nil {
+		return err
+	}
+	params, err := resolveParams(cf.params, privObj, ctObj)
+	if err != nil {
+		return err
+	}
+	key, err := loadPrivateKey(params, privObj.data)
+	if err != nil {
+		return err
+	}
+
+	pt, err := sike.Decrypt(key.PrivateKey(), ctObj.data)
+	if err != nil {
+		return err
+	}
+	return writeObject(*outFile, cf.format, &object{kindPlaintext, params.Name, pt}, e.stdout)
+}
+
+// Prints sizes of the parameter set
+func printSizes(w io.Writer, p sidh.ParamsInfo) {
+	fmt.Fprintf(w, "%s (NIST level %d): public key %d, private key %d, ciphertext %d, shared secret %d bytes\n",
+		p.Name, p.SecurityLevel, p.PublicKeySize, p.SikePrivateKeySize,
+		p.SikeCiphertextSize, p.SikeSharedSecretSize)
+}
+
+// Returns kinds of objects of given size for the parameter set
+func kindsBySize(params *SidhParams, size int) []string {
+	var kinds []string
+	for _, v := range []struct {
+		kind string
+		size int
+	}{
+		{kindPublicKey, params.PublicKeySize},
+		{kindPrivateKey, privateKeySize(params)},
+		{kindCiphertext, params.PublicKeySize + params.MsgLen},
+		{kindSharedSecret, params.KemSize},
+		{kindPlaintext, params.MsgLen},
+	} {
+		if v.size == size {
+			kinds = append(kinds, v.kind)
+		}
+	}
+	return kinds
+}
+
+// Prints description of the object
+func describe(w io.Writer, params *SidhParams, kind string, data []byte) {
+	fmt.Fprintf(w, "%s\n  params: %s\n  size:   %d bytes\n", kind, params.Name, len(data))
+	switch kind {
+	case kindPublicKey:
+		if pub, err := loadPublicKey(params, data); err != nil {
+			fmt.Fprintf(w, "  valid:  no (%s)\n", err)
+		} else if err = pub.Validate(); err != nil {
+			fmt.Fprintf(w, "  valid:  no (%s)\n", err)
+		} else {
+			fmt.Fprintf(w, "  valid:  yes\n")
+		}
+	case kindPrivateKey:
+		if _, err := loadPrivateKey(params, data); err != nil {
+			fmt.Fprintf(w, "  valid:  no (%s)\n", err)
+		} else {
+			fmt.Fprintf(w, "  valid:  yes\n")
+		}
+	}
+}
+
+func runInspect(e *env, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet(e, "inspect", &cf)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errors.New("inspect: too many arguments")
+	}
+
+	// Without a file, print sizes of parameter sets
+	if fs.NArg() == 0 {
+		for _, p := range sidh.SupportedParams() {
+			if cf.params == "" || strings.EqualFold(cf.params, p.Name) {
+				printSizes(e.stdout, p)
+			}
+		}
+		return nil
+	}
+
+	obj, err := readObject(e, fs.Arg(0), cf.format, "")
+	if err != nil {
+		return err
+	}
+	if obj.kind != "" {
+		params, err := resolveParams(cf.params, obj)
+		if err != nil {
+			return err
+		}
+		describe(e.stdout, params, obj.kind, obj.data)
+		return nil
+	}
+
+	// Kind of hex and base64 encoded objects is guessed by size
+	var found bool
+	for _, p := range sidh.SupportedParams() {
+		if cf.params != "" && !strings.EqualFold(cf.params, p.Name) {
+			continue
+		}
+		params, err := sidh.LookupParams(p.Id)
+		if err != nil {
+			return err
+		}
+		for _, kind := range kindsBySize(params, len(obj.data)) {
+			describe(e.stdout, params, kind, obj.data)
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("unrecognized object of size %d bytes", len(obj.data))
+	}
+	return nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: sike <command> [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.usage)
+	}
+	fmt.Fprintf(w, "\nRun 'sike <command> -h' for flags of a command.\n")
+}
+
+func run(e *env, args []string) error {
+	if len(args) == 0 {
+		usage(e.stderr)
+		return errors.New("command not specified")
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(e, args[1:])
+		}
+	}
+	usage(e.stderr)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func main() {
+	e := &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, rng: rand.Reader}
+	if err := run(e, os.Args[1:]); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintf(os.Stderr, "sike: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
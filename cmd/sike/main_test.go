@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Runs command, returns its standard output
+func runCmd(t *testing.T, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	e := &env{stdin: strings.NewReader(""), stdout: &stdout, stderr: &stderr, rng: rand.Reader}
+	err := run(e, args)
+	return stdout.String(), err
+}
+
+func mustRun(t *testing.T, args ...string) string {
+	out, err := runCmd(t, args...)
+	if err != nil {
+		t.Fatalf("%v: %s", args, err)
+	}
+	return out
+}
+
+func readFileT(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "sike-cmd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestKEMRoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	pub := filepath.Join(dir, "key.pub")
+	priv := filepath.Join(dir, "key.priv")
+	ct := filepath.Join(dir, "ct")
+	ss1 := filepath.Join(dir, "ss1")
+	ss2 := filepath.Join(dir, "ss2")
+
+	for _, format := range []string{formatHex, formatBase64, formatPEM} {
+		mustRun(t, "keygen", "-params", "SIKEp434", "-format", format, "-pub", pub, "-priv", priv)
+
+		// PEM files carry parameter set
+		params := []string{"-params", "sikep434"}
+		if format == formatPEM {
+			params = nil
+		}
+		mustRun(t, append([]string{"encaps", "-format", format, "-pub", pub, "-ct", ct, "-ss", ss1}, params...)...)
+		mustRun(t, append([]string{"decaps", "-format", format, "-priv", priv, "-ct", ct, "-ss", ss2}, params...)...)
+		if readFileT(t, ss1) != readFileT(t, ss2) {
+			t.Errorf("%s: shared secrets differ", format)
+		}
+
+		out := mustRun(t, "inspect", "-format", format, pub)
+		if !strings.Contains(out, kindPublicKey) || !strings.Contains(out, "valid:  yes") {
+			t.Errorf("%s: unexpected output of inspect:\n%s", format, out)
+		}
+		out = mustRun(t, "inspect", "-format", format, priv)
+		if !strings.Contains(out, kindPrivateKey) || !strings.Contains(out, "valid:  yes") {
+			t.Errorf("%s: unexpected output of inspect:\n%s", format, out)
+		}
+	}
+}
+
+func TestPKERoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	pub := filepath.Join(dir, "key.pub")
+	priv := filepath.Join(dir, "key.priv")
+	msg := filepath.Join(dir, "msg")
+	ct := filepath.Join(dir, "ct")
+
+	mustRun(t, "keygen", "-params", "SIKEp503", "-pub", pub, "-priv", priv)
+	// SIKEp503 uses 24 bytes long messages
+	m := strings.Repeat("A5", 24) + "\n"
+	if err := ioutil.WriteFile(msg, []byte(m), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mustRun(t, "pkeenc", "-params", "SIKEp503", "-pub", pub, "-in", msg, "-ct", ct)
+	out := mustRun(t, "pkedec", "-params", "SIKEp503", "-priv", priv, "-ct", ct)
+	if out != m {
+		t.Errorf("decryption failed: got %s, expected %s", out, m)
+	}
+
+	// Message of wrong size
+	if err := ioutil.WriteFile(msg, []byte("A5A5"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCmd(t, "pkeenc", "-params", "SIKEp503", "-pub", pub, "-in", msg); err == nil {
+		t.Error("message of wrong size accepted")
+	}
+}
+
+func TestInspectSizes(t *testing.T) {
+	out := mustRun(t, "inspect")
+	for _, name := range []string{"SIKEp434", "SIKEp503", "SIKEp610", "SIKEp751", "SIKEp964"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("%s missing in the output", name)
+		}
+	}
+	out = mustRun(t, "inspect", "-params", "SIKEp434")
+	exp := "SIKEp434 (NIST level 1): public key 330, private key 374, ciphertext 346, shared secret 16 bytes\n"
+	if out != exp {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	pub := filepath.Join(dir, "key.pub")
+	priv := filepath.Join(dir, "key.priv")
+	mustRun(t, "keygen", "-params", "SIKEp434", "-format", "pem", "-pub", pub, "-priv", priv)
+
+	for _, args := range [][]string{
+		{},
+		{"unknown"},
+		{"keygen", "-params", "SIKEp1", "-pub", pub, "-priv", priv},
+		{"keygen", "-pub", pub, "-priv", priv},
+		{"keygen", "-params", "SIKEp434", "-format", "der", "-pub", pub, "-priv", priv},
+		// Parameter set differs from the one in PEM header
+		{"encaps", "-params", "SIKEp503", "-format", "pem", "-pub", pub, "-ct", "-"},
+		// Wrong PEM block type
+		{"encaps", "-format", "pem", "-pub", priv, "-ct", "-"},
+		// Not a hex file
+		{"encaps", "-params", "SIKEp434", "-pub", pub, "-ct", "-"},
+	} {
+		if _, err := runCmd(t, args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+// Private key file with public key of other key pair is rejected
+func TestMismatchedPrivateKey(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	pub := filepath.Join(dir, "key.pub")
+	priv := filepath.Join(dir, "key.priv")
+	ct := filepath.Join(dir, "ct")
+	mustRun(t, "keygen", "-params", "SIKEp434", "-pub", pub, "-priv", priv)
+	mustRun(t, "encaps", "-params", "SIKEp434", "-pub", pub, "-ct", ct, "-ss", "-")
+	sk := strings.TrimSpace(readFileT(t, priv))
+	mustRun(t, "keygen", "-params", "SIKEp434", "-pub", pub, "-priv", priv)
+	pk := strings.TrimSpace(readFileT(t, pub))
+
+	// s || sk of the first key, pk of the second one
+	bad := sk[:len(sk)-len(pk)] + pk
+	if err := ioutil.WriteFile(priv, []byte(bad+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCmd(t, "decaps", "-params", "SIKEp434", "-priv", priv, "-ct", ct, "-ss", "-"); err == nil {
+		t.Error("private key with mismatched public key accepted")
+	}
+	out := mustRun(t, "inspect", "-params", "SIKEp434", priv)
+	if !strings.Contains(out, "valid:  no") {
+		t.Errorf("unexpected output of inspect:\n%s", out)
+	}
+}
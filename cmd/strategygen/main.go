@@ -0,0 +1,214 @@
+// Command strategygen computes optimal isogeny strategies for a registered
+// parameter set, based on costs of point multiplication and isogeny
+// evaluation measured on the current machine, and emits them as Go source
+// code which can replace tables in p434, p503, ... packages.
+//
+// Usage:
+//
+//	strategygen [-params SIKEp434] [-o file] [-verify] [-tolerance 1.0]
+//	            [-mula ns] [-evala ns] [-mulb ns] [-evalb ns]
+//
+// With -verify, the command doesn't emit source code but compares the costs of
+// shipped strategies with the costs of optimal ones and exits with non-zero
+// status if any of them is more expensive by more than tolerance percent.
+// Costs given by -mula, -evala, -mulb and -evalb (in nanoseconds) are used
+// instead of measured ones, which makes output reproducible.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/strategy"
+)
+
+// Costs of operations used by isogeny tree traversal, in nanoseconds
+type costs struct {
+	// Point multiplication by 4 and evaluation of 4-isogeny
+	mulA, evalA float64
+	// Point multiplication by 3 and evaluation of 3-isogeny
+	mulB, evalB float64
+}
+
+// Minimal duration of a single measurement
+const measureTime = time.Second
+
+// Returns average time of executing f, in nanoseconds. Number of iterations
+// is doubled until they take at least measureTime.
+func measure(f func()) float64 {
+	for n := 1; ; n *= 2 {
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			f()
+		}
+		if d := time.Since(start); d >= measureTime {
+			return float64(d.Nanoseconds()) / float64(n)
+		}
+	}
+}
+
+// Measures costs of operations for given parameter set
+func measureCosts(params *SidhParams) (c costs) {
+	var op = CurveOperations{Params: params}
+	var T ProjectivePoint
+
+	e2, e3 := op.TorsionExponents()
+	curve := params.InitCurve
+	xQA := ProjectivePoint{X: params.A.Affine_Q, Z: params.OneFp2}
+	xQB := ProjectivePoint{X: params.B.Affine_Q, Z: params.OneFp2}
+
+	// Kernel of 4-isogeny: [2^(e2-2)]PA
+	cparam := op.CalcCurveParamsEquiv4(&curve)
+	xK := ProjectivePoint{X: params.A.Affine_P, Z: params.OneFp2}
+	op.Pow2k(&xK, &cparam, uint32(e2-2))
+	phi4 := Newisogeny4(params.Op)
+	phi4.GenerateCurve(&xK)
+	c.mulA = measure(func() {
+		T = xQA
+		op.Pow2k(&T, &cparam, 2)
+	})
+	c.evalA = measure(func() {
+		T = phi4.EvaluatePoint(&xQA)
+	})
+
+	// Kernel of 3-isogeny: [3^(e3-1)]PB
+	cparam = op.CalcCurveParamsEquiv3(&curve)
+	xK = ProjectivePoint{X: params.B.Affine_P, Z: params.OneFp2}
+	op.Pow3k(&xK, &cparam, uint32(e3-1))
+	phi3 := Newisogeny3(params.Op)
+	phi3.GenerateCurve(&xK)
+	c.mulB = measure(func() {
+		T = xQB
+		op.Pow3k(&T, &cparam, 1)
+	})
+	c.evalB = measure(func() {
+		T = phi3.EvaluatePoint(&xQB)
+	})
+	return c
+}
+
+// Finds registered parameter set by name
+func findParams(name string) (*SidhParams, error) {
+	var names []string
+	for _, p := range sidh.SupportedParams() {
+		if strings.EqualFold(p.Name, name) {
+			return sidh.LookupParams(p.Id)
+		}
+		names = append(names, p.Name)
+	}
+	return nil, fmt.Errorf("unknown parameter set %q, supported: %s", name, strings.Join(names, ", "))
+}
+
+// Compares shipped strategies with optimal ones. Returns false if any of
+// them is worse than optimal by more than tolerance percent.
+func verify(params *SidhParams, c costs, tolerance float64) (bool, error) {
+	ok := true
+	for _, v := range []struct {
+		name      string
+		s         []uint32
+		mul, eval float64
+	}{
+		{"Alice", params.A.IsogenyStrategy, c.mulA, c.evalA},
+		{"Bob", params.B.IsogenyStrategy, c.mulB, c.evalB},
+	} {
+		shipped, err := strategy.Cost(v.s, v.mul, v.eval)
+		if err != nil {
+			return false, err
+		}
+		opt, _ := strategy.Cost(strategy.Optimal(len(v.s)+1, v.mul, v.eval), v.mul, v.eval)
+		excess := 100 * (shipped - opt) / opt
+		status := "OK"
+		if excess > tolerance {
+			status, ok = "NOT OPTIMAL", false
+		}
+		fmt.Printf("%s %-5s shipped: %.0fns optimal: %.0fns excess: %.3f%% %s\n",
+			params.Name, v.name, shipped, opt, excess, status)
+	}
+	return ok, nil
+}
+
+// Emits Go source code with optimal strategies
+func emit(params *SidhParams, c costs, prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by strategygen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "// Strategies for %s. Costs used (ns):\n", params.Name)
+	fmt.Fprintf(&buf, "// 4-mul: %.1f, 4-iso eval: %.1f, 3-mul: %.1f, 3-iso eval: %.1f\n\n",
+		c.mulA, c.evalA, c.mulB, c.evalB)
+
+	sA := strategy.Optimal(len(params.A.IsogenyStrategy)+1, c.mulA, c.evalA)
+	sB := strategy.Optimal(len(params.B.IsogenyStrategy)+1, c.mulB, c.evalB)
+	err := strategy.Write(&buf, "2-torsion group computation strategy",
+		prefix+"_AliceIsogenyStrategy", "strategySizeA", sA)
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	err = strategy.Write(&buf, "3-torsion group computation strategy",
+		prefix+"_BobIsogenyStrategy", "strategySizeB", sB)
+	return buf.Bytes(), err
+}
+
+func main() {
+	var c costs
+	name := flag.String("params", "SIKEp434", "name of the parameter set")
+	out := flag.String("o", "", "output file (default: standard output)")
+	prefix := flag.String("prefix", "", "prefix of variable names (default: derived from parameter set name)")
+	doVerify := flag.Bool("verify", false, "verify that shipped strategies are optimal")
+	tolerance := flag.Float64("tolerance", 1.0, "accepted excess cost of shipped strategies, in percent")
+	flag.Float64Var(&c.mulA, "mula", 0, "cost of point multiplication by 4 (default: measured)")
+	flag.Float64Var(&c.evalA, "evala", 0, "cost of 4-isogeny evaluation (default: measured)")
+	flag.Float64Var(&c.mulB, "mulb", 0, "cost of point multiplication by 3 (default: measured)")
+	flag.Float64Var(&c.evalB, "evalb", 0, "cost of 3-isogeny evaluation (default: measured)")
+	flag.Parse()
+
+	params, err := findParams(*name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if c.mulA <= 0 || c.evalA <= 0 || c.mulB <= 0 || c.evalB <= 0 {
+		m := measureCosts(params)
+		for _, v := range []struct{ given, measured *float64 }{
+			{&c.mulA, &m.mulA}, {&c.evalA, &m.evalA}, {&c.mulB, &m.mulB}, {&c.evalB, &m.evalB},
+		} {
+			if *v.given <= 0 {
+				*v.given = *v.measured
+			}
+		}
+	}
+
+	if *doVerify {
+		ok, err := verify(params, c, *tolerance)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *prefix == "" {
+		*prefix = "P" + strings.TrimPrefix(strings.ToLower(params.Name), "sikep")
+	}
+	src, err := emit(params, c, *prefix)
+	if err == nil {
+		if *out == "" {
+			_, err = os.Stdout.Write(src)
+		} else {
+			err = os.WriteFile(*out, src, 0644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,432 @@
+//go:build go1.24
+// +build go1.24
+
+// Package hpke implements Hybrid Public Key Encryption (RFC 9180) with KEM
+// based on SIKE, in base and PSK modes.
+//
+// SIKE KEMs are not registered with IANA, identifiers defined by this package
+// are experimental and must be agreed between the parties. Shared secret of
+// SIKE KEM is derived from the shared secret of sike.Encapsulate with
+// ExtractAndExpand, with enc || pkR as a KEM context, as for DHKEM. Auth and
+// AuthPSK modes are out of scope, they require a KEM which authenticates the
+// sender and SIKE doesn't provide AuthEncap and AuthDecap.
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+)
+
+// KEMID is an identifier of the KEM
+type KEMID uint16
+
+// Experimental identifiers of SIKE KEMs
+const (
+	KEM_SIKEp434 KEMID = 0xFE01
+	KEM_SIKEp503 KEMID = 0xFE02
+	KEM_SIKEp610 KEMID = 0xFE03
+	KEM_SIKEp751 KEMID = 0xFE04
+	KEM_SIKEp964 KEMID = 0xFE05
+)
+
+// KDFID is an identifier of the KDF
+type KDFID uint16
+
+const (
+	KDF_HKDF_SHA256 KDFID = 0x0001
+	KDF_HKDF_SHA384 KDFID = 0x0002
+	KDF_HKDF_SHA512 KDFID = 0x0003
+)
+
+// AEADID is an identifier of the AEAD
+type AEADID uint16
+
+const (
+	AEAD_AES128GCM AEADID = 0x0001
+	AEAD_AES256GCM AEADID = 0x0002
+	// Context can only be used for export of secrets
+	AEAD_ExportOnly AEADID = 0xFFFF
+)
+
+// Modes of HPKE
+const (
+	modeBase uint8 = 0x00
+	modePSK  uint8 = 0x01
+)
+
+var (
+	// ErrOpen is returned if ciphertext can't be decrypted
+	ErrOpen = errors.New("hpke: decryption failed")
+	// ErrUnsupportedSuite is returned for unknown KEM, KDF or AEAD
+	ErrUnsupportedSuite = errors.New("hpke: unsupported cipher suite")
+)
+
+// KEM parameters: SIKE parameter set and KDF used by the KEM. Nsecret is
+// the output size of the hash function of the KDF.
+var kems = map[KEMID]struct {
+	id  uint8
+	kdf KDFID
+}{
+	KEM_SIKEp434: {sidh.FP_434, KDF_HKDF_SHA256},
+	KEM_SIKEp503: {sidh.FP_503, KDF_HKDF_SHA256},
+	KEM_SIKEp610: {sidh.FP_610, KDF_HKDF_SHA384},
+	KEM_SIKEp751: {sidh.FP_751, KDF_HKDF_SHA384},
+	KEM_SIKEp964: {sidh.FP_964, KDF_HKDF_SHA512},
+}
+
+// Returns hash function of the KDF
+func (kdf KDFID) hash() func() hash.Hash {
+	switch kdf {
+	case KDF_HKDF_SHA256:
+		return sha256.New
+	case KDF_HKDF_SHA384:
+		return sha512.New384
+	case KDF_HKDF_SHA512:
+		return sha512.New
+	}
+	return nil
+}
+
+// Returns sizes of key and nonce of the AEAD
+func (aead AEADID) sizes() (nk, nn int) {
+	switch aead {
+	case AEAD_AES128GCM:
+		return 16, 12
+	case AEAD_AES256GCM:
+		return 32, 12
+	case AEAD_ExportOnly:
+		return 0, 0
+	}
+	return -1, -1
+}
+
+// KDF with suite_id used for labeled extract and expand
+type labeledKDF struct {
+	h       func() hash.Hash
+	suiteID []byte
+}
+
+// LabeledExtract (RFC 9180, 4)
+func (k *labeledKDF) extract(salt []byte, label string, ikm []byte) ([]byte, error) {
+	in := append([]byte("HPKE-v1"), k.suiteID...)
+	in = append(in, label...)
+	return hkdf.Extract(k.h, append(in, ikm...), salt)
+}
+
+// LabeledExpand (RFC 9180, 4). Fails if length is greater than 255 times
+// the output size of the hash function.
+func (k *labeledKDF) expand(prk []byte, label string, info []byte, length int) ([]byte, error) {
+	in := []byte{byte(length >> 8), byte(length)}
+	in = append(in, "HPKE-v1"...)
+	in = append(in, k.suiteID...)
+	in = append(in, label...)
+	return hkdf.Expand(k.h, prk, string(append(in, info...)), length)
+}
+
+// KEM is HPKE KEM based on SIKE
+type KEM struct {
+	id      uint8
+	kdf     labeledKDF
+	nsecret int
+}
+
+// NewKEM returns KEM with given identifier
+func NewKEM(kem KEMID) (*KEM, error) {
+	p, ok := kems[kem]
+	if !ok {
+		return nil, ErrUnsupportedSuite
+	}
+	h := p.kdf.hash()
+	return &KEM{
+		id:      p.id,
+		kdf:     labeledKDF{h: h, suiteID: []byte{'K', 'E', 'M', byte(kem >> 8), byte(kem)}},
+		nsecret: h().Size(),
+	}, nil
+}
+
+// ID returns SIKE parameter set of the KEM
+func (k *KEM) ID() uint8 { return k.id }
+
+// GenerateKeyPair generates SIKE keypair for the KEM
+func (k *KEM) GenerateKeyPair(rng io.Reader) (*sidh.PrivateKey, *sidh.PublicKey, error) {
+	prv := sidh.NewPrivateKey(k.id, sidh.KeyVariant_SIKE)
+	if err := prv.Generate(rng); err != nil {
+		return nil, nil, err
+	}
+	return prv, prv.GeneratePublicKey(), nil
+}
+
+func (k *KEM) extractAndExpand(ss, kemContext []byte) ([]byte, error) {
+	prk, err := k.kdf.extract(nil, "eae_prk", ss)
+	if err != nil {
+		return nil, err
+	}
+	return k.kdf.expand(prk, "shared_secret", kemContext, k.nsecret)
+}
+
+func (k *KEM) checkKey(pub *sidh.PublicKey) error {
+	if pub == nil || pub.Variant() != sidh.KeyVariant_SIKE || pub.Params().Id != k.id {
+		return errors.New("hpke: public key doesn't match the KEM")
+	}
+	return nil
+}
+
+// Encap returns shared secret and its encapsulation for pkR
+func (k *KEM) Encap(rng io.Reader, pkR *sidh.PublicKey) (sharedSecret, enc []byte, err error) {
+	if err = k.checkKey(pkR); err != nil {
+		return nil, nil, err
+	}
+	enc, ss, err := sike.Encapsulate(rng, pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	sharedSecret, err = k.extractAndExpand(ss, append(append([]byte{}, enc...), pkR.Export()...))
+	if err != nil {
+		return nil, nil, err
+	}
+	return sharedSecret, enc, nil
+}
+
+// Decap returns shared secret from its encapsulation enc. Public key is
+// required by SIKE decapsulation.
+func (k *KEM) Decap(enc []byte, skR *sidh.PrivateKey, pkR *sidh.PublicKey) ([]byte, error) {
+	if err := k.checkKey(pkR); err != nil {
+		return nil, err
+	}
+	if skR == nil || skR.Variant() != sidh.KeyVariant_SIKE || skR.Params().Id != k.id {
+		return nil, errors.New("hpke: private key doesn't match the KEM")
+	}
+	ss, err := sike.Decapsulate(skR, pkR, enc)
+	if err != nil {
+		return nil, err
+	}
+	return k.extractAndExpand(ss, append(append([]byte{}, enc...), pkR.Export()...))
+}
+
+// Suite is HPKE cipher suite
+type Suite struct {
+	KEM  KEMID
+	KDF  KDFID
+	AEAD AEADID
+}
+
+func (s Suite) suiteID() []byte {
+	return []byte{'H', 'P', 'K', 'E',
+		byte(s.KEM >> 8), byte(s.KEM),
+		byte(s.KDF >> 8), byte(s.KDF),
+		byte(s.AEAD >> 8), byte(s.AEAD)}
+}
+
+func (s Suite) check() (*KEM, error) {
+	if nk, _ := s.AEAD.sizes(); nk < 0 || s.KDF.hash() == nil {
+		return nil, ErrUnsupportedSuite
+	}
+	return NewKEM(s.KEM)
+}
+
+// Context is an encryption context (RFC 9180, 5.2). Context of the sender
+// can only seal, context of the recipient can only open.
+type Context struct {
+	aead      cipher.AEAD
+	baseNonce []byte
+	seq       uint64
+	exporter  []byte
+	kdf       labeledKDF
+	sender    bool
+}
+
+// KeySchedule (RFC 9180, 5.1)
+func (s Suite) keySchedule(mode uint8, sharedSecret, info, psk, pskID []byte) (*Context, error) {
+	if (len(psk) == 0) != (len(pskID) == 0) {
+		return nil, errors.New("hpke: inconsistent PSK inputs")
+	}
+	if (mode == modeBase) != (len(psk) == 0) {
+		return nil, errors.New("hpke: PSK input provided when not needed, or missing")
+	}
+
+	kdf := labeledKDF{h: s.KDF.hash(), suiteID: s.suiteID()}
+	pskIDHash, err := kdf.extract(nil, "psk_id_hash", pskID)
+	if err != nil {
+		return nil, err
+	}
+	infoHash, err := kdf.extract(nil, "info_hash", info)
+	if err != nil {
+		return nil, err
+	}
+	ksContext := append([]byte{mode}, pskIDHash...)
+	ksContext = append(ksContext, infoHash...)
+
+	secret, err := kdf.extract(sharedSecret, "secret", psk)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &Context{kdf: kdf}
+	if ctx.exporter, err = kdf.expand(secret, "exp", ksContext, kdf.h().Size()); err != nil {
+		return nil, err
+	}
+	if s.AEAD == AEAD_ExportOnly {
+		return ctx, nil
+	}
+
+	nk, nn := s.AEAD.sizes()
+	key, err := kdf.expand(secret, "key", ksContext, nk)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.baseNonce, err = kdf.expand(secret, "base_nonce", ksContext, nn); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.aead, err = cipher.NewGCM(block); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+func (s Suite) setupS(rng io.Reader, mode uint8, pkR *sidh.PublicKey, info, psk, pskID []byte) ([]byte, *Context, error) {
+	kem, err := s.check()
+	if err != nil {
+		return nil, nil, err
+	}
+	ss, enc, err := kem.Encap(rng, pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := s.keySchedule(mode, ss, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx.sender = true
+	return enc, ctx, nil
+}
+
+func (s Suite) setupR(mode uint8, enc []byte, skR *sidh.PrivateKey, pkR *sidh.PublicKey, info, psk, pskID []byte) (*Context, error) {
+	kem, err := s.check()
+	if err != nil {
+		return nil, err
+	}
+	ss, err := kem.Decap(enc, skR, pkR)
+	if err != nil {
+		return nil, err
+	}
+	return s.keySchedule(mode, ss, info, psk, pskID)
+}
+
+// SetupBaseS creates context of the sender in base mode. Returns encapsulated
+// key which must be sent to the recipient.
+func (s Suite) SetupBaseS(rng io.Reader, pkR *sidh.PublicKey, info []byte) (enc []byte, ctx *Context, err error) {
+	return s.setupS(rng, modeBase, pkR, info, nil, nil)
+}
+
+// SetupBaseR creates context of the recipient in base mode
+func (s Suite) SetupBaseR(enc []byte, skR *sidh.PrivateKey, pkR *sidh.PublicKey, info []byte) (*Context, error) {
+	return s.setupR(modeBase, enc, skR, pkR, info, nil, nil)
+}
+
+// SetupPSKS creates context of the sender in PSK mode
+func (s Suite) SetupPSKS(rng io.Reader, pkR *sidh.PublicKey, info, psk, pskID []byte) (enc []byte, ctx *Context, err error) {
+	return s.setupS(rng, modePSK, pkR, info, psk, pskID)
+}
+
+// SetupPSKR creates context of the recipient in PSK mode
+func (s Suite) SetupPSKR(enc []byte, skR *sidh.PrivateKey, pkR *sidh.PublicKey, info, psk, pskID []byte) (*Context, error) {
+	return s.setupR(modePSK, enc, skR, pkR, info, psk, pskID)
+}
+
+// Returns nonce for the current sequence number
+func (c *Context) nonce() []byte {
+	nonce := append([]byte{}, c.baseNonce...)
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], c.seq)
+	for i := range seq {
+		nonce[len(nonce)-len(seq)+i] ^= seq[i]
+	}
+	return nonce
+}
+
+// Seal encrypts and authenticates pt with additional data aad
+func (c *Context) Seal(aad, pt []byte) ([]byte, error) {
+	if c.aead == nil || !c.sender {
+		return nil, errors.New("hpke: context can't seal")
+	}
+	if c.seq == ^uint64(0) {
+		return nil, errors.New("hpke: message limit reached")
+	}
+	ct := c.aead.Seal(nil, c.nonce(), pt, aad)
+	c.seq++
+	return ct, nil
+}
+
+// Open decrypts ct with additional data aad. Returns ErrOpen if ct is not
+// authentic.
+func (c *Context) Open(aad, ct []byte) ([]byte, error) {
+	if c.aead == nil || c.sender {
+		return nil, errors.New("hpke: context can't open")
+	}
+	if c.seq == ^uint64(0) {
+		return nil, errors.New("hpke: message limit reached")
+	}
+	pt, err := c.aead.Open(nil, c.nonce(), ct, aad)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	c.seq++
+	return pt, nil
+}
+
+// Export derives secret of given length from the context. Fails if length
+// is greater than 255 times the output size of the hash function of the KDF.
+func (c *Context) Export(exporterContext []byte, length int) ([]byte, error) {
+	return c.kdf.expand(c.exporter, "sec", exporterContext, length)
+}
+
+// SealBase encrypts pt to pkR in base mode, in a single call
+func (s Suite) SealBase(rng io.Reader, pkR *sidh.PublicKey, info, aad, pt []byte) (enc, ct []byte, err error) {
+	enc, ctx, err := s.SetupBaseS(rng, pkR, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err = ctx.Seal(aad, pt)
+	return enc, ct, err
+}
+
+// OpenBase decrypts ciphertext produced by SealBase
+func (s Suite) OpenBase(enc []byte, skR *sidh.PrivateKey, pkR *sidh.PublicKey, info, aad, ct []byte) ([]byte, error) {
+	ctx, err := s.SetupBaseR(enc, skR, pkR, info)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Open(aad, ct)
+}
+
+// SealPSK encrypts pt to pkR in PSK mode, in a single call
+func (s Suite) SealPSK(rng io.Reader, pkR *sidh.PublicKey, info, aad, pt, psk, pskID []byte) (enc, ct []byte, err error) {
+	enc, ctx, err := s.SetupPSKS(rng, pkR, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err = ctx.Seal(aad, pt)
+	return enc, ct, err
+}
+
+// OpenPSK decrypts ciphertext produced by SealPSK
+func (s Suite) OpenPSK(enc []byte, skR *sidh.PrivateKey, pkR *sidh.PublicKey, info, aad, ct, psk, pskID []byte) ([]byte, error) {
+	ctx, err := s.SetupPSKR(enc, skR, pkR, info, psk, pskID)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Open(aad, ct)
+}
@@ -0,0 +1,255 @@
+//go:build go1.24
+// +build go1.24
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/sidh/internal/drbg"
+	"github.com/cloudflare/sidh/sidh"
+)
+
+func mustDecode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Key schedule, encryption and export are checked with test vector A.1.1 of
+// RFC 9180 (DHKEM(X25519, HKDF-SHA256), HKDF-SHA256, AES-128-GCM, base mode),
+// starting from the shared secret of the KEM.
+func TestKeyScheduleRFC9180(t *testing.T) {
+	s := Suite{KEM: 0x0020, KDF: KDF_HKDF_SHA256, AEAD: AEAD_AES128GCM}
+	ss := mustDecode(t, "fe0e18c9f024ce43799ae393c7e8fe8fce9d218875e8227b0187c04e7d2ea1fc")
+	info := mustDecode(t, "4f6465206f6e2061204772656369616e2055726e")
+	ctx, err := s.keySchedule(modeBase, ss, info, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.sender = true
+	if !bytes.Equal(ctx.baseNonce, mustDecode(t, "56d890e5accaaf011cff4b7d")) {
+		t.Errorf("wrong base_nonce: %x", ctx.baseNonce)
+	}
+	if !bytes.Equal(ctx.exporter, mustDecode(t, "45ff1c2e220db587171952c0592d5f5ebe103f1561a2614e38f2ffd47e99e3f8")) {
+		t.Errorf("wrong exporter_secret: %x", ctx.exporter)
+	}
+	ct, err := ctx.Seal(mustDecode(t, "436f756e742d30"),
+		mustDecode(t, "4265617574792069732074727574682c20747275746820626561757479"))
+	if err != nil || !bytes.Equal(ct, mustDecode(t, "f938558b5d72f1a23810b4be2ab4f84331acc02fc97babc53a52ae8218a355a96d8770ac83d07bea87e13c512a")) {
+		t.Errorf("wrong ciphertext: %x", ct)
+	}
+	exp, err := ctx.Export(nil, 32)
+	if err != nil || !bytes.Equal(exp, mustDecode(t, "3853fe2b4035195a573ffc53856e77058e15d9ea064de3e59f4961d0095250ee")) {
+		t.Errorf("wrong exported value: %x", exp)
+	}
+}
+
+var suites = []Suite{
+	{KEM_SIKEp434, KDF_HKDF_SHA256, AEAD_AES128GCM},
+	{KEM_SIKEp503, KDF_HKDF_SHA256, AEAD_AES256GCM},
+	{KEM_SIKEp610, KDF_HKDF_SHA384, AEAD_AES256GCM},
+	{KEM_SIKEp751, KDF_HKDF_SHA384, AEAD_AES256GCM},
+	{KEM_SIKEp964, KDF_HKDF_SHA512, AEAD_AES256GCM},
+}
+
+func generateKeyPair(t *testing.T, kem KEMID) (*sidh.PrivateKey, *sidh.PublicKey) {
+	k, err := NewKEM(kem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prv, pub, err := k.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prv, pub
+}
+
+func TestSealOpen(t *testing.T) {
+	info, aad := []byte("config v1"), []byte("aad")
+	psk, pskID := bytes.Repeat([]byte{0x42}, 32), []byte("psk id")
+	for _, s := range suites {
+		prv, pub := generateKeyPair(t, s.KEM)
+		// Messages of different lengths
+		for _, n := range []int{0, 1, 4097} {
+			pt := make([]byte, n)
+			rand.Read(pt)
+
+			enc, ct, err := s.SealBase(rand.Reader, pub, info, aad, pt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.OpenBase(enc, prv, pub, info, aad, ct)
+			if err != nil || !bytes.Equal(got, pt) {
+				t.Errorf("%04x: base mode roundtrip failed", s.KEM)
+			}
+
+			enc, ct, err = s.SealPSK(rand.Reader, pub, info, aad, pt, psk, pskID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err = s.OpenPSK(enc, prv, pub, info, aad, ct, psk, pskID)
+			if err != nil || !bytes.Equal(got, pt) {
+				t.Errorf("%04x: PSK mode roundtrip failed", s.KEM)
+			}
+			if _, err = s.OpenPSK(enc, prv, pub, info, aad, ct, psk[1:], pskID); err != ErrOpen {
+				t.Errorf("%04x: wrong PSK accepted", s.KEM)
+			}
+		}
+	}
+}
+
+// Context is used for multiple messages and secrets exported by both sides
+// are the same
+func TestContext(t *testing.T) {
+	s := suites[0]
+	prv, pub := generateKeyPair(t, s.KEM)
+	enc, sender, err := s.SetupBaseS(rand.Reader, pub, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver, err := s.SetupBaseR(enc, prv, pub, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cts [][]byte
+	for i := 0; i < 3; i++ {
+		ct, err := sender.Seal(nil, []byte{byte(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cts = append(cts, ct)
+	}
+	// Messages must be opened in order
+	if _, err = receiver.Open(nil, cts[1]); err != ErrOpen {
+		t.Error("message out of order accepted")
+	}
+	for i, ct := range cts {
+		pt, err := receiver.Open(nil, ct)
+		if err != nil || !bytes.Equal(pt, []byte{byte(i)}) {
+			t.Errorf("can't open message %d", i)
+		}
+	}
+
+	exp1, err1 := sender.Export([]byte("ctx"), 50)
+	exp2, err2 := receiver.Export([]byte("ctx"), 50)
+	if err1 != nil || err2 != nil || !bytes.Equal(exp1, exp2) {
+		t.Error("exported secrets differ")
+	}
+	// At most 255 blocks of HKDF-Expand, for any of the hash functions
+	if _, err = sender.Export(nil, 255*64+1); err == nil {
+		t.Error("export of too long secret succeeded")
+	}
+	if _, err = receiver.Seal(nil, nil); err == nil {
+		t.Error("context of the recipient can seal")
+	}
+	if _, err = sender.Open(nil, cts[0]); err == nil {
+		t.Error("context of the sender can open")
+	}
+}
+
+func TestExportOnly(t *testing.T) {
+	s := Suite{KEM_SIKEp434, KDF_HKDF_SHA256, AEAD_ExportOnly}
+	prv, pub := generateKeyPair(t, s.KEM)
+	enc, sender, err := s.SetupBaseS(rand.Reader, pub, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver, err := s.SetupBaseR(enc, prv, pub, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp1, err1 := sender.Export(nil, 32)
+	exp2, err2 := receiver.Export(nil, 32)
+	if err1 != nil || err2 != nil || !bytes.Equal(exp1, exp2) {
+		t.Error("exported secrets differ")
+	}
+	if _, err = sender.Seal(nil, nil); err == nil {
+		t.Error("export-only context can seal")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := suites[0]
+	prv, pub := generateKeyPair(t, s.KEM)
+	_, pub503 := generateKeyPair(t, KEM_SIKEp503)
+
+	for _, bad := range []Suite{
+		{0x0020, KDF_HKDF_SHA256, AEAD_AES128GCM},
+		{KEM_SIKEp434, 0x0004, AEAD_AES128GCM},
+		{KEM_SIKEp434, KDF_HKDF_SHA256, 0x0003},
+	} {
+		if _, _, err := bad.SealBase(rand.Reader, pub, nil, nil, nil); err != ErrUnsupportedSuite {
+			t.Errorf("unsupported suite %v accepted", bad)
+		}
+	}
+	if _, _, err := s.SealBase(rand.Reader, pub503, nil, nil, nil); err == nil {
+		t.Error("public key for other KEM accepted")
+	}
+	if _, _, err := s.SealPSK(rand.Reader, pub, nil, nil, nil, []byte("psk"), nil); err == nil {
+		t.Error("PSK without identifier accepted")
+	}
+	if _, _, err := s.SealPSK(rand.Reader, pub, nil, nil, nil, nil, nil); err == nil {
+		t.Error("PSK mode without PSK accepted")
+	}
+
+	enc, ct, err := s.SealBase(rand.Reader, pub, []byte("info"), nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.OpenBase(enc, prv, pub, []byte("other"), nil, ct); err != ErrOpen {
+		t.Error("wrong info accepted")
+	}
+	ct[0] ^= 1
+	if _, err = s.OpenBase(enc, prv, pub, []byte("info"), nil, ct); err != ErrOpen {
+		t.Error("modified ciphertext accepted")
+	}
+	if _, err = s.OpenBase(enc[1:], prv, pub, []byte("info"), nil, ct); err == nil {
+		t.Error("encapsulated key of wrong size accepted")
+	}
+	prv503, _ := generateKeyPair(t, KEM_SIKEp503)
+	if _, err = s.OpenBase(enc, prv503, pub, []byte("info"), nil, ct); err == nil {
+		t.Error("private key for other KEM accepted")
+	}
+	if _, err = s.OpenBase(enc, nil, pub, []byte("info"), nil, ct); err == nil {
+		t.Error("missing private key accepted")
+	}
+}
+
+// Output is deterministic for deterministic source of randomness
+func TestDeterministic(t *testing.T) {
+	s := suites[0]
+	seed := make([]byte, drbg.SeedSize)
+	k, _ := NewKEM(s.KEM)
+	_, pub, err := k.GenerateKeyPair(drbg.New(seed, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc1, ct1, err := s.SealBase(drbg.New(seed, nil), pub, nil, nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc2, ct2, err := s.SealBase(drbg.New(seed, nil), pub, nil, nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc1, enc2) || !bytes.Equal(ct1, ct2) {
+		t.Error("output is not deterministic")
+	}
+}
+
+func BenchmarkSealBase(b *testing.B) {
+	s := suites[0]
+	k, _ := NewKEM(s.KEM)
+	_, pub, _ := k.GenerateKeyPair(rand.Reader)
+	pt := make([]byte, 1024)
+	for i := 0; i < b.N; i++ {
+		s.SealBase(rand.Reader, pub, nil, nil, pt)
+	}
+}
@@ -0,0 +1,304 @@
+// Package keyshare implements TLS 1.3 key shares (RFC 8446, 4.2.8) for
+// experimental named groups, which combine X25519 with SIDH or SIKE. It is
+// meant for forks of crypto/tls, which only need to put and get bytes of the
+// key_share extension and feed the shared secret to the key schedule.
+//
+// Contents of key_exchange field of KeyShareEntry:
+//
+//	X25519SIDHp503  client: X25519 public key || SIDH public key (variant A)
+//	                server: X25519 public key || SIDH public key (variant B)
+//	X25519SIKEp503  client: X25519 public key || SIKE public key
+//	                server: X25519 public key || SIKE ciphertext
+//
+// Shared secret is X25519 shared secret || SIDH (or SIKE) shared secret,
+// as used by the experimental implementations.
+package keyshare
+
+import (
+	"crypto/ecdh"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+)
+
+// Group is a TLS 1.3 NamedGroup codepoint
+type Group uint16
+
+// Codepoints from the range reserved for private use, as used by the
+// Cloudflare post-quantum TLS experiments (X25519SIKEp503 is the CECPQ2b
+// style hybrid).
+const (
+	X25519SIDHp503 Group = 0xFE30
+	X25519SIKEp503 Group = 0xFE32
+)
+
+const x25519Size = 32
+
+// Parameters of the named group
+type groupParams struct {
+	name string
+	id   uint8
+	// true if SIKE KEM is used, otherwise SIDH key agreement
+	kem bool
+}
+
+var groups = map[Group]groupParams{
+	X25519SIDHp503: {"X25519SIDHp503", sidh.FP_503, false},
+	X25519SIKEp503: {"X25519SIKEp503", sidh.FP_503, true},
+}
+
+func (g Group) String() string {
+	if p, ok := groups[g]; ok {
+		return p.name
+	}
+	return fmt.Sprintf("Group(%#04x)", uint16(g))
+}
+
+// Supported returns true if the package implements key exchange for g
+func (g Group) Supported() bool {
+	_, ok := groups[g]
+	return ok
+}
+
+// Sizes of client's and server's key_exchange for supported group
+func (g Group) shareSizes() (client, server int) {
+	p := groups[g]
+	params := sidh.Params(p.id)
+	client = x25519Size + params.PublicKeySize
+	server = client
+	if p.kem {
+		server += params.MsgLen
+	}
+	return
+}
+
+// KeyShare is a KeyShareEntry
+type KeyShare struct {
+	Group       Group
+	KeyExchange []byte
+}
+
+// Appends encoded KeyShareEntry to b
+func appendKeyShare(b []byte, ks KeyShare) ([]byte, error) {
+	if len(ks.KeyExchange) == 0 || len(ks.KeyExchange) > 0xFFFF {
+		return nil, errors.New("keyshare: key_exchange has wrong size")
+	}
+	b = append(b, byte(ks.Group>>8), byte(ks.Group))
+	b = append(b, byte(len(ks.KeyExchange)>>8), byte(len(ks.KeyExchange)))
+	return append(b, ks.KeyExchange...), nil
+}
+
+// Reads KeyShareEntry from b, returns the rest of input
+func readKeyShare(b []byte) (KeyShare, []byte, error) {
+	var ks KeyShare
+	if len(b) < 4 {
+		return ks, nil, errors.New("keyshare: truncated KeyShareEntry")
+	}
+	ks.Group = Group(binary.BigEndian.Uint16(b))
+	n := int(binary.BigEndian.Uint16(b[2:]))
+	b = b[4:]
+	if n == 0 || len(b) < n {
+		return ks, nil, errors.New("keyshare: wrong length of key_exchange")
+	}
+	ks.KeyExchange = append([]byte(nil), b[:n]...)
+	return ks, b[n:], nil
+}
+
+// MarshalClientHello returns extension_data of key_share extension in
+// ClientHello (KeyShareClientHello structure), carrying given shares.
+func MarshalClientHello(shares []KeyShare) ([]byte, error) {
+	var err error
+	b := []byte{0, 0}
+	for _, ks := range shares {
+		if b, err = appendKeyShare(b, ks); err != nil {
+			return nil, err
+		}
+	}
+	if len(b)-2 > 0xFFFF {
+		return nil, errors.New("keyshare: client_shares too long")
+	}
+	binary.BigEndian.PutUint16(b, uint16(len(b)-2))
+	return b, nil
+}
+
+// ParseClientHello parses extension_data of key_share extension in
+// ClientHello. Shares for all groups are returned, including not supported
+// ones. Error is returned if input is malformed or a group is offered twice.
+func ParseClientHello(data []byte) ([]KeyShare, error) {
+	var shares []KeyShare
+	if len(data) < 2 || int(binary.BigEndian.Uint16(data)) != len(data)-2 {
+		return nil, errors.New("keyshare: wrong length of client_shares")
+	}
+	seen := make(map[Group]bool)
+	for b := data[2:]; len(b) != 0; {
+		var ks KeyShare
+		var err error
+		if ks, b, err = readKeyShare(b); err != nil {
+			return nil, err
+		}
+		if seen[ks.Group] {
+			return nil, errors.New("keyshare: group offered more than once")
+		}
+		seen[ks.Group] = true
+		shares = append(shares, ks)
+	}
+	return shares, nil
+}
+
+// MarshalServerHello returns extension_data of key_share extension in
+// ServerHello (KeyShareServerHello structure).
+func MarshalServerHello(share KeyShare) ([]byte, error) {
+	return appendKeyShare(nil, share)
+}
+
+// ParseServerHello parses extension_data of key_share extension in
+// ServerHello.
+func ParseServerHello(data []byte) (KeyShare, error) {
+	ks, rest, err := readKeyShare(data)
+	if err == nil && len(rest) != 0 {
+		err = errors.New("keyshare: trailing data after KeyShareEntry")
+	}
+	return ks, err
+}
+
+// ClientKey holds client's ephemeral keys for a group
+type ClientKey struct {
+	group Group
+	x     *ecdh.PrivateKey
+	prv   *sidh.PrivateKey
+	pub   *sidh.PublicKey
+}
+
+// Generates X25519 key from 32 bytes read from rng
+func generateX25519(rng io.Reader) (*ecdh.PrivateKey, error) {
+	var seed [x25519Size]byte
+	if _, err := io.ReadFull(rng, seed[:]); err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPrivateKey(seed[:])
+}
+
+// Returns X25519 shared secret with peer's key from first 32 bytes of share
+func x25519Secret(x *ecdh.PrivateKey, share []byte) ([]byte, error) {
+	peer, err := ecdh.X25519().NewPublicKey(share[:x25519Size])
+	if err != nil {
+		return nil, err
+	}
+	return x.ECDH(peer)
+}
+
+// GenerateClientKey generates ephemeral keys of the client for group. X25519
+// key is generated from the first 32 bytes read from rng, hence output is
+// deterministic for deterministic rng.
+func GenerateClientKey(rng io.Reader, group Group) (*ClientKey, error) {
+	var err error
+	p, ok := groups[group]
+	if !ok {
+		return nil, errors.New("keyshare: group not supported")
+	}
+
+	c := &ClientKey{group: group}
+	if c.x, err = generateX25519(rng); err != nil {
+		return nil, err
+	}
+	variant := sidh.KeyVariant_SIDH_A
+	if p.kem {
+		variant = sidh.KeyVariant_SIKE
+	}
+	c.prv = sidh.NewPrivateKey(p.id, variant)
+	if err = c.prv.Generate(rng); err != nil {
+		return nil, err
+	}
+	c.pub = c.prv.GeneratePublicKey()
+	return c, nil
+}
+
+// KeyShare returns client's KeyShareEntry
+func (c *ClientKey) KeyShare() KeyShare {
+	return KeyShare{
+		Group:       c.group,
+		KeyExchange: append(c.x.PublicKey().Bytes(), c.pub.Export()...),
+	}
+}
+
+// SharedSecret returns shared secret for server's KeyShareEntry
+func (c *ClientKey) SharedSecret(server KeyShare) ([]byte, error) {
+	var ss []byte
+	p := groups[c.group]
+	if server.Group != c.group {
+		return nil, errors.New("keyshare: server selected different group")
+	}
+	if _, sz := c.group.shareSizes(); len(server.KeyExchange) != sz {
+		return nil, errors.New("keyshare: server's key_exchange has wrong size")
+	}
+
+	ssX, err := x25519Secret(c.x, server.KeyExchange)
+	if err != nil {
+		return nil, err
+	}
+	if p.kem {
+		ss, err = sike.Decapsulate(c.prv, c.pub, server.KeyExchange[x25519Size:])
+	} else {
+		pubB := sidh.NewPublicKey(p.id, sidh.KeyVariant_SIDH_B)
+		if err = pubB.Import(server.KeyExchange[x25519Size:]); err == nil {
+			ss, err = sidh.DeriveSecret(c.prv, pubB)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(ssX, ss...), nil
+}
+
+// ServerKeyShare generates server's KeyShareEntry and shared secret for
+// client's KeyShareEntry. As in GenerateClientKey, output is deterministic
+// for deterministic rng.
+func ServerKeyShare(rng io.Reader, client KeyShare) (KeyShare, []byte, error) {
+	var share, ss []byte
+	p, ok := groups[client.Group]
+	if !ok {
+		return KeyShare{}, nil, errors.New("keyshare: group not supported")
+	}
+	if sz, _ := client.Group.shareSizes(); len(client.KeyExchange) != sz {
+		return KeyShare{}, nil, errors.New("keyshare: client's key_exchange has wrong size")
+	}
+
+	x, err := generateX25519(rng)
+	if err != nil {
+		return KeyShare{}, nil, err
+	}
+	ssX, err := x25519Secret(x, client.KeyExchange)
+	if err != nil {
+		return KeyShare{}, nil, err
+	}
+
+	if p.kem {
+		pub := sidh.NewPublicKey(p.id, sidh.KeyVariant_SIKE)
+		if err = pub.Import(client.KeyExchange[x25519Size:]); err == nil {
+			share, ss, err = sike.Encapsulate(rng, pub)
+		}
+	} else {
+		pubA := sidh.NewPublicKey(p.id, sidh.KeyVariant_SIDH_A)
+		prvB := sidh.NewPrivateKey(p.id, sidh.KeyVariant_SIDH_B)
+		if err = pubA.Import(client.KeyExchange[x25519Size:]); err == nil {
+			err = prvB.Generate(rng)
+		}
+		if err == nil {
+			share = prvB.GeneratePublicKey().Export()
+			ss, err = sidh.DeriveSecret(prvB, pubA)
+		}
+	}
+	if err != nil {
+		return KeyShare{}, nil, err
+	}
+
+	server := KeyShare{
+		Group:       client.Group,
+		KeyExchange: append(x.PublicKey().Bytes(), share...),
+	}
+	return server, append(ssX, ss...), nil
+}
@@ -0,0 +1,171 @@
+package keyshare
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/sidh/internal/drbg"
+)
+
+// Test vectors generated with DRBG initialized with the seed of the first test
+// vector in NIST KAT files. Client's keys are generated first, then server's.
+const vectorSeed = "061550234D158C5EC95595FE04EF7A25767F2E24CC2BC479D09D86DC9ABCFDE7056A8C266F9EF97ED08541DBD2E1FFA1"
+
+var vectors = []struct {
+	group        Group
+	clientHello  string
+	serverHello  string
+	sharedSecret string
+}{
+	{
+		X25519SIDHp503,
+		"019EFE30019A39DF206EFF1518CCCC64873666C342593D0BBF46E96AD346D89D9245694E1112B88FE33D55B3A7322169F51A7B0263162C38BDCEF7E6B1A8DC8475B9CE65895593C96017E5851125AFDCDD07BCB39F78FB475787E3CF9AA072430157CB561AC4B26FEB4964A05D3F22801400B24EF0E56236E92E2B8A6DB2A4D736AC858B0ECEFF61D1587354B0A70870F2623B3AE9CC130BD12729077017B7B7B92F223B81B69912C329553E143DB09BD373562F5754666BA7A84F4B1EA96D319A374EC2FF5FEF74856EDA17A84DA5915299FA455C08E2E116793B1752AF648896780B7413CDC3C33C0628F6B3C906055E7F7819282C384CF1DAF4D144B1F45F4661E3F331E041D9069613D4537DA1EDA9B028C8792A30891031FB821B60D49E8D1EE767B54B77B2759452B41229CA0A259F4A5869661368FF0C8251A5DB7D62A53884F3F7BD9996E721A3CA8E37DDF97565C7623789F2C044A1C45CF816D5FB27DF720D70EDAD57E9223C352ED01A3CAA2EB3B02CF010C11D091DB07CAA6EF09BB0AF11D31993BDABCA131BE90139D5248E0A43E8C641A62EAFEB7363E2001A",
+		"FE30019AC1C79A9F9D6497B96AB5B67411C11795BC2AEB4F5EB90EC2FA7E845E7E0A7D58DE32DA318D1E86A4AE247D1D66683448CF90EB3CE19056E2C63F07DC557D0F5F707A6A9138782D5106AFDAE8CE5AF7F36776BAA4C8404491975C7F96EDE0089EC9C773FE9FF2922DA532A3FDA6F6974C53592D37E85AE0B8CF37237C524E3C3C3023192A3B600C7E3612751E8A245F12146E4366303C6EF0DF48999B3425F8A85515AA8568D0CB0795F45ECDACAD02A77F6BAA84BCA18941D52EEBEDFBF137A49813BF8F082F9B6E7C3B1BA822FA5181BB2FCF82B65869A9DC25BF5F3DBDEC42CFE013DCAE2B79FE154B43463AD95D69FEE2D08EF7D5AA7F9AAE2079B7021D27E333475F600B2EAE37B6DB8AE6502363919D767F08B609547B0A7A2AE01D3457B986698D0BA208EC27823A4E475511BEDAF42545FEEE0C495A34B861BBE917083D8C3BD29D81D7FF3C06DCE11B4BA950DBA801F2CDE50138F931290355C3817E801500FBE9F530D8C60803F96815DEFABB68AC30C95E1A0BA9C971DE6D92C7D629E0959F6E431A3142CBDEFEE00497FF9F36C586485B99964333",
+		"088060C4433722D8ADF800EE6FF918386491EF420B97E2221A5FFBD84A5A5176F5F3F3B2CD11E88C0A2FD3A6E7813E61237B225C9CA0BAEFCEDE4B4B38D6AEC281C441691E0B82355A2848862316A4760E6FF4DDED09ACB336E4854D4A552BDFD8458E2B1DA385CD8362200AA6FFDC6FB9CD71E7010CB03EF98F81CDD6CD2E56F79B0B171D12BDDC058804C7D76AE478582589FDCF477C87939FB6356B21"},
+	{
+		X25519SIKEp503,
+		"019EFE32019A39DF206EFF1518CCCC64873666C342593D0BBF46E96AD346D89D9245694E11122192F60E79C52DEC85BE9715C0106E4D5D6D03075656938BC444BC3780B759C83DE6D3C9AFA1DBB15705825A6CC2A0342964F3C5F27D4624C898CF8D0478341FF7E2A8BD747FF2562B5D9E786CAAC43EB28C5DD6DC50AF2D5436362D1AB3D4B12C83B50FDD8517C00C01BE9B46FF678EF57601DC3F17B93FC69D122D292A80A0898C3C9DB50788E4F783AFEEB710EED83670DF9F0E69BE1EF3465052DCF6DB9033734B6A42494B16F491AE861FBA669783E5E4776D21D13629CEE68B27787ED4EB8A01484676490B96152E82F1F5D0ACC844DA11E17DF608FE39D1236A4694284918C1F29A8CA8B808A85FD2A28B8F38A2CF7A1040D2C068A2EBD921F3726B9D90812A628920AD814A1326401386CD6CBE9A242346549813C40731FE15F50F6D2266F79EF08374894AE96EA91F920FEB9D64E7C29F5407041D342B152065F5E5204957137BDCE317BB4602BB8B4FCDAEB9BFF2AF54B099187168FF04658888FC7D3D3D594D41BB58A2B03C2BC609444D7E6836D8C386EEBCCC2B",
+		"FE3201B281F069AD038BD0BB3573281F228FD685DF624C552DFE096783E2BBB46C86944CF9AFAC79F3E8AEF2181A86CD26249C3D1A031FA7639681ADDFEB6EFB8B7763B5ABB471CBACE5249E2CADFA6A87549B126F7C55E60405D0E50EA4A3F2A32E0FA0F8B80EC2E0D21A4EE611799E16EAFFFAADA51C1F8F1A3140547725DD135089E10E59843A31CEFFA5FCA0F6E8A8092F776C46EE3A2F9BF58D249A6BB7AE2F43BEC55A12BD20FA5A3A5E3B8090A1D5CB1A294787BCCB0EB0F2A5516F3C8DE4FB64591865C5A30A1C76AA9A400B24185CE255F5185C563A28BEE81379180C3E4BC0FDB77C1EA155887607D610017D3A33F12E75DA56B9FC5C5818955A60E7099ADF4CEBB1DC71BB10D0350D82C7EA8C0F4153CEA0F3A7F68B4EB31D0834E913E6460D4E92A4E67BC15AD249D096DB568C6BCF18433329AE64120C7565F8E88D73D1C337E5177394B49FAF400A497B9E4E5719B82E7247A4741223A53ABC3B906186AD6F67632357D1159FCBFA2FD1299904601BD25E1131003B16DD44BD7DE7A20CF81A6C29356E51A4CFE0A506377958ECDB17FAC19A1948474A0A0E34E385DC672F7624FDBFC1BC96691BE53F73EDE2EB1FCC",
+		"18169C5C1C694D6791B8758A1577DDCF1436F683B8ED39B50A4EC0647FE4BB5CB70CCC6FA83F2922500EB07B85CB4317"},
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		rng := drbg.New(mustDecode(t, vectorSeed), nil)
+		c, err := GenerateClientKey(rng, v.group)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ch, err := MarshalClientHello([]KeyShare{c.KeyShare()})
+		if err != nil || !bytes.Equal(ch, mustDecode(t, v.clientHello)) {
+			t.Fatalf("%s: wrong ClientHello key share", v.group)
+		}
+
+		shares, err := ParseClientHello(ch)
+		if err != nil || len(shares) != 1 {
+			t.Fatalf("%s: can't parse ClientHello key share", v.group)
+		}
+		server, ss, err := ServerKeyShare(rng, shares[0])
+		if err != nil || !bytes.Equal(ss, mustDecode(t, v.sharedSecret)) {
+			t.Fatalf("%s: wrong server's shared secret", v.group)
+		}
+		sh, err := MarshalServerHello(server)
+		if err != nil || !bytes.Equal(sh, mustDecode(t, v.serverHello)) {
+			t.Fatalf("%s: wrong ServerHello key share", v.group)
+		}
+
+		server, err = ParseServerHello(sh)
+		if err != nil {
+			t.Fatalf("%s: can't parse ServerHello key share", v.group)
+		}
+		ss, err = c.SharedSecret(server)
+		if err != nil || !bytes.Equal(ss, mustDecode(t, v.sharedSecret)) {
+			t.Fatalf("%s: wrong client's shared secret", v.group)
+		}
+	}
+}
+
+// Key shares for other groups are passed through
+func TestClientHelloOtherGroups(t *testing.T) {
+	c, err := GenerateClientKey(rand.Reader, X25519SIKEp503)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x25519 := KeyShare{Group: 0x001D, KeyExchange: bytes.Repeat([]byte{9}, 32)}
+	ch, err := MarshalClientHello([]KeyShare{x25519, c.KeyShare()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := ParseClientHello(ch)
+	if err != nil || len(shares) != 2 {
+		t.Fatal("can't parse ClientHello key share")
+	}
+	if shares[0].Group.Supported() || !shares[1].Group.Supported() {
+		t.Error("wrong groups")
+	}
+	if shares[0].Group.String() != "Group(0x001d)" || shares[1].Group.String() != "X25519SIKEp503" {
+		t.Errorf("wrong names of groups: %s, %s", shares[0].Group, shares[1].Group)
+	}
+	if !bytes.Equal(shares[0].KeyExchange, x25519.KeyExchange) {
+		t.Error("wrong key_exchange")
+	}
+	if _, _, err = ServerKeyShare(rand.Reader, shares[0]); err == nil {
+		t.Error("key exchange for unsupported group succeeded")
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"00",
+		// wrong length of client_shares
+		"0006FE30000100",
+		// empty key_exchange
+		"0004FE300000",
+		// truncated key_exchange
+		"0006FE30000300AA",
+		// group offered twice
+		"000AFE300001AAFE300001BB",
+	} {
+		if _, err := ParseClientHello(mustDecode(t, s)); err == nil {
+			t.Errorf("malformed ClientHello key share %s accepted", s)
+		}
+	}
+	for _, s := range []string{"", "FE30", "FE300000", "FE300001AABB", "FE300002AA"} {
+		if _, err := ParseServerHello(mustDecode(t, s)); err == nil {
+			t.Errorf("malformed ServerHello key share %s accepted", s)
+		}
+	}
+	if _, err := MarshalServerHello(KeyShare{Group: X25519SIKEp503}); err == nil {
+		t.Error("empty key_exchange accepted")
+	}
+}
+
+func TestWrongShares(t *testing.T) {
+	for g := range groups {
+		c, err := GenerateClientKey(rand.Reader, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ks := c.KeyShare()
+		ks.KeyExchange = ks.KeyExchange[1:]
+		if _, _, err = ServerKeyShare(rand.Reader, ks); err == nil {
+			t.Errorf("%s: client's share of wrong size accepted", g)
+		}
+
+		server, _, err := ServerKeyShare(rand.Reader, c.KeyShare())
+		if err != nil {
+			t.Fatal(err)
+		}
+		server.Group ^= 2
+		if _, err = c.SharedSecret(server); err == nil {
+			t.Errorf("%s: server's share for other group accepted", g)
+		}
+		server.Group ^= 2
+		server.KeyExchange = server.KeyExchange[1:]
+		if _, err = c.SharedSecret(server); err == nil {
+			t.Errorf("%s: server's share of wrong size accepted", g)
+		}
+	}
+	if _, err := GenerateClientKey(rand.Reader, 0x001D); err == nil {
+		t.Error("unsupported group accepted")
+	}
+}
+
+func BenchmarkKeyExchange(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		c, _ := GenerateClientKey(rand.Reader, X25519SIKEp503)
+		s, _, _ := ServerKeyShare(rand.Reader, c.KeyShare())
+		c.SharedSecret(s)
+	}
+}
@@ -0,0 +1,284 @@
+//go:build go1.24
+// +build go1.24
+
+// Package mlkemsike implements hybrid key encapsulation mechanism, which
+// combines ML-KEM (FIPS 203) with SIKE. It is meant for migration of existing
+// SIKE deployments: SIKE is no longer considered secure on its own, so
+// security of the hybrid rests on ML-KEM, while peers which only support SIKE
+// keep working until SIKE is removed.
+//
+// ML-KEM-768 is used with SIKE parameter sets of NIST security level 1 and 3,
+// ML-KEM-1024 with parameter sets of level 5.
+//
+// Encodings (all parts are concatenated, sizes are fixed for a parameter set):
+//
+//	public key:  ML-KEM encapsulation key || SIKE public key
+//	private key: ML-KEM seed (64 bytes) || SIKE private key (s || sk)
+//	ciphertext:  ML-KEM ciphertext || SIKE ciphertext
+//
+// Shared secret is an output of the combiner:
+//
+//	cSHAKE256(ML-KEM shared secret || SIKE shared secret || ciphertext || public key,
+//	          L = 256, N = "", S = name of the hybrid, e.g. "MLKEM768-SIKEp434")
+//
+// Peers which only support SIKE use SIKE part of the public key (see
+// PublicKey.SIKE) with sike.Encapsulate. Their ciphertexts are decapsulated
+// with DecapsulateSIKE and resulting shared secrets rely on SIKE only.
+package mlkemsike
+
+import (
+	"crypto/mlkem"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+	cshake "github.com/henrydcase/nobs/hash/sha3"
+)
+
+const (
+	// SeedSize is the size of ML-KEM part of the private key
+	SeedSize = mlkem.SeedSize
+	// SharedSecretSize is the size of the shared secret produced by the KEM
+	SharedSecretSize = 32
+)
+
+// Methods common to ML-KEM-768 and ML-KEM-1024 keys
+type encapsulationKey interface {
+	Bytes() []byte
+	Encapsulate() (sharedKey, ciphertext []byte)
+}
+
+type decapsulationKey interface {
+	Bytes() []byte
+	Decapsulate(ciphertext []byte) (sharedKey []byte, err error)
+}
+
+// ML-KEM parameter set
+type mlkemParams struct {
+	name           string
+	ekSize, ctSize int
+	newDK          func(seed []byte) (decapsulationKey, encapsulationKey, error)
+	newEK          func(b []byte) (encapsulationKey, error)
+}
+
+var mlkem768 = mlkemParams{
+	name:   "MLKEM768",
+	ekSize: mlkem.EncapsulationKeySize768,
+	ctSize: mlkem.CiphertextSize768,
+	newDK: func(seed []byte) (decapsulationKey, encapsulationKey, error) {
+		dk, err := mlkem.NewDecapsulationKey768(seed)
+		if err != nil {
+			return nil, nil, err
+		}
+		return dk, dk.EncapsulationKey(), nil
+	},
+	newEK: func(b []byte) (encapsulationKey, error) {
+		return mlkem.NewEncapsulationKey768(b)
+	},
+}
+
+var mlkem1024 = mlkemParams{
+	name:   "MLKEM1024",
+	ekSize: mlkem.EncapsulationKeySize1024,
+	ctSize: mlkem.CiphertextSize1024,
+	newDK: func(seed []byte) (decapsulationKey, encapsulationKey, error) {
+		dk, err := mlkem.NewDecapsulationKey1024(seed)
+		if err != nil {
+			return nil, nil, err
+		}
+		return dk, dk.EncapsulationKey(), nil
+	},
+	newEK: func(b []byte) (encapsulationKey, error) {
+		return mlkem.NewEncapsulationKey1024(b)
+	},
+}
+
+// Returns ML-KEM parameter set used with SIKE parameter set id
+func mlkemFor(id uint8) *mlkemParams {
+	if sidh.Params(id).SecurityLevel >= 5 {
+		return &mlkem1024
+	}
+	return &mlkem768
+}
+
+// PublicKey is a hybrid public key
+type PublicKey struct {
+	id   uint8
+	ml   encapsulationKey
+	sike *sidh.PublicKey
+}
+
+// PrivateKey is a hybrid private key
+type PrivateKey struct {
+	id   uint8
+	ml   decapsulationKey
+	mlEK encapsulationKey
+	sike *sidh.PrivateKey
+}
+
+// NewPublicKey allocates public key for SIKE parameter set id. Panics if id
+// is not supported.
+func NewPublicKey(id uint8) *PublicKey {
+	return &PublicKey{id: id, sike: sidh.NewPublicKey(id, sidh.KeyVariant_SIKE)}
+}
+
+// NewPrivateKey allocates private key for SIKE parameter set id. Panics if id
+// is not supported.
+func NewPrivateKey(id uint8) *PrivateKey {
+	return &PrivateKey{id: id, sike: sidh.NewPrivateKey(id, sidh.KeyVariant_SIKE)}
+}
+
+// Name returns name of the hybrid scheme, e.g. "MLKEM768-SIKEp434"
+func Name(id uint8) string {
+	return mlkemFor(id).name + "-" + sidh.Params(id).Name
+}
+
+// CiphertextSize returns size of the ciphertext for parameter set id
+func CiphertextSize(id uint8) int {
+	params := sidh.Params(id)
+	return mlkemFor(id).ctSize + params.PublicKeySize + params.MsgLen
+}
+
+// Size returns size of the encoded public key
+func (pub *PublicKey) Size() int {
+	return mlkemFor(pub.id).ekSize + pub.sike.Size()
+}
+
+// Import decodes public key. Input must be exactly Size() bytes long.
+func (pub *PublicKey) Import(input []byte) error {
+	ml := mlkemFor(pub.id)
+	if len(input) != pub.Size() {
+		return errors.New("mlkemsike: input has wrong size")
+	}
+	ek, err := ml.newEK(input[:ml.ekSize])
+	if err != nil {
+		return err
+	}
+	if err = pub.sike.Import(input[ml.ekSize:]); err != nil {
+		return err
+	}
+	pub.ml = ek
+	return nil
+}
+
+// Export returns encoding of the public key
+func (pub *PublicKey) Export() []byte {
+	return append(pub.ml.Bytes(), pub.sike.Export()...)
+}
+
+// SIKE returns SIKE part of the public key, for peers which don't support
+// the hybrid.
+func (pub *PublicKey) SIKE() *sidh.PublicKey {
+	return pub.sike
+}
+
+// Size returns size of the encoded private key
+func (prv *PrivateKey) Size() int {
+	return SeedSize + prv.sike.Size()
+}
+
+// Import decodes private key. Input must be exactly Size() bytes long.
+func (prv *PrivateKey) Import(input []byte) error {
+	if len(input) != prv.Size() {
+		return errors.New("mlkemsike: input has wrong size")
+	}
+	dk, ek, err := mlkemFor(prv.id).newDK(input[:SeedSize])
+	if err != nil {
+		return err
+	}
+	if err = prv.sike.Import(input[SeedSize:]); err != nil {
+		return err
+	}
+	prv.ml, prv.mlEK = dk, ek
+	return nil
+}
+
+// Export returns encoding of the private key
+func (prv *PrivateKey) Export() []byte {
+	return append(prv.ml.Bytes(), prv.sike.Export()...)
+}
+
+// Generate generates private key. ML-KEM seed is taken directly from the
+// first 64 bytes read from rng, hence output is deterministic for
+// deterministic rng.
+func (prv *PrivateKey) Generate(rng io.Reader) error {
+	var seed [SeedSize]byte
+	if _, err := io.ReadFull(rng, seed[:]); err != nil {
+		return err
+	}
+	dk, ek, err := mlkemFor(prv.id).newDK(seed[:])
+	if err != nil {
+		return err
+	}
+	if err = prv.sike.Generate(rng); err != nil {
+		return err
+	}
+	prv.ml, prv.mlEK = dk, ek
+	return nil
+}
+
+// GeneratePublicKey returns public key corresponding to the private key
+func (prv *PrivateKey) GeneratePublicKey() *PublicKey {
+	return &PublicKey{id: prv.id, ml: prv.mlEK, sike: prv.sike.GeneratePublicKey()}
+}
+
+// Derives final shared secret from the shared secrets of the components
+func combine(id uint8, ssML, ssSIKE, ctext []byte, pub *PublicKey) []byte {
+	var ss = make([]byte, SharedSecretSize)
+	h := cshake.NewCShake256(nil, []byte(Name(id)))
+	h.Write(ssML)
+	h.Write(ssSIKE)
+	h.Write(ctext)
+	h.Write(pub.Export())
+	h.Read(ss)
+	return ss
+}
+
+// Encapsulate generates ciphertext and shared secret for the public key. rng
+// is used by sike.Encapsulate, ML-KEM encapsulation always uses randomness
+// from crypto/rand.
+func Encapsulate(rng io.Reader, pub *PublicKey) (ctext []byte, secret []byte, err error) {
+	if pub.ml == nil {
+		return nil, nil, errors.New("mlkemsike: public key not initialized")
+	}
+	ssML, ctML := pub.ml.Encapsulate()
+	ctSIKE, ssSIKE, err := sike.Encapsulate(rng, pub.sike)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctext = append(ctML, ctSIKE...)
+	return ctext, combine(pub.id, ssML, ssSIKE, ctext, pub), nil
+}
+
+// Decapsulate returns shared secret for the hybrid ciphertext. As in
+// sike.Decapsulate and ML-KEM, result is an unpredictable value if ciphertext
+// doesn't verify. Error is returned if input is wrongly formatted.
+func Decapsulate(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error) {
+	if prv.id != pub.id || prv.ml == nil || pub.ml == nil {
+		return nil, errors.New("mlkemsike: public and private are incompatible")
+	}
+	if len(ctext) != CiphertextSize(prv.id) {
+		return nil, errors.New("mlkemsike: ciphertext has wrong size")
+	}
+	ctSize := mlkemFor(prv.id).ctSize
+	ssML, err := prv.ml.Decapsulate(ctext[:ctSize])
+	if err != nil {
+		return nil, err
+	}
+	ssSIKE, err := sike.Decapsulate(prv.sike, pub.sike, ctext[ctSize:])
+	if err != nil {
+		return nil, err
+	}
+	return combine(prv.id, ssML, ssSIKE, ctext, pub), nil
+}
+
+// DecapsulateSIKE decapsulates ciphertext produced by sike.Encapsulate for
+// SIKE part of the public key, by peers which don't support the hybrid.
+// Returned shared secret is the one of SIKE and relies on SIKE only.
+func DecapsulateSIKE(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error) {
+	if prv.id != pub.id {
+		return nil, errors.New("mlkemsike: public and private are incompatible")
+	}
+	return sike.Decapsulate(prv.sike, pub.sike, ctext)
+}
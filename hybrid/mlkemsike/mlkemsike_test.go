@@ -0,0 +1,108 @@
+//go:build go1.24
+// +build go1.24
+
+package mlkemsike
+
+import (
+	"bytes"
+	"crypto/mlkem"
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/sidh/internal/drbg"
+	"github.com/cloudflare/sidh/internal/kemtest"
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+)
+
+var scheme = &kemtest.Scheme[*PrivateKey, *PublicKey]{
+	Ids:              []uint8{sidh.FP_434, sidh.FP_503, sidh.FP_610, sidh.FP_751, sidh.FP_964},
+	Name:             Name,
+	NewPrivateKey:    NewPrivateKey,
+	NewPublicKey:     NewPublicKey,
+	Encapsulate:      Encapsulate,
+	Decapsulate:      Decapsulate,
+	CiphertextSize:   CiphertextSize,
+	SharedSecretSize: SharedSecretSize,
+	SIKEOffset:       func(id uint8) int { return mlkemFor(id).ctSize },
+}
+
+// Peers which only support SIKE keep working
+func testSIKEPeer(t *testing.T, id uint8) {
+	prv, pub := scheme.GenerateKeyPair(t, id)
+
+	// Peer only knows SIKE public key
+	pkSIKE := sidh.NewPublicKey(id, sidh.KeyVariant_SIKE)
+	kemtest.CheckErr(t, pkSIKE.Import(pub.SIKE().Export()), "SIKE public key import failed")
+	ct, ss, err := sike.Encapsulate(rand.Reader, pkSIKE)
+	kemtest.CheckErr(t, err, "SIKE encapsulation failed")
+
+	ss2, err := DecapsulateSIKE(prv, pub, ct)
+	kemtest.CheckErr(t, err, "SIKE decapsulation failed")
+	if !bytes.Equal(ss, ss2) {
+		t.Error("shared secrets differ")
+	}
+
+	// SIKE ciphertext is not a hybrid ciphertext
+	if _, err = Decapsulate(prv, pub, ct); err == nil {
+		t.Error("SIKE ciphertext accepted as hybrid ciphertext")
+	}
+}
+
+// Key generation is deterministic and ML-KEM key is derived from the first
+// 64 bytes read from rng
+func testGenerateDeterministic(t *testing.T, id uint8) {
+	seed := make([]byte, drbg.SeedSize)
+	prv1, prv2 := NewPrivateKey(id), NewPrivateKey(id)
+	kemtest.CheckErr(t, prv1.Generate(drbg.New(seed, nil)), "private key generation failed")
+	kemtest.CheckErr(t, prv2.Generate(drbg.New(seed, nil)), "private key generation failed")
+	if !bytes.Equal(prv1.Export(), prv2.Export()) {
+		t.Error("key generation is not deterministic")
+	}
+
+	var mlSeed [SeedSize]byte
+	drbg.New(seed, nil).Read(mlSeed[:])
+	if !bytes.Equal(prv1.Export()[:SeedSize], mlSeed[:]) {
+		t.Error("wrong ML-KEM seed")
+	}
+}
+
+func TestMLKEMParams(t *testing.T) {
+	for id, exp := range map[uint8]string{
+		sidh.FP_434: "MLKEM768-SIKEp434",
+		sidh.FP_751: "MLKEM768-SIKEp751",
+		sidh.FP_964: "MLKEM1024-SIKEp964",
+	} {
+		if Name(id) != exp {
+			t.Errorf("wrong name: %s, expected %s", Name(id), exp)
+		}
+	}
+	if sz := NewPublicKey(sidh.FP_964).Size(); sz != mlkem.EncapsulationKeySize1024+sidh.Params(sidh.FP_964).PublicKeySize {
+		t.Errorf("wrong size of public key: %d", sz)
+	}
+}
+
+func TestRoundTrip(t *testing.T)             { scheme.Do(scheme.TestRoundTrip, t) }
+func TestSIKEPeer(t *testing.T)              { scheme.Do(testSIKEPeer, t) }
+func TestGenerateDeterministic(t *testing.T) { scheme.Do(testGenerateDeterministic, t) }
+func TestModifiedCiphertext(t *testing.T)    { scheme.Do(scheme.TestModifiedCiphertext, t) }
+func TestNegative(t *testing.T)              { scheme.Do(scheme.TestNegative, t) }
+
+func BenchmarkEncapsulate(b *testing.B) {
+	prv := NewPrivateKey(sidh.FP_434)
+	prv.Generate(rand.Reader)
+	pub := prv.GeneratePublicKey()
+	for i := 0; i < b.N; i++ {
+		Encapsulate(rand.Reader, pub)
+	}
+}
+
+func BenchmarkDecapsulate(b *testing.B) {
+	prv := NewPrivateKey(sidh.FP_434)
+	prv.Generate(rand.Reader)
+	pub := prv.GeneratePublicKey()
+	ct, _, _ := Encapsulate(rand.Reader, pub)
+	for i := 0; i < b.N; i++ {
+		Decapsulate(prv, pub, ct)
+	}
+}
@@ -0,0 +1,211 @@
+// Package x25519sike implements hybrid key encapsulation mechanism, which
+// combines X25519 key agreement with SIKE KEM. Shared secret is secure as long
+// as at least one of the two is secure.
+//
+// Encodings (all parts are concatenated, sizes are fixed for a parameter set):
+//
+//	public key:  X25519 public key (32 bytes) || SIKE public key
+//	private key: X25519 private key (32 bytes) || SIKE private key (s || sk)
+//	ciphertext:  X25519 ephemeral public key (32 bytes) || SIKE ciphertext
+//
+// Shared secret is an output of the combiner:
+//
+//	cSHAKE256(X25519 shared secret || SIKE shared secret || ciphertext || public key,
+//	          L = 256, N = "", S = "X25519-" || name of SIKE parameter set)
+//
+// so it is bound to both ciphertexts and both public keys.
+package x25519sike
+
+import (
+	"crypto/ecdh"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+	cshake "github.com/henrydcase/nobs/hash/sha3"
+)
+
+const (
+	// X25519Size is the size of X25519 public and private keys
+	X25519Size = 32
+	// SharedSecretSize is the size of the shared secret produced by the KEM
+	SharedSecretSize = 32
+)
+
+// PublicKey is a hybrid public key
+type PublicKey struct {
+	id   uint8
+	x    *ecdh.PublicKey
+	sike *sidh.PublicKey
+}
+
+// PrivateKey is a hybrid private key
+type PrivateKey struct {
+	id   uint8
+	x    *ecdh.PrivateKey
+	sike *sidh.PrivateKey
+}
+
+// NewPublicKey allocates public key for SIKE parameter set id. Panics if id
+// is not supported.
+func NewPublicKey(id uint8) *PublicKey {
+	return &PublicKey{id: id, sike: sidh.NewPublicKey(id, sidh.KeyVariant_SIKE)}
+}
+
+// NewPrivateKey allocates private key for SIKE parameter set id. Panics if id
+// is not supported.
+func NewPrivateKey(id uint8) *PrivateKey {
+	return &PrivateKey{id: id, sike: sidh.NewPrivateKey(id, sidh.KeyVariant_SIKE)}
+}
+
+// Name returns name of the hybrid scheme, e.g. "X25519-SIKEp434"
+func Name(id uint8) string {
+	return "X25519-" + sidh.Params(id).Name
+}
+
+// CiphertextSize returns size of the ciphertext for parameter set id
+func CiphertextSize(id uint8) int {
+	params := sidh.Params(id)
+	return X25519Size + params.PublicKeySize + params.MsgLen
+}
+
+// Size returns size of the encoded public key
+func (pub *PublicKey) Size() int {
+	return X25519Size + pub.sike.Size()
+}
+
+// Import decodes public key. Input must be exactly Size() bytes long.
+func (pub *PublicKey) Import(input []byte) error {
+	if len(input) != pub.Size() {
+		return errors.New("x25519sike: input has wrong size")
+	}
+	x, err := ecdh.X25519().NewPublicKey(input[:X25519Size])
+	if err != nil {
+		return err
+	}
+	if err = pub.sike.Import(input[X25519Size:]); err != nil {
+		return err
+	}
+	pub.x = x
+	return nil
+}
+
+// Export returns encoding of the public key
+func (pub *PublicKey) Export() []byte {
+	return append(pub.x.Bytes(), pub.sike.Export()...)
+}
+
+// Size returns size of the encoded private key
+func (prv *PrivateKey) Size() int {
+	return X25519Size + prv.sike.Size()
+}
+
+// Import decodes private key. Input must be exactly Size() bytes long.
+func (prv *PrivateKey) Import(input []byte) error {
+	if len(input) != prv.Size() {
+		return errors.New("x25519sike: input has wrong size")
+	}
+	x, err := ecdh.X25519().NewPrivateKey(input[:X25519Size])
+	if err != nil {
+		return err
+	}
+	if err = prv.sike.Import(input[X25519Size:]); err != nil {
+		return err
+	}
+	prv.x = x
+	return nil
+}
+
+// Export returns encoding of the private key
+func (prv *PrivateKey) Export() []byte {
+	return append(prv.x.Bytes(), prv.sike.Export()...)
+}
+
+// Generate generates private key. X25519 key is taken directly from the
+// first 32 bytes read from rng, hence output is deterministic for
+// deterministic rng (see known answer tests).
+func (prv *PrivateKey) Generate(rng io.Reader) error {
+	var seed [X25519Size]byte
+	if _, err := io.ReadFull(rng, seed[:]); err != nil {
+		return err
+	}
+	x, err := ecdh.X25519().NewPrivateKey(seed[:])
+	if err != nil {
+		return err
+	}
+	if err = prv.sike.Generate(rng); err != nil {
+		return err
+	}
+	prv.x = x
+	return nil
+}
+
+// GeneratePublicKey returns public key corresponding to the private key
+func (prv *PrivateKey) GeneratePublicKey() *PublicKey {
+	return &PublicKey{id: prv.id, x: prv.x.PublicKey(), sike: prv.sike.GeneratePublicKey()}
+}
+
+// Derives final shared secret from the shared secrets of the components
+func combine(id uint8, ssX, ssSIKE, ctext []byte, pub *PublicKey) []byte {
+	var ss = make([]byte, SharedSecretSize)
+	h := cshake.NewCShake256(nil, []byte(Name(id)))
+	h.Write(ssX)
+	h.Write(ssSIKE)
+	h.Write(ctext)
+	h.Write(pub.Export())
+	h.Read(ss)
+	return ss
+}
+
+// Encapsulate generates ciphertext and shared secret for the public key.
+// Ephemeral X25519 key is read from rng first, then rng is used by
+// sike.Encapsulate.
+func Encapsulate(rng io.Reader, pub *PublicKey) (ctext []byte, secret []byte, err error) {
+	var seed [X25519Size]byte
+	if pub.x == nil {
+		return nil, nil, errors.New("x25519sike: public key not initialized")
+	}
+	if _, err = io.ReadFull(rng, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	eph, err := ecdh.X25519().NewPrivateKey(seed[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	ssX, err := eph.ECDH(pub.x)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctSIKE, ssSIKE, err := sike.Encapsulate(rng, pub.sike)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctext = append(eph.PublicKey().Bytes(), ctSIKE...)
+	return ctext, combine(pub.id, ssX, ssSIKE, ctext, pub), nil
+}
+
+// Decapsulate returns shared secret for the ciphertext. As in sike.Decapsulate,
+// result is a random value if SIKE ciphertext doesn't verify. Error is returned
+// if input is wrongly formatted or X25519 shared secret is all-zero.
+func Decapsulate(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error) {
+	if prv.id != pub.id || prv.x == nil || pub.x == nil {
+		return nil, errors.New("x25519sike: public and private are incompatible")
+	}
+	if len(ctext) != CiphertextSize(prv.id) {
+		return nil, errors.New("x25519sike: ciphertext has wrong size")
+	}
+	eph, err := ecdh.X25519().NewPublicKey(ctext[:X25519Size])
+	if err != nil {
+		return nil, err
+	}
+	ssX, err := prv.x.ECDH(eph)
+	if err != nil {
+		return nil, err
+	}
+	ssSIKE, err := sike.Decapsulate(prv.sike, pub.sike, ctext[X25519Size:])
+	if err != nil {
+		return nil, err
+	}
+	return combine(prv.id, ssX, ssSIKE, ctext, pub), nil
+}
@@ -0,0 +1,138 @@
+//go:build go1.18
+// +build go1.18
+
+package x25519sike
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/sidh/internal/drbg"
+	"github.com/cloudflare/sidh/internal/kemtest"
+	"github.com/cloudflare/sidh/sidh"
+)
+
+var scheme = &kemtest.Scheme[*PrivateKey, *PublicKey]{
+	Ids:              []uint8{sidh.FP_434, sidh.FP_503, sidh.FP_610, sidh.FP_751, sidh.FP_964},
+	Name:             Name,
+	NewPrivateKey:    NewPrivateKey,
+	NewPublicKey:     NewPublicKey,
+	Encapsulate:      Encapsulate,
+	Decapsulate:      Decapsulate,
+	CiphertextSize:   CiphertextSize,
+	SharedSecretSize: SharedSecretSize,
+	SIKEOffset:       func(uint8) int { return X25519Size },
+}
+
+// Low order X25519 point gives all-zero shared secret
+func testLowOrder(t *testing.T, id uint8) {
+	prv, pub := scheme.GenerateKeyPair(t, id)
+	ct, _, err := Encapsulate(rand.Reader, pub)
+	kemtest.CheckErr(t, err, "encapsulation failed")
+
+	copy(ct, make([]byte, X25519Size))
+	if _, err = Decapsulate(prv, pub, ct); err == nil {
+		t.Error("low order X25519 point accepted")
+	}
+	lowOrder := append(make([]byte, X25519Size), pub.Export()[X25519Size:]...)
+	pub2 := NewPublicKey(id)
+	kemtest.CheckErr(t, pub2.Import(lowOrder), "public key import failed")
+	if _, _, err = Encapsulate(rand.Reader, pub2); err == nil {
+		t.Error("encapsulation to low order X25519 point succeeded")
+	}
+}
+
+func readAndCheckLine(r *bufio.Reader) []byte {
+	line, isPrefix, err := r.ReadLine()
+	if err != nil || isPrefix {
+		panic("Wrong format of input file")
+	}
+	hexst := strings.TrimSpace(strings.Split(string(line), "=")[1])
+	ret, err := hex.DecodeString(hexst)
+	if err != nil {
+		panic("Wrong format of input file")
+	}
+	return ret
+}
+
+// Known answer tests generated by cmd/katgen. Checks key generation and
+// encapsulation from seed, and decapsulation.
+func testKAT(t *testing.T, id uint8) {
+	f, err := os.Open("../../etc/PQCkemKAT_" + Name(id) + ".rsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, isPrefix, err := r.ReadLine()
+		if err != nil || isPrefix {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		if len(strings.TrimSpace(string(line))) == 0 || line[0] == '#' {
+			continue
+		}
+		count := strings.TrimSpace(strings.Split(string(line), "=")[1])
+		seed := readAndCheckLine(r)
+		pk := readAndCheckLine(r)
+		sk := readAndCheckLine(r)
+		ct := readAndCheckLine(r)
+		ss := readAndCheckLine(r)
+
+		rng := drbg.New(seed, nil)
+		prv := NewPrivateKey(id)
+		kemtest.CheckErr(t, prv.Generate(rng), "private key generation failed")
+		pub := prv.GeneratePublicKey()
+		if !bytes.Equal(prv.Export(), sk) || !bytes.Equal(pub.Export(), pk) {
+			t.Fatalf("KAT keygen from seed failed at %s", count)
+		}
+		ctGot, ssGot, err := Encapsulate(rng, pub)
+		if err != nil || !bytes.Equal(ctGot, ct) || !bytes.Equal(ssGot, ss) {
+			t.Fatalf("KAT encapsulation failed at %s", count)
+		}
+
+		prv = NewPrivateKey(id)
+		pub = NewPublicKey(id)
+		kemtest.CheckErr(t, prv.Import(sk), "private key import failed")
+		kemtest.CheckErr(t, pub.Import(pk), "public key import failed")
+		ssGot, err = Decapsulate(prv, pub, ct)
+		if err != nil || !bytes.Equal(ssGot, ss) {
+			t.Fatalf("KAT decapsulation failed at %s", count)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T)          { scheme.Do(scheme.TestRoundTrip, t) }
+func TestModifiedCiphertext(t *testing.T) { scheme.Do(scheme.TestModifiedCiphertext, t) }
+func TestNegative(t *testing.T)           { scheme.Do(scheme.TestNegative, t) }
+func TestLowOrder(t *testing.T)           { scheme.Do(testLowOrder, t) }
+func TestKAT(t *testing.T)                { scheme.Do(testKAT, t) }
+
+func BenchmarkEncapsulate(b *testing.B) {
+	prv := NewPrivateKey(sidh.FP_434)
+	prv.Generate(rand.Reader)
+	pub := prv.GeneratePublicKey()
+	for i := 0; i < b.N; i++ {
+		Encapsulate(rand.Reader, pub)
+	}
+}
+
+func BenchmarkDecapsulate(b *testing.B) {
+	prv := NewPrivateKey(sidh.FP_434)
+	prv.Generate(rand.Reader)
+	pub := prv.GeneratePublicKey()
+	ct, _, _ := Encapsulate(rand.Reader, pub)
+	for i := 0; i < b.N; i++ {
+		Decapsulate(prv, pub, ct)
+	}
+}
@@ -1,5 +1,3 @@
-// +build noasm !amd64
-
 package internal
 
 // helper used for Uint128 representation
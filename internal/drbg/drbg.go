@@ -0,0 +1,82 @@
+// Package drbg implements AES-256 CTR DRBG, as used by randombytes function
+// of the NIST PQC reference implementations for generating known answer
+// tests (rng.c). It must not be used for anything else than KATs.
+package drbg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+)
+
+// SeedSize is the size of the entropy input and personalization string
+const SeedSize = 48
+
+// DRBG is a deterministic random bit generator. Output depends on the
+// sizes of buffers passed to Read, as the internal state is updated after
+// each call, exactly as after each call to randombytes.
+type DRBG struct {
+	key [32]byte
+	v   [16]byte
+}
+
+// New returns DRBG initialized with 48 bytes of entropy input and optional
+// personalization string (nil or 48 bytes), as randombytes_init does.
+func New(entropy, personalization []byte) *DRBG {
+	var seed [SeedSize]byte
+	if len(entropy) != SeedSize || (personalization != nil && len(personalization) != SeedSize) {
+		panic("drbg: wrong size of the seed")
+	}
+	copy(seed[:], entropy)
+	for i := range personalization {
+		seed[i] ^= personalization[i]
+	}
+	d := new(DRBG)
+	d.update(seed[:])
+	return d
+}
+
+// Increments V, which is a 128-bit big-endian counter
+func (d *DRBG) incV() {
+	for j := len(d.v) - 1; j >= 0; j-- {
+		d.v[j]++
+		if d.v[j] != 0 {
+			break
+		}
+	}
+}
+
+func (d *DRBG) cipher() cipher.Block {
+	b, err := aes.NewCipher(d.key[:])
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// AES256_CTR_DRBG_Update. The data is either nil or 48 bytes long.
+func (d *DRBG) update(data []byte) {
+	var tmp [SeedSize]byte
+	b := d.cipher()
+	for i := 0; i < 3; i++ {
+		d.incV()
+		b.Encrypt(tmp[16*i:], d.v[:])
+	}
+	for i := range data {
+		tmp[i] ^= data[i]
+	}
+	copy(d.key[:], tmp[:32])
+	copy(d.v[:], tmp[32:])
+}
+
+// Read fills x with random bytes, as randombytes does. Never fails.
+func (d *DRBG) Read(x []byte) (int, error) {
+	var block [16]byte
+	b := d.cipher()
+	for i := 0; i < len(x); i += len(block) {
+		d.incV()
+		b.Encrypt(block[:], d.v[:])
+		copy(x[i:], block[:])
+	}
+	d.update(nil)
+	return len(x), nil
+}
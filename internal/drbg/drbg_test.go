@@ -0,0 +1,69 @@
+package drbg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Seeds of first and second test vectors in NIST KAT files. Those are
+// generated by DRBG initialized with entropy input 0,1,...,47.
+const (
+	seed0 = "061550234D158C5EC95595FE04EF7A25767F2E24CC2BC479D09D86DC9ABCFDE7056A8C266F9EF97ED08541DBD2E1FFA1"
+	seed1 = "D81C4D8D734FCBFBEADE3D3F8A039FAA2A2C9957E835AD55B22E75BF57BB556AC81ADDE6AEEB4A5A875C3BFCADFA958F"
+)
+
+func TestKATSeeds(t *testing.T) {
+	var entropy [SeedSize]byte
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+	d := New(entropy[:], nil)
+	for _, exp := range []string{seed0, seed1} {
+		var seed [SeedSize]byte
+		d.Read(seed[:])
+		if got := hex.EncodeToString(seed[:]); got != strings.ToLower(exp) {
+			t.Errorf("wrong seed: got %s, expected %s", got, exp)
+		}
+	}
+}
+
+// Output depends on boundaries of reads, the same as randombytes
+func TestReadBoundaries(t *testing.T) {
+	seed, _ := hex.DecodeString(seed0)
+	var one, two [32]byte
+	New(seed, nil).Read(one[:])
+	d := New(seed, nil)
+	d.Read(two[:16])
+	d.Read(two[16:])
+	if !bytes.Equal(one[:16], two[:16]) {
+		t.Error("first block differs")
+	}
+	if bytes.Equal(one[16:], two[16:]) {
+		t.Error("state not updated after read")
+	}
+
+	// Not a multiple of block size
+	var odd [21]byte
+	New(seed, nil).Read(odd[:])
+	if !bytes.Equal(odd[:], one[:21]) {
+		t.Error("short read differs")
+	}
+}
+
+func TestPersonalization(t *testing.T) {
+	seed, _ := hex.DecodeString(seed0)
+	zero := make([]byte, SeedSize)
+	var a, b [16]byte
+	New(seed, nil).Read(a[:])
+	New(seed, zero).Read(b[:])
+	if a != b {
+		t.Error("zero personalization string changes output")
+	}
+	zero[0] = 1
+	New(seed, zero).Read(b[:])
+	if a == b {
+		t.Error("personalization string ignored")
+	}
+}
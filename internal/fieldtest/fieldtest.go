@@ -0,0 +1,575 @@
+// Package fieldtest implements tests shared by packages providing field
+// arithmetic (p434, p503, p610, p964). Package under test describes its
+// field with Field and runs the tests from its own _test.go files.
+package fieldtest
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	. "github.com/cloudflare/sidh/internal/isogeny"
+)
+
+// Configure testing/quick
+var (
+	QuickCheckScaleFactor = uint8(3)
+	QuickCheckConfig      = &quick.Config{MaxCount: (1 << (12 + QuickCheckScaleFactor))}
+)
+
+// Field describes field arithmetic under test
+type Field struct {
+	// Characteristic of the field
+	Prime *big.Int
+	// Number of 64-bit words used by element of Fp
+	NumWords int
+	// Bytelen of p
+	Bytelen int
+	// Identity element and 1/2 in Fp2, in Montgomery domain
+	OneFp2, HalfFp2 Fp2Element
+	// Operations in Fp2
+	Op FieldOps
+	// Montgomery multiplication and exponentiation to (p-3)/4 in Fp
+	Mul func(z, x, y *FpElement)
+	P34 func(z, x *FpElement)
+	// Montgomery reduction, reduction to [0,p) and constant time swap
+	MontgomeryReduce func(z *FpElement, x *FpElementX2)
+	StrongReduce     func(x *FpElement)
+	CondSwap         func(x, y *FpElement, choice uint8)
+}
+
+// Curve and points on it, used by TestCurve. Values are computed with Sage.
+type CurveVectors struct {
+	// Curve Cy^2 = x^3 + Ax^2 + Cx and its j-invariant
+	A, C, J Fp2Element
+	// x(P), x([2]P), x([4]P) and x([9]P)
+	XP, XP2, XP4, XP9 Fp2Element
+	// Scalar m (little-endian) and x([m]P)
+	M   []byte
+	XmP Fp2Element
+}
+
+// CurveOps returns curve operations over the field
+func (f *Field) CurveOps() *CurveOperations {
+	return &CurveOperations{
+		Params: &SidhParams{
+			Op:      f.Op,
+			OneFp2:  f.OneFp2,
+			HalfFp2: f.HalfFp2,
+			Bytelen: f.Bytelen,
+		},
+	}
+}
+
+// Generation of random values
+
+// Returns random element of Fp. Low limbs are taken from [0,2^64), high limb
+// is capped by the top digit of 2*p-1. This still allows generating values
+// >= 2p, but hopefully that excess is OK (and if it's not, we'll find out,
+// because it's for testing...)
+func (f *Field) randFp(rand *rand.Rand) FpElement {
+	var x FpElement
+	top := new(big.Int).Lsh(f.Prime, 1)
+	top.Sub(top, big.NewInt(1)).Rsh(top, uint(64*(f.NumWords-1)))
+	for i := 0; i < f.NumWords-1; i++ {
+		x[i] = rand.Uint64()
+	}
+	x[f.NumWords-1] = rand.Uint64() % top.Uint64()
+	return x
+}
+
+func (f *Field) randFp2(rand *rand.Rand) Fp2Element {
+	return Fp2Element{A: f.randFp(rand), B: f.randFp(rand)}
+}
+
+// Returns quick.Config running 2^(scale + QuickCheckScaleFactor) checks, with
+// arguments generated by gen
+func config(scale uint8, gen func(*rand.Rand) interface{}) *quick.Config {
+	return &quick.Config{
+		MaxCount: 1 << (scale + QuickCheckScaleFactor),
+		Values: func(args []reflect.Value, rand *rand.Rand) {
+			for i := range args {
+				args[i] = reflect.ValueOf(gen(rand))
+			}
+		},
+	}
+}
+
+func (f *Field) fp2Config(scale uint8) *quick.Config {
+	return config(scale, func(r *rand.Rand) interface{} { return f.randFp2(r) })
+}
+
+func (f *Field) fpConfig(scale uint8) *quick.Config {
+	return config(scale, func(r *rand.Rand) interface{} { return f.randFp(r) })
+}
+
+// Helpers
+
+// Given xP = x(P), xQ = x(Q), and xPmQ = x(P-Q), compute xR = x(P+Q).
+//
+// Returns xR to allow chaining.  Safe to overlap xP, xQ, xR.
+func (f *Field) AddProjFp2(xP, xQ, xPmQ *ProjectivePoint) ProjectivePoint {
+	// Algorithm 1 of Costello-Smith.
+	var v0, v1, v2, v3, v4 Fp2Element
+	var xR ProjectivePoint
+	f.Op.Add(&v0, &xP.X, &xP.Z) // X_P + Z_P
+	f.Op.Sub(&v1, &xQ.X, &xQ.Z)
+	f.Op.Mul(&v1, &v1, &v0)     // (X_Q - Z_Q)(X_P + Z_P)
+	f.Op.Sub(&v0, &xP.X, &xP.Z) // X_P - Z_P
+	f.Op.Add(&v2, &xQ.X, &xQ.Z)
+	f.Op.Mul(&v2, &v2, &v0) // (X_Q + Z_Q)(X_P - Z_P)
+	f.Op.Add(&v3, &v1, &v2)
+	f.Op.Square(&v3, &v3) // 4(X_Q X_P - Z_Q Z_P)^2
+	f.Op.Sub(&v4, &v1, &v2)
+	f.Op.Square(&v4, &v4)         // 4(X_Q Z_P - Z_Q X_P)^2
+	f.Op.Mul(&v0, &xPmQ.Z, &v3)   // 4X_{P-Q}(X_Q X_P - Z_Q Z_P)^2
+	f.Op.Mul(&xR.Z, &xPmQ.X, &v4) // 4Z_{P-Q}(X_Q Z_P - Z_Q X_P)^2
+	xR.X = v0
+	return xR
+}
+
+// Given xP = x(P) and cached curve parameters Aplus2C = A + 2*C, C4 = 4*C,
+// compute xQ = x([2]P).
+//
+// Returns xQ to allow chaining.  Safe to overlap xP, xQ.
+func (f *Field) DoubleProjFp2(xP *ProjectivePoint, Aplus2C, C4 *Fp2Element) ProjectivePoint {
+	// Algorithm 2 of Costello-Smith, amended to work with projective curve coefficients.
+	var v1, v2, v3, xz4 Fp2Element
+	var xQ ProjectivePoint
+	f.Op.Add(&v1, &xP.X, &xP.Z) // (X+Z)^2
+	f.Op.Square(&v1, &v1)
+	f.Op.Sub(&v2, &xP.X, &xP.Z) // (X-Z)^2
+	f.Op.Square(&v2, &v2)
+	f.Op.Sub(&xz4, &v1, &v2)     // 4XZ = (X+Z)^2 - (X-Z)^2
+	f.Op.Mul(&v2, &v2, C4)       // 4C(X-Z)^2
+	f.Op.Mul(&xQ.X, &v1, &v2)    // 4C(X+Z)^2(X-Z)^2
+	f.Op.Mul(&v3, &xz4, Aplus2C) // 4XZ(A + 2C)
+	f.Op.Add(&v3, &v3, &v2)      // 4XZ(A + 2C) + 4C(X-Z)^2
+	f.Op.Mul(&xQ.Z, &v3, &xz4)   // (4XZ(A + 2C) + 4C(X-Z)^2)4XZ
+	// Now (xQ.x : xQ.z)
+	//   = (4C(X+Z)^2(X-Z)^2 : (4XZ(A + 2C) + 4C(X-Z)^2)4XZ )
+	//   = ((X+Z)^2(X-Z)^2 : (4XZ((A + 2C)/4C) + (X-Z)^2)4XZ )
+	//   = ((X+Z)^2(X-Z)^2 : (4XZ((a + 2)/4) + (X-Z)^2)4XZ )
+	return xQ
+}
+
+// Given x(P) and a scalar m in little-endian bytes, compute x([m]P) using the
+// Montgomery ladder.  This is described in Algorithm 8 of Costello-Smith.
+//
+// This function's execution time is dependent only on the byte-length of the
+// input scalar.  All scalars of the same input length execute in uniform time.
+// The scalar can be padded with zero bytes to ensure a uniform length.
+//
+// Safe to overlap the source with the destination.
+func (f *Field) ScalarMult(curve *ProjectiveCurveParameters, xP *ProjectivePoint, scalar []uint8) ProjectivePoint {
+	var x0, x1, tmp ProjectivePoint
+	var Aplus2C, C4 Fp2Element
+
+	f.Op.Add(&Aplus2C, &curve.C, &curve.C) // = 2*C
+	f.Op.Add(&C4, &Aplus2C, &Aplus2C)      // = 4*C
+	f.Op.Add(&Aplus2C, &Aplus2C, &curve.A) // = 2*C + A
+
+	x0.X = f.OneFp2
+	x1 = *xP
+
+	// Iterate over the bits of the scalar, top to bottom
+	prevBit := uint8(0)
+	for i := len(scalar) - 1; i >= 0; i-- {
+		scalarByte := scalar[i]
+		for j := 7; j >= 0; j-- {
+			bit := (scalarByte >> uint(j)) & 0x1
+			f.Op.CondSwap(&x0.X, &x0.Z, &x1.X, &x1.Z, (bit ^ prevBit))
+			tmp = f.DoubleProjFp2(&x0, &Aplus2C, &C4)
+			x1 = f.AddProjFp2(&x0, &x1, xP)
+			x0 = tmp
+			prevBit = bit
+		}
+	}
+	// now prevBit is the lowest bit of the scalar
+	f.Op.CondSwap(&x0.X, &x0.Z, &x1.X, &x1.Z, prevBit)
+	return x0
+}
+
+// Returns true if lhs = rhs.  Takes variable time.
+func (f *Field) VartimeEqFp2(lhs, rhs *Fp2Element) bool {
+	a := *lhs
+	b := *rhs
+
+	f.StrongReduce(&a.A)
+	f.StrongReduce(&a.B)
+	f.StrongReduce(&b.A)
+	f.StrongReduce(&b.B)
+	return a == b
+}
+
+// Returns true if lhs = rhs.  Takes variable time.
+func (f *Field) VartimeEqProjFp2(lhs, rhs *ProjectivePoint) bool {
+	var t0, t1 Fp2Element
+	f.Op.Mul(&t0, &lhs.X, &rhs.Z)
+	f.Op.Mul(&t1, &lhs.Z, &rhs.X)
+	return f.VartimeEqFp2(&t0, &t1)
+}
+
+// ToBigInt converts an FpElement in Montgomery domain to a big.Int. Because
+// this is only for testing, no big.Int to FpElement conversion is provided.
+func (f *Field) ToBigInt(x *FpElement) *big.Int {
+	a := FpElement{}
+	aR := FpElementX2{}
+	copy(aR[:], x[:f.NumWords]) // = a*R
+	f.MontgomeryReduce(&a, &aR) // = a mod p  in [0,2p)
+	f.StrongReduce(&a)          // = a mod p  in [0,p)
+
+	val := new(big.Int)
+	for i := f.NumWords - 1; i >= 0; i-- {
+		val.Lsh(val, 64)
+		val.Or(val, new(big.Int).SetUint64(a[i]))
+	}
+	return val
+}
+
+// Tests
+
+// TestArith checks arithmetic in Fp and Fp2
+func (f *Field) TestArith(t *testing.T) {
+	curveOps := f.CurveOps()
+
+	t.Run("OneFp2ToBytes", func(t *testing.T) {
+		var xBytes = make([]byte, 2*f.Bytelen)
+
+		curveOps.Fp2ToBytes(xBytes, &f.OneFp2)
+		if xBytes[0] != 1 {
+			t.Error("Expected 1, got", xBytes[0])
+		}
+		for i := 1; i < 2*f.Bytelen; i++ {
+			if xBytes[i] != 0 {
+				t.Error("Expected 0, got", xBytes[0])
+			}
+		}
+	})
+
+	t.Run("Fp2ElementToBytesRoundTrip", func(t *testing.T) {
+		roundTrips := func(x Fp2Element) bool {
+			var xBytes = make([]byte, 2*f.Bytelen)
+			var xPrime Fp2Element
+
+			curveOps.Fp2ToBytes(xBytes, &x)
+			curveOps.Fp2FromBytes(&xPrime, xBytes)
+			return f.VartimeEqFp2(&xPrime, &x)
+		}
+
+		if err := quick.Check(roundTrips, f.fp2Config(12)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Fp2ElementMulDistributesOverAdd", func(t *testing.T) {
+		mulDistributesOverAdd := func(x, y, z Fp2Element) bool {
+			// Compute t1 = (x+y)*z
+			t1 := new(Fp2Element)
+			f.Op.Add(t1, &x, &y)
+			f.Op.Mul(t1, t1, &z)
+
+			// Compute t2 = x*z + y*z
+			t2 := new(Fp2Element)
+			t3 := new(Fp2Element)
+			f.Op.Mul(t2, &x, &z)
+			f.Op.Mul(t3, &y, &z)
+			f.Op.Add(t2, t2, t3)
+
+			return f.VartimeEqFp2(t1, t2)
+		}
+
+		if err := quick.Check(mulDistributesOverAdd, f.fp2Config(12)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Fp2ElementMulIsAssociative", func(t *testing.T) {
+		isAssociative := func(x, y, z Fp2Element) bool {
+			// Compute t1 = (x*y)*z
+			t1 := new(Fp2Element)
+			f.Op.Mul(t1, &x, &y)
+			f.Op.Mul(t1, t1, &z)
+
+			// Compute t2 = (y*z)*x
+			t2 := new(Fp2Element)
+			f.Op.Mul(t2, &y, &z)
+			f.Op.Mul(t2, t2, &x)
+
+			return f.VartimeEqFp2(t1, t2)
+		}
+
+		if err := quick.Check(isAssociative, f.fp2Config(12)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Fp2ElementSquareMatchesMul", func(t *testing.T) {
+		sqrMatchesMul := func(x Fp2Element) bool {
+			// Compute t1 = (x*x)
+			t1 := new(Fp2Element)
+			f.Op.Mul(t1, &x, &x)
+
+			// Compute t2 = x^2
+			t2 := new(Fp2Element)
+			f.Op.Square(t2, &x)
+
+			return f.VartimeEqFp2(t1, t2)
+		}
+
+		if err := quick.Check(sqrMatchesMul, f.fp2Config(12)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Fp2ElementInv", func(t *testing.T) {
+		inverseIsCorrect := func(x Fp2Element) bool {
+			z := new(Fp2Element)
+			f.Op.Inv(z, &x)
+
+			// Now z = (1/x), so (z * x) * x == x
+			f.Op.Mul(z, z, &x)
+			f.Op.Mul(z, z, &x)
+
+			return f.VartimeEqFp2(z, &x)
+		}
+
+		// This is more expensive; run fewer tests
+		if err := quick.Check(inverseIsCorrect, f.fp2Config(8)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Fp2ElementBatch3Inv", func(t *testing.T) {
+		batchInverseIsCorrect := func(x1, x2, x3 Fp2Element) bool {
+			var x1Inv, x2Inv, x3Inv Fp2Element
+			f.Op.Inv(&x1Inv, &x1)
+			f.Op.Inv(&x2Inv, &x2)
+			f.Op.Inv(&x3Inv, &x3)
+
+			var y1, y2, y3 Fp2Element
+			curveOps.Fp2Batch3Inv(&x1, &x2, &x3, &y1, &y2, &y3)
+
+			return (f.VartimeEqFp2(&x1Inv, &y1) && f.VartimeEqFp2(&x2Inv, &y2) && f.VartimeEqFp2(&x3Inv, &y3))
+		}
+
+		// This is more expensive; run fewer tests
+		if err := quick.Check(batchInverseIsCorrect, f.fp2Config(5)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("PrimeFieldElementMulVersusBigInt", func(t *testing.T) {
+		mulMatchesBigInt := func(x, y FpElement) bool {
+			var z FpElement
+			f.Mul(&z, &x, &y)
+			check := new(big.Int)
+			check.Mul(f.ToBigInt(&x), f.ToBigInt(&y))
+			check.Mod(check, f.Prime)
+			return check.Cmp(f.ToBigInt(&z)) == 0
+		}
+
+		if err := quick.Check(mulMatchesBigInt, f.fpConfig(12)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("PrimeFieldElementP34VersusBigInt", func(t *testing.T) {
+		// p34 = (p-3)/4
+		p34 := new(big.Int).Sub(f.Prime, big.NewInt(3))
+		p34.Rsh(p34, 2)
+		p34MatchesBigInt := func(x FpElement) bool {
+			var z FpElement
+			f.P34(&z, &x)
+
+			check := f.ToBigInt(&x)
+			check.Exp(check, p34, f.Prime)
+
+			return check.Cmp(f.ToBigInt(&z)) == 0
+		}
+
+		// This is more expensive; run fewer tests
+		if err := quick.Check(p34MatchesBigInt, f.fpConfig(8)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("FpElementConditionalSwap", func(t *testing.T) {
+		var one, two FpElement
+		for i := 0; i < f.NumWords; i++ {
+			one[i], two[i] = 1, 2
+		}
+
+		var x = one
+		var y = two
+
+		f.CondSwap(&x, &y, 0)
+
+		if !(x == one && y == two) {
+			t.Error("Found", x, "expected", one)
+		}
+
+		f.CondSwap(&x, &y, 1)
+
+		if !(x == two && y == one) {
+			t.Error("Found", x, "expected", two)
+		}
+	})
+}
+
+// TestCurve checks operations on the curve given by v
+func (f *Field) TestCurve(t *testing.T, v *CurveVectors) {
+	curveOps := f.CurveOps()
+	curve := ProjectiveCurveParameters{A: v.A, C: v.C}
+
+	t.Run("One", func(t *testing.T) {
+		var tmp Fp2Element
+
+		f.Op.Mul(&tmp, &f.OneFp2, &v.XP)
+		if !f.VartimeEqFp2(&tmp, &v.XP) {
+			t.Error("Not equal 1")
+		}
+	})
+
+	// This test is here only to ensure that ScalarMult helper works correctly
+	t.Run("ScalarMultVersusSage", func(t *testing.T) {
+		xP := ProjectivePoint{X: v.XP, Z: f.OneFp2}
+		xP = f.ScalarMult(&curve, &xP, v.M) // = x([m]P)
+		affine_xQ := xP.ToAffine(curveOps)
+		if !f.VartimeEqFp2(&v.XmP, affine_xQ) {
+			t.Error("\nExpected\n", v.XmP, "\nfound\n", affine_xQ)
+		}
+	})
+
+	t.Run("jInvariant", func(t *testing.T) {
+		var jbufRes = make([]byte, 2*f.Bytelen)
+		var jbufExp = make([]byte, 2*f.Bytelen)
+
+		curveOps.Jinvariant(&curve, jbufRes)
+		curveOps.Fp2ToBytes(jbufExp, &v.J)
+
+		if !bytes.Equal(jbufRes, jbufExp) {
+			t.Error("Computed incorrect j-invariant: found\n", jbufRes, "\nexpected\n", jbufExp)
+		}
+	})
+
+	t.Run("ProjectivePointVartimeEq", func(t *testing.T) {
+		xP := ProjectivePoint{X: v.XP, Z: f.OneFp2}
+		xQ := xP
+		// Scale xQ, which results in the same projective point
+		f.Op.Mul(&xQ.X, &xQ.X, &v.A)
+		f.Op.Mul(&xQ.Z, &xQ.Z, &v.A)
+		if !f.VartimeEqProjFp2(&xP, &xQ) {
+			t.Error("Expected the scaled point to be equal to the original")
+		}
+	})
+
+	t.Run("PointDoubleVersusSage", func(t *testing.T) {
+		var params = curveOps.CalcCurveParamsEquiv4(&curve)
+
+		xP := ProjectivePoint{X: v.XP, Z: f.OneFp2}
+		curveOps.Pow2k(&xP, &params, 1)
+		affine_xQ := xP.ToAffine(curveOps)
+		if !f.VartimeEqFp2(affine_xQ, &v.XP2) {
+			t.Error("\nExpected\n", v.XP2, "\nfound\n", affine_xQ)
+		}
+	})
+
+	t.Run("PointMul4VersusSage", func(t *testing.T) {
+		var params = curveOps.CalcCurveParamsEquiv4(&curve)
+
+		xP := ProjectivePoint{X: v.XP, Z: f.OneFp2}
+		curveOps.Pow2k(&xP, &params, 2)
+		affine_xQ := xP.ToAffine(curveOps)
+		if !f.VartimeEqFp2(affine_xQ, &v.XP4) {
+			t.Error("\nExpected\n", v.XP4, "\nfound\n", affine_xQ)
+		}
+	})
+
+	t.Run("PointMul9VersusSage", func(t *testing.T) {
+		var params = curveOps.CalcCurveParamsEquiv3(&curve)
+
+		xP := ProjectivePoint{X: v.XP, Z: f.OneFp2}
+		curveOps.Pow3k(&xP, &params, 2)
+		affine_xQ := xP.ToAffine(curveOps)
+		if !f.VartimeEqFp2(affine_xQ, &v.XP9) {
+			t.Error("\nExpected\n", v.XP9, "\nfound\n", affine_xQ)
+		}
+	})
+
+	t.Run("PointPow2kVersusScalarMult", func(t *testing.T) {
+		var params = curveOps.CalcCurveParamsEquiv4(&curve)
+
+		xP := ProjectivePoint{X: v.XP, Z: f.OneFp2}
+		xQ := xP
+		curveOps.Pow2k(&xQ, &params, 5)
+		xR := f.ScalarMult(&curve, &xP, []byte{32})
+		affine_xQ := xQ.ToAffine(curveOps) // = x([32]P)
+		affine_xR := xR.ToAffine(curveOps) // = x([32]P)
+
+		if !f.VartimeEqFp2(affine_xQ, affine_xR) {
+			t.Error("\nExpected\n", affine_xQ, "\nfound\n", affine_xR)
+		}
+	})
+
+	t.Run("PointTripleVersusAddDouble", func(t *testing.T) {
+		tripleEqualsAddDouble := func(X, Z, A, C Fp2Element) bool {
+			var P2, P3, P2plusP ProjectivePoint
+			var point = ProjectivePoint{X: X, Z: Z}
+			var cparam = ProjectiveCurveParameters{A: A, C: C}
+
+			eqivParams4 := curveOps.CalcCurveParamsEquiv4(&cparam)
+			eqivParams3 := curveOps.CalcCurveParamsEquiv3(&cparam)
+			P2 = point
+			P3 = point
+			curveOps.Pow2k(&P2, &eqivParams4, 1)        // = x([2]P)
+			curveOps.Pow3k(&P3, &eqivParams3, 1)        // = x([3]P)
+			P2plusP = f.AddProjFp2(&P2, &point, &point) // = x([2]P + P)
+			return f.VartimeEqProjFp2(&P3, &P2plusP)
+		}
+
+		if err := quick.Check(tripleEqualsAddDouble, f.fp2Config(12)); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// BenchmarkFp2 measures operations in Fp2 and multiplication in Fp
+func (f *Field) BenchmarkFp2(b *testing.B, x, y FpElement) {
+	z := &Fp2Element{A: x, B: y}
+	w := new(Fp2Element)
+
+	b.Run("Mul", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			f.Op.Mul(w, z, z)
+		}
+	})
+	b.Run("Inv", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			f.Op.Inv(w, z)
+		}
+	})
+	b.Run("Square", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			f.Op.Square(w, z)
+		}
+	})
+	b.Run("Add", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			f.Op.Add(w, z, z)
+		}
+	})
+	b.Run("Sub", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			f.Op.Sub(w, z, z)
+		}
+	})
+	b.Run("PrimeFieldMul", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			f.Mul(&w.A, &x, &x)
+		}
+	})
+}
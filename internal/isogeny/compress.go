@@ -0,0 +1,555 @@
+package internal
+
+import (
+	"math/big"
+)
+
+// Maximal number of candidates tried when looking for torsion basis points
+const maxBasisCandidates = 256
+
+// A point (X:Y:Z) on a Montgomery curve y^2 = x^3 + Ax^2 + x, represented
+// in homogeneous projective coordinates. Z = 0 denotes point at infinity.
+//
+// Full points are needed only by public key compression, which works with
+// public values. Hence, functions operating on CurvePoint are not constant
+// time.
+type CurvePoint struct {
+	X, Y, Z Fp2Element
+}
+
+/* -------------------------------------------------------------------------
+   Helpers for arithmetic in F_{p^2}
+   -------------------------------------------------------------------------*/
+
+// Returns true if x is equal to 0.
+func (c *CurveOperations) fp2IsZero(x *Fp2Element) bool {
+	var t Fp2Element
+	c.Params.Op.FromMontgomery(x, &t)
+	for i := range t.A {
+		if t.A[i]|t.B[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if x is equal to y.
+func (c *CurveOperations) Fp2IsEqual(x, y *Fp2Element) bool {
+	var t Fp2Element
+	c.Params.Op.Sub(&t, x, y)
+	return c.fp2IsZero(&t)
+}
+
+// Sets res = x^k. Not constant time.
+func (c *CurveOperations) fp2Pow(res, x *Fp2Element, k *big.Int) {
+	var t = c.Params.OneFp2
+	op := c.Params.Op
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		op.Square(&t, &t)
+		if k.Bit(i) == 1 {
+			op.Mul(&t, &t, x)
+		}
+	}
+	*res = t
+}
+
+// Sets res = conjugate of x. For elements of norm 1 it is equal to 1/x.
+func (c *CurveOperations) fp2Conj(res, x *Fp2Element) {
+	var zero, t Fp2Element
+	t.B = x.B
+	c.Params.Op.Sub(&t, &zero, &t)
+	res.A = x.A
+	res.B = t.B
+}
+
+// Returns characteristic of the prime field.
+func (c *CurveOperations) FieldPrime() *big.Int {
+	var zero, t, pm1 Fp2Element
+	c.Params.Op.Sub(&t, &zero, &c.Params.OneFp2)
+	c.Params.Op.FromMontgomery(&t, &pm1)
+
+	p := new(big.Int)
+	w := new(big.Int)
+	for i := len(pm1.A) - 1; i >= 0; i-- {
+		p.Lsh(p, 64)
+		p.Or(p, w.SetUint64(pm1.A[i]))
+	}
+	return p.Add(p, big.NewInt(1))
+}
+
+// Returns exponents e2 and e3, such that p = 2^e2*3^e3 - 1.
+func (c *CurveOperations) TorsionExponents() (e2, e3 uint) {
+	var three = big.NewInt(3)
+	var m = new(big.Int)
+
+	n := c.FieldPrime()
+	n.Add(n, big.NewInt(1))
+	for n.Bit(0) == 0 {
+		n.Rsh(n, 1)
+		e2++
+	}
+	for {
+		n.DivMod(n, three, m)
+		if m.Sign() != 0 {
+			break
+		}
+		e3++
+	}
+	return
+}
+
+// Computes square root of x. Returns false if x is not a square in F_{p^2}.
+// Implementation corresponds to Algorithm 9 from "Square root computation
+// over even extension fields" (G. Adj, F. Rodriguez-Henriquez, 2012), which
+// requires p = 3 mod 4.
+func (c *CurveOperations) Fp2Sqrt(res, x *Fp2Element) bool {
+	var a1, alpha, x0, b, t, minusOne, zero Fp2Element
+	op := c.Params.Op
+	p := c.FieldPrime()
+
+	e := new(big.Int).Rsh(p, 2) // (p-3)/4
+	c.fp2Pow(&a1, x, e)         // a1 = x^((p-3)/4)
+	op.Square(&alpha, &a1)
+	op.Mul(&alpha, &alpha, x) // alpha = a1^2 * x
+	op.Mul(&x0, &a1, x)       // x0 = a1 * x
+
+	op.Sub(&minusOne, &zero, &c.Params.OneFp2)
+	if c.Fp2IsEqual(&alpha, &minusOne) {
+		// t = i*x0 = -x0.B + x0.A*i
+		b.B = x0.B
+		op.Sub(&b, &zero, &b)
+		t.A = b.B
+		t.B = x0.A
+	} else {
+		op.Add(&b, &alpha, &c.Params.OneFp2)
+		e.Rsh(p, 1) // (p-1)/2
+		c.fp2Pow(&b, &b, e)
+		op.Mul(&t, &b, &x0)
+	}
+
+	op.Square(&b, &t)
+	if !c.Fp2IsEqual(&b, x) {
+		return false
+	}
+	*res = t
+	return true
+}
+
+/* -------------------------------------------------------------------------
+   Arithmetic on full points of a Montgomery curve
+   -------------------------------------------------------------------------*/
+
+// Returns true if P is a point at infinity.
+func (c *CurveOperations) IsInfinity(P *CurvePoint) bool {
+	return c.fp2IsZero(&P.Z)
+}
+
+// Converts P to affine representation (X/Z : Y/Z : 1). P must not be
+// a point at infinity.
+func (c *CurveOperations) Normalize(P *CurvePoint) {
+	var zInv Fp2Element
+	op := c.Params.Op
+	op.Inv(&zInv, &P.Z)
+	op.Mul(&P.X, &P.X, &zInv)
+	op.Mul(&P.Y, &P.Y, &zInv)
+	P.Z = c.Params.OneFp2
+}
+
+// Returns -P.
+func (c *CurveOperations) Neg(P *CurvePoint) CurvePoint {
+	var zero Fp2Element
+	R := *P
+	c.Params.Op.Sub(&R.Y, &zero, &P.Y)
+	return R
+}
+
+// Given affine x-coordinate returns point (x, y) on the curve with affine
+// coefficient A. Returns false if such point doesn't exist in E(F_{p^2}).
+func (c *CurveOperations) LiftPoint(A, x *Fp2Element) (P CurvePoint, ok bool) {
+	var t Fp2Element
+	op := c.Params.Op
+	op.Add(&t, x, A)
+	op.Mul(&t, &t, x)
+	op.Add(&t, &t, &c.Params.OneFp2)
+	op.Mul(&t, &t, x) // t = x^3 + Ax^2 + x
+	if !c.Fp2Sqrt(&P.Y, &t) {
+		return P, false
+	}
+	P.X = *x
+	P.Z = c.Params.OneFp2
+	return P, true
+}
+
+// Computes R = T + S together with a slope N/D of the line crossing T and S
+// (or tangent at T in case T = S). Returns vertical=true in case this line
+// is vertical, in which case R is a point at infinity. Neither T nor S can
+// be a point at infinity.
+func (c *CurveOperations) chord(A *Fp2Element, T, S *CurvePoint) (R CurvePoint, N, D Fp2Element, vertical bool) {
+	var t0, t1, t2, W, X1Z2, Y1Z2, D2, D3 Fp2Element
+	op := c.Params.Op
+
+	op.Mul(&t0, &S.X, &T.Z)
+	op.Mul(&X1Z2, &T.X, &S.Z)
+	op.Sub(&D, &t0, &X1Z2) // D = X2*Z1 - X1*Z2
+	op.Mul(&t1, &S.Y, &T.Z)
+	op.Mul(&Y1Z2, &T.Y, &S.Z)
+	op.Sub(&N, &t1, &Y1Z2) // N = Y2*Z1 - Y1*Z2
+
+	if c.fp2IsZero(&D) {
+		if !c.fp2IsZero(&N) {
+			// S = -T
+			vertical = true
+			return
+		}
+		// S = T, slope of the tangent line is (3x^2 + 2Ax + 1)/2y
+		op.Square(&t0, &T.X)
+		op.Add(&N, &t0, &t0)
+		op.Add(&N, &N, &t0)
+		op.Mul(&t1, A, &T.X)
+		op.Mul(&t1, &t1, &T.Z)
+		op.Add(&t1, &t1, &t1)
+		op.Add(&N, &N, &t1)
+		op.Square(&t1, &T.Z)
+		op.Add(&N, &N, &t1) // N = 3X^2 + 2AXZ + Z^2
+		op.Mul(&D, &T.Y, &T.Z)
+		op.Add(&D, &D, &D) // D = 2YZ
+		if c.fp2IsZero(&D) {
+			// T is a point of order 2
+			vertical = true
+			return
+		}
+	}
+
+	// x3 = (N/D)^2 - A - x1 - x2
+	// y3 = (N/D)(x1 - x3) - y1
+	op.Mul(&W, &T.Z, &S.Z)
+	op.Square(&D2, &D)
+	op.Mul(&D3, &D2, &D)
+	op.Square(&t0, &N)
+	op.Mul(&t1, A, &D2)
+	op.Sub(&t0, &t0, &t1)
+	op.Mul(&t0, &t0, &W)
+	op.Mul(&t1, &S.X, &T.Z)
+	op.Add(&t1, &t1, &X1Z2)
+	op.Mul(&t1, &t1, &D2)
+	op.Sub(&t0, &t0, &t1) // t0 = x3 * D^2 * W
+	op.Mul(&R.X, &t0, &D)
+	op.Mul(&t1, &X1Z2, &D2)
+	op.Sub(&t1, &t1, &t0)
+	op.Mul(&t1, &t1, &N)
+	op.Mul(&t2, &Y1Z2, &D3)
+	op.Sub(&R.Y, &t1, &t2)
+	op.Mul(&R.Z, &D3, &W)
+	return
+}
+
+// Returns P + Q.
+func (c *CurveOperations) Add(A *Fp2Element, P, Q *CurvePoint) CurvePoint {
+	if c.IsInfinity(P) {
+		return *Q
+	}
+	if c.IsInfinity(Q) {
+		return *P
+	}
+	R, _, _, vertical := c.chord(A, P, Q)
+	if vertical {
+		return CurvePoint{}
+	}
+	return R
+}
+
+// Returns [k]P. Not constant time.
+func (c *CurveOperations) ScalarMul(A *Fp2Element, P *CurvePoint, k *big.Int) CurvePoint {
+	var R CurvePoint
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		R = c.Add(A, &R, &R)
+		if k.Bit(i) == 1 {
+			R = c.Add(A, &R, P)
+		}
+	}
+	return R
+}
+
+// Given affine x(P) and k, computes x([k]P) with Montgomery ladder. The a24
+// is (A+2)/4. Not constant time.
+func (c *CurveOperations) xMul(xP *Fp2Element, k *big.Int, a24 *Fp2Element) ProjectivePoint {
+	var P = ProjectivePoint{X: *xP, Z: c.Params.OneFp2}
+	var R0 = ProjectivePoint{X: c.Params.OneFp2}
+	var R1 = P
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		if k.Bit(i) == 1 {
+			R1, R0 = c.xDblAdd(&R1, &R0, &P, a24)
+		} else {
+			R0, R1 = c.xDblAdd(&R0, &R1, &P, a24)
+		}
+	}
+	return R0
+}
+
+/* -------------------------------------------------------------------------
+   Pairings and discrete logarithms
+   -------------------------------------------------------------------------*/
+
+// Evaluates Miller function f_{n,P} at each of the points Qs. P and Qs
+// must be in affine representation. Results are returned as fractions
+// num[i]/den[i].
+func (c *CurveOperations) miller(A *Fp2Element, P *CurvePoint, Qs []CurvePoint, n *big.Int) (num, den []Fp2Element) {
+	var t0, t1 Fp2Element
+	op := c.Params.Op
+
+	num = make([]Fp2Element, len(Qs))
+	den = make([]Fp2Element, len(Qs))
+	for i := range Qs {
+		num[i] = c.Params.OneFp2
+		den[i] = c.Params.OneFp2
+	}
+
+	step := func(T *CurvePoint, S *CurvePoint) {
+		if c.IsInfinity(T) {
+			*T = *S
+			return
+		}
+		R, N, D, vertical := c.chord(A, T, S)
+		for i := range Qs {
+			Q := &Qs[i]
+			op.Mul(&t0, &Q.X, &T.Z)
+			op.Sub(&t0, &t0, &T.X) // t0 = (xQ - x1) * Z1
+			if vertical {
+				// l(Q) = xQ - x1
+				op.Mul(&num[i], &num[i], &t0)
+				op.Mul(&den[i], &den[i], &T.Z)
+				continue
+			}
+			// l(Q) = yQ - y1 - (N/D)(xQ - x1)
+			op.Mul(&t1, &Q.Y, &T.Z)
+			op.Sub(&t1, &t1, &T.Y)
+			op.Mul(&t1, &t1, &D)
+			op.Mul(&t0, &t0, &N)
+			op.Sub(&t1, &t1, &t0)
+			op.Mul(&t1, &t1, &R.Z)
+			op.Mul(&num[i], &num[i], &t1)
+			// v(Q) = xQ - x3
+			op.Mul(&t0, &Q.X, &R.Z)
+			op.Sub(&t0, &t0, &R.X)
+			op.Mul(&t0, &t0, &D)
+			op.Mul(&t0, &t0, &T.Z)
+			op.Mul(&den[i], &den[i], &t0)
+		}
+		if vertical {
+			*T = CurvePoint{}
+		} else {
+			*T = R
+		}
+	}
+
+	T := *P
+	for i := n.BitLen() - 2; i >= 0; i-- {
+		for j := range Qs {
+			op.Square(&num[j], &num[j])
+			op.Square(&den[j], &den[j])
+		}
+		step(&T, &T)
+		if n.Bit(i) == 1 {
+			step(&T, P)
+		}
+	}
+	return
+}
+
+// Computes Weil pairings e_n(Ps[i], Qs[j]) of points from E[n] on the curve
+// with affine coefficient A. Implementation follows "The Weil Pairing, and
+// Its Efficient Calculation" (V. Miller, 2004). Computing pairings in batch
+// requires only len(Ps)+len(Qs) evaluations of Miller loop.
+func (c *CurveOperations) WeilPairings(A *Fp2Element, Ps, Qs []CurvePoint, n *big.Int) [][]Fp2Element {
+	var zero, t0, t1 Fp2Element
+	op := c.Params.Op
+
+	affine := func(in []CurvePoint) []CurvePoint {
+		out := make([]CurvePoint, len(in))
+		for i := range in {
+			out[i] = in[i]
+			if !c.IsInfinity(&out[i]) {
+				c.Normalize(&out[i])
+			}
+		}
+		return out
+	}
+	Pa := affine(Ps)
+	Qa := affine(Qs)
+
+	fP := make([][2][]Fp2Element, len(Pa))
+	for i := range Pa {
+		if !c.IsInfinity(&Pa[i]) {
+			fP[i][0], fP[i][1] = c.miller(A, &Pa[i], Qa, n)
+		}
+	}
+	fQ := make([][2][]Fp2Element, len(Qa))
+	for j := range Qa {
+		if !c.IsInfinity(&Qa[j]) {
+			fQ[j][0], fQ[j][1] = c.miller(A, &Qa[j], Pa, n)
+		}
+	}
+
+	res := make([][]Fp2Element, len(Pa))
+	for i := range Pa {
+		res[i] = make([]Fp2Element, len(Qa))
+		for j := range Qa {
+			res[i][j] = c.Params.OneFp2
+			if c.IsInfinity(&Pa[i]) || c.IsInfinity(&Qa[j]) {
+				continue
+			}
+			n1, d1 := &fP[i][0][j], &fP[i][1][j]
+			n2, d2 := &fQ[j][0][i], &fQ[j][1][i]
+			if c.fp2IsZero(n1) || c.fp2IsZero(d1) || c.fp2IsZero(n2) || c.fp2IsZero(d2) {
+				// Divisors of Miller functions aren't disjoint, which
+				// happens only when Q is a multiple of P.
+				continue
+			}
+			// e(P, Q) = (-1)^n * f_P(Q) / f_Q(P)
+			op.Mul(&t0, n1, d2)
+			op.Mul(&t1, d1, n2)
+			op.Inv(&t1, &t1)
+			op.Mul(&res[i][j], &t0, &t1)
+			if n.Bit(0) == 1 {
+				op.Sub(&res[i][j], &zero, &res[i][j])
+			}
+		}
+	}
+	return res
+}
+
+// Computes Weil pairing e_n(P, Q) of points P, Q from E[n] on the curve
+// with affine coefficient A.
+func (c *CurveOperations) WeilPairing(A *Fp2Element, P, Q *CurvePoint, n *big.Int) Fp2Element {
+	return c.WeilPairings(A, []CurvePoint{*P}, []CurvePoint{*Q}, n)[0][0]
+}
+
+// Sets x = x^(ell^k), for ell in {2, 3}.
+func (c *CurveOperations) powEll(x *Fp2Element, ell, k uint) {
+	var t Fp2Element
+	op := c.Params.Op
+	for i := uint(0); i < k; i++ {
+		op.Square(&t, x)
+		if ell == 3 {
+			op.Mul(&t, &t, x)
+		}
+		*x = t
+	}
+}
+
+// Computes discrete logarithm x of h to the base w, such that h = w^x.
+// The w must be of order ell^e, for ell in {2, 3}, and must have norm 1,
+// which is the case for results of Weil pairing. Uses Pohlig-Hellman
+// algorithm, which recursively splits the problem into two problems in
+// subgroups of order ell^(e/2). Returns false if h isn't in the group
+// generated by w.
+func (c *CurveOperations) Dlog(h, w *Fp2Element, ell, e uint) (*big.Int, bool) {
+	var hh, ww Fp2Element
+
+	if e == 1 {
+		ww = c.Params.OneFp2
+		for d := int64(0); d < int64(ell); d++ {
+			if c.Fp2IsEqual(h, &ww) {
+				return big.NewInt(d), true
+			}
+			c.Params.Op.Mul(&ww, &ww, w)
+		}
+		return nil, false
+	}
+
+	e1 := e / 2
+	e2 := e - e1
+
+	// x1 = x mod ell^e1
+	hh, ww = *h, *w
+	c.powEll(&hh, ell, e2)
+	c.powEll(&ww, ell, e2)
+	x1, ok := c.Dlog(&hh, &ww, ell, e1)
+	if !ok {
+		return nil, false
+	}
+
+	// h*w^(-x1) is in the subgroup generated by w^(ell^e1)
+	c.fp2Conj(&ww, w)
+	c.fp2Pow(&ww, &ww, x1)
+	c.Params.Op.Mul(&hh, h, &ww)
+	ww = *w
+	c.powEll(&ww, ell, e1)
+	x2, ok := c.Dlog(&hh, &ww, ell, e2)
+	if !ok {
+		return nil, false
+	}
+
+	// x = x1 + ell^e1 * x2
+	x2.Mul(x2, new(big.Int).Exp(big.NewInt(int64(ell)), big.NewInt(int64(e1)), nil))
+	return x2.Add(x2, x1), true
+}
+
+// Deterministically generates basis {R1, R2} of the torsion subgroup
+// E[ell^e] of the curve with affine coefficient A. Returns also Weil
+// pairing w = e(R1, R2) of order ell^e. Points are obtained by multiplying
+// points with x-coordinates i+1, i+2, ... by the cofactor (p+1)/ell^e.
+// Returns false in case basis couldn't be found, which may happen only if
+// A doesn't define a supersingular curve.
+func (c *CurveOperations) TorsionBasis(A *Fp2Element, ell, e uint) (R1, R2 CurvePoint, w Fp2Element, ok bool) {
+	var x, xR Fp2Element
+	var T1 ProjectivePoint
+	var cparam CurveCoefficientsEquiv
+	var found bool
+	op := c.Params.Op
+
+	n := new(big.Int).Exp(big.NewInt(int64(ell)), big.NewInt(int64(e)), nil)
+	cofactor := c.FieldPrime()
+	cofactor.Add(cofactor, big.NewInt(1))
+	cofactor.Div(cofactor, n)
+
+	curve := ProjectiveCurveParameters{A: *A, C: c.Params.OneFp2}
+	a24 := c.CalcAplus2Over4(&curve)
+	if ell == 2 {
+		cparam = c.CalcCurveParamsEquiv4(&curve)
+	} else {
+		cparam = c.CalcCurveParamsEquiv3(&curve)
+	}
+
+	// x = 1 + i
+	x.A = c.Params.OneFp2.A
+	x.B = c.Params.OneFp2.A
+	for i := 0; i < maxBasisCandidates; i++ {
+		xP := c.xMul(&x, cofactor, &a24)
+		op.Add(&x, &x, &c.Params.OneFp2)
+
+		// Check if P is of order ell^e
+		T := xP
+		if ell == 2 {
+			c.Pow2k(&T, &cparam, uint32(e-1))
+		} else {
+			c.Pow3k(&T, &cparam, uint32(e-1))
+		}
+		if c.fp2IsZero(&T.Z) {
+			continue
+		}
+		// R2 must be independent from R1, which is the case if
+		// subgroups of order ell generated by R1 and R2 differ.
+		if found && c.IsEqualX(&T, &T1) {
+			continue
+		}
+
+		op.Inv(&xR, &xP.Z)
+		op.Mul(&xR, &xR, &xP.X)
+		P, isOnCurve := c.LiftPoint(A, &xR)
+		if !isOnCurve {
+			// point on a quadratic twist
+			continue
+		}
+		if !found {
+			R1, T1, found = P, T, true
+			continue
+		}
+		R2 = P
+		w = c.WeilPairing(A, &R1, &R2, n)
+		ok = true
+		return
+	}
+	return
+}
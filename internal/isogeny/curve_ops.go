@@ -281,6 +281,7 @@ func (c *CurveOperations) Fp2FromBytes(fp2 *Fp2Element, input []byte) {
 		panic("input byte slice too short")
 	}
 
+	*fp2 = Fp2Element{}
 	for i := 0; i < c.Params.Bytelen; i++ {
 		j := i / 8
 		k := uint64(i % 8)
@@ -294,6 +295,11 @@ func (c *CurveOperations) Fp2FromBytes(fp2 *Fp2Element, input []byte) {
    Mechnisms used for isogeny calculations
    -------------------------------------------------------------------------*/
 
+// Constructs isogeny2 objects
+func Newisogeny2(op FieldOps) Isogeny {
+	return &isogeny2{Field: op}
+}
+
 // Constructs isogeny3 objects
 func Newisogeny3(op FieldOps) Isogeny {
 	return &isogeny3{Field: op}
@@ -304,6 +310,48 @@ func Newisogeny4(op FieldOps) Isogeny {
 	return &isogeny4{isogeny3: isogeny3{Field: op}}
 }
 
+// Given a two-torsion point p = x(P2) on the curve E_(A:C), construct the
+// two-isogeny phi : E_(A:C) -> E_(A:C)/<P_2> = E_(A':C').
+//
+// Input: (XP_2: ZP_2), where P_2 has exact order 2 on E_A/C and P_2 != (0,0)
+// Output: * Curve coordinates (A' + 2C', 4C') corresponding to E_A'/C' = A_E/C/<P2>
+//         * Isogeny phi with constants in F_p^2
+func (phi *isogeny2) GenerateCurve(p *ProjectivePoint) CurveCoefficientsEquiv {
+	var coefEq CurveCoefficientsEquiv
+	var K1, K2 = &phi.K1, &phi.K2
+
+	op := phi.Field
+	op.Sub(K1, &p.X, &p.Z)                  // K1 = XP2 - ZP2
+	op.Add(K2, &p.X, &p.Z)                  // K2 = XP2 + ZP2
+	op.Square(&coefEq.A, &p.X)              // A24plus = XP2^2
+	op.Square(&coefEq.C, &p.Z)              // C24 = ZP2^2
+	op.Sub(&coefEq.A, &coefEq.C, &coefEq.A) // A24plus = ZP2^2 - XP2^2
+	return coefEq
+}
+
+// Given a 2-isogeny phi and a point pB = x(PB), compute x(QB), the x-coordinate
+// of the image QB = phi(PB) of PB under phi : E_(A:C) -> E_(A':C').
+//
+// Input: Isogeny returned by GenerateCurve and point q=(Qx,Qz) from E0_A/C
+// Output: Corresponding point q from E1_A'/C', where E1 is 2-isogenous to E0
+func (phi *isogeny2) EvaluatePoint(p *ProjectivePoint) ProjectivePoint {
+	var t0, t1 Fp2Element
+	var q = *p
+	var xq, zq = &q.X, &q.Z
+	var K1, K2 = &phi.K1, &phi.K2
+
+	op := phi.Field
+	op.Sub(&t0, xq, zq)  // t0 = Xq - Zq
+	op.Add(&t1, xq, zq)  // t1 = Xq + Zq
+	op.Mul(&t0, &t0, K2) // t0 = K2*(Xq - Zq)
+	op.Mul(&t1, &t1, K1) // t1 = K1*(Xq + Zq)
+	op.Add(xq, &t0, &t1) // Xq' = t0 + t1 (temporarily)
+	op.Sub(zq, &t0, &t1) // Zq' = t0 - t1 (temporarily)
+	op.Mul(xq, xq, &p.X) // Xq' = Xq*(t0 + t1)
+	op.Mul(zq, zq, &p.Z) // Zq' = Zq*(t0 - t1)
+	return q
+}
+
 // Given a three-torsion point p = x(PB) on the curve E_(A:C), construct the
 // three-isogeny phi : E_(A:C) -> E_(A:C)/<P_3> = E_(A':C').
 //
@@ -1,7 +1,16 @@
 package internal
 
 const (
-	FP_MAX_WORDS = 12 // Currently p751.NumWords
+	FP_MAX_WORDS = 16 // Currently p964.NumWords
+)
+
+// Revisions of the SIKE specification, identified by the round of the NIST
+// PQC standardization process in which they were submitted.
+const (
+	// Uses cSHAKE256 with customization strings and starting curve E_0.
+	SIKE_ROUND1 uint8 = 1
+	// Uses SHAKE256 and starting curve E_6: y^2 = x^3 + 6x^2 + x.
+	SIKE_ROUND3 uint8 = 3
 )
 
 // Representation of an element of the base field F_p.
@@ -33,6 +42,10 @@ type DomainParams struct {
 
 type SidhParams struct {
 	Id uint8
+	// Name of the parameter set, e.g. "SIKEp434"
+	Name string
+	// NIST security category provided by the parameter set
+	SecurityLevel int
 	// Bytelen of P
 	Bytelen int
 	// The public key size, in bytes.
@@ -41,15 +54,20 @@ type SidhParams struct {
 	SharedSecretSize int
 	// 2- and 3-torsion group parameter definitions
 	A, B DomainParams
+	// Defines A,C constant for starting curve Cy^2 = x^3 + Ax^2 + x
+	InitCurve ProjectiveCurveParameters
 	// Precomputed identity element in the Fp2 in Montgomery domain
 	OneFp2 Fp2Element
 	// Precomputed 1/2 in the Fp2 in Montgomery domain
 	HalfFp2 Fp2Element
-	// Length of SIKE secret message. Must be one of {24,32,40},
+	// Length of SIKE secret message. Must be one of {16,24,32,40},
 	// depending on size of prime field used (see [SIKE], 1.4 and 5.1)
 	MsgLen int
 	// Length of SIKE ephemeral KEM key (see [SIKE], 1.4 and 5.1)
 	KemSize int
+	// Revision of the SIKE specification followed by the parameter set.
+	// One of SIKE_ROUND1 or SIKE_ROUND3.
+	SikeRound uint8
 	// Access to field arithmetic
 	Op FieldOps
 }
@@ -94,6 +112,13 @@ type ProjectiveCurveParameters struct {
 	C Fp2Element
 }
 
+// Stores Isogeny 2 curve constants
+type isogeny2 struct {
+	Field FieldOps
+	K1    Fp2Element
+	K2    Fp2Element
+}
+
 // Stores Isogeny 3 curve constants
 type isogeny3 struct {
 	Field FieldOps
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"math/big"
+)
+
+// Functions used for validation of public keys. They operate on public
+// data and are not constant time.
+
+// Returns true if the Montgomery curve with affine coefficient A is singular,
+// which is the case if A^2 = 4.
+func (c *CurveOperations) IsSingular(A *Fp2Element) bool {
+	var a2, four Fp2Element
+	op := c.Params.Op
+	op.Square(&a2, A)
+	op.Add(&four, &c.Params.OneFp2, &c.Params.OneFp2)
+	op.Square(&four, &four)
+	return c.Fp2IsEqual(&a2, &four)
+}
+
+// Returns true if the curve with affine coefficient A is supersingular.
+// Supersingular curves used by SIDH have (p+1)^2 points over F_{p^2}, and
+// their quadratic twists have (p-1)^2 points. Hence, for any x-coordinate,
+// [p+1]x or [p-1]x must be a point at infinity. Test is probabilistic,
+// ordinary curve passes it only if the tested point has small order by
+// chance.
+func (c *CurveOperations) IsSupersingular(A *Fp2Element) bool {
+	var x Fp2Element
+	curve := ProjectiveCurveParameters{A: *A, C: c.Params.OneFp2}
+	a24 := c.CalcAplus2Over4(&curve)
+	p := c.FieldPrime()
+
+	// x = 1 + i
+	x.A = c.Params.OneFp2.A
+	x.B = c.Params.OneFp2.A
+	T := c.xMul(&x, new(big.Int).Add(p, big.NewInt(1)), &a24)
+	if c.fp2IsZero(&T.Z) {
+		return true
+	}
+	T = c.xMul(&x, new(big.Int).Sub(p, big.NewInt(1)), &a24)
+	return c.fp2IsZero(&T.Z)
+}
+
+// Checks if point with affine x-coordinate xP, on the curve with affine
+// coefficient A, has order ell^e, where ell is 2 or 3. On success, returns
+// also [ell^(e-1)]P, which can be used for checking linear independence.
+func (c *CurveOperations) HasTorsionOrder(A, xP *Fp2Element, ell, e uint) (T ProjectivePoint, ok bool) {
+	var cparam CurveCoefficientsEquiv
+	var pow func(*ProjectivePoint, *CurveCoefficientsEquiv, uint32)
+
+	curve := ProjectiveCurveParameters{A: *A, C: c.Params.OneFp2}
+	if ell == 2 {
+		cparam = c.CalcCurveParamsEquiv4(&curve)
+		pow = c.Pow2k
+	} else {
+		cparam = c.CalcCurveParamsEquiv3(&curve)
+		pow = c.Pow3k
+	}
+
+	T = ProjectivePoint{X: *xP, Z: c.Params.OneFp2}
+	pow(&T, &cparam, uint32(e-1))
+	if c.fp2IsZero(&T.Z) {
+		return T, false
+	}
+	U := T
+	pow(&U, &cparam, 1)
+	return T, c.fp2IsZero(&U.Z)
+}
+
+// Returns true if projective points P and Q have the same x-coordinate.
+func (c *CurveOperations) IsEqualX(P, Q *ProjectivePoint) bool {
+	var t0, t1 Fp2Element
+	c.Params.Op.Mul(&t0, &P.X, &Q.Z)
+	c.Params.Op.Mul(&t1, &Q.X, &P.Z)
+	return c.Fp2IsEqual(&t0, &t1)
+}
@@ -0,0 +1,20 @@
+package internal
+
+// Zeroize overwrites b with zeros. Used for wiping of secret values. Function
+// is not inlined, so that compiler can't remove the writes as dead stores.
+//
+//go:noinline
+func Zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// ZeroizeFp2 overwrites field elements with zeros, see Zeroize.
+//
+//go:noinline
+func ZeroizeFp2(elems ...*Fp2Element) {
+	for _, e := range elems {
+		*e = Fp2Element{}
+	}
+}
@@ -0,0 +1,132 @@
+//go:build go1.18
+// +build go1.18
+
+// Package kemtest implements conformance tests shared by hybrid KEMs
+// (x25519sike, mlkemsike). Package under test describes its API with Scheme
+// and runs the tests from its own _test.go files.
+package kemtest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// PublicKey is implemented by public keys of hybrid KEMs
+type PublicKey interface {
+	Import(input []byte) error
+	Export() []byte
+}
+
+// PrivateKey is implemented by private keys of hybrid KEMs
+type PrivateKey[Pub PublicKey] interface {
+	PublicKey
+	Generate(rng io.Reader) error
+	GeneratePublicKey() Pub
+}
+
+// Scheme describes hybrid KEM under test
+type Scheme[Prv PrivateKey[Pub], Pub PublicKey] struct {
+	// Parameter sets to test
+	Ids []uint8
+	// Name of the KEM for parameter set
+	Name           func(id uint8) string
+	NewPrivateKey  func(id uint8) Prv
+	NewPublicKey   func(id uint8) Pub
+	Encapsulate    func(rng io.Reader, pub Pub) (ctext []byte, secret []byte, err error)
+	Decapsulate    func(prv Prv, pub Pub, ctext []byte) ([]byte, error)
+	CiphertextSize func(id uint8) int
+	// Size of shared secret
+	SharedSecretSize int
+	// Offset of SIKE ciphertext in the hybrid ciphertext
+	SIKEOffset func(id uint8) int
+}
+
+// CheckErr fails the test if err != nil. Displays msg as an error message.
+func CheckErr(t testing.TB, err error, msg string) {
+	if err != nil {
+		t.Errorf("%s [%s]", msg, err)
+	}
+}
+
+// Do runs f for each parameter set
+func (s *Scheme[Prv, Pub]) Do(f func(*testing.T, uint8), t *testing.T) {
+	for _, id := range s.Ids {
+		fmt.Printf("\tTesting: %s\n", s.Name(id))
+		f(t, id)
+	}
+}
+
+// GenerateKeyPair generates random key pair
+func (s *Scheme[Prv, Pub]) GenerateKeyPair(t *testing.T, id uint8) (Prv, Pub) {
+	prv := s.NewPrivateKey(id)
+	CheckErr(t, prv.Generate(rand.Reader), "private key generation failed")
+	return prv, prv.GeneratePublicKey()
+}
+
+// TestRoundTrip checks that both sides agree on the shared secret, also after
+// export and import of the keys
+func (s *Scheme[Prv, Pub]) TestRoundTrip(t *testing.T, id uint8) {
+	prv, pub := s.GenerateKeyPair(t, id)
+	ct, ss, err := s.Encapsulate(rand.Reader, pub)
+	CheckErr(t, err, "encapsulation failed")
+	if len(ct) != s.CiphertextSize(id) || len(ss) != s.SharedSecretSize {
+		t.Fatal("wrong size of ciphertext or shared secret")
+	}
+
+	// Keys survive export and import
+	prv2 := s.NewPrivateKey(id)
+	pub2 := s.NewPublicKey(id)
+	CheckErr(t, prv2.Import(prv.Export()), "private key import failed")
+	CheckErr(t, pub2.Import(pub.Export()), "public key import failed")
+	if !bytes.Equal(prv2.GeneratePublicKey().Export(), pub.Export()) {
+		t.Error("imported private key doesn't match public key")
+	}
+
+	ss2, err := s.Decapsulate(prv2, pub2, ct)
+	CheckErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss, ss2) {
+		t.Error("shared secrets differ")
+	}
+}
+
+// TestModifiedCiphertext checks that modification of any part of the
+// ciphertext changes the shared secret
+func (s *Scheme[Prv, Pub]) TestModifiedCiphertext(t *testing.T, id uint8) {
+	prv, pub := s.GenerateKeyPair(t, id)
+	ct, ss, err := s.Encapsulate(rand.Reader, pub)
+	CheckErr(t, err, "encapsulation failed")
+
+	for _, i := range []int{0, s.SIKEOffset(id), len(ct) - 1} {
+		ct[i] ^= 0x02
+		ss2, err := s.Decapsulate(prv, pub, ct)
+		CheckErr(t, err, "decapsulation failed")
+		if bytes.Equal(ss, ss2) {
+			t.Errorf("shared secret not changed by modification of byte %d", i)
+		}
+		ct[i] ^= 0x02
+	}
+}
+
+// TestNegative checks that inputs of wrong size and uninitialized keys are
+// rejected
+func (s *Scheme[Prv, Pub]) TestNegative(t *testing.T, id uint8) {
+	prv, pub := s.GenerateKeyPair(t, id)
+	ct, _, err := s.Encapsulate(rand.Reader, pub)
+	CheckErr(t, err, "encapsulation failed")
+
+	if _, err = s.Decapsulate(prv, pub, ct[:len(ct)-1]); err == nil {
+		t.Error("ciphertext of wrong size accepted")
+	}
+	if s.NewPublicKey(id).Import(pub.Export()[1:]) == nil {
+		t.Error("public key of wrong size accepted")
+	}
+	if s.NewPrivateKey(id).Import(prv.Export()[1:]) == nil {
+		t.Error("private key of wrong size accepted")
+	}
+	if _, _, err = s.Encapsulate(rand.Reader, s.NewPublicKey(id)); err == nil {
+		t.Error("encapsulation to uninitialized public key succeeded")
+	}
+}
@@ -0,0 +1,166 @@
+// Package kem defines generic interface of key encapsulation mechanisms and
+// registry of available schemes, so that code using a KEM doesn't depend on
+// a particular scheme. SIKE parameter sets are registered by the package
+// itself (see SIKEp503 and others).
+//
+// Schemes are looked up by name or by object identifier. No OIDs are
+// standardized for SIKE, OIDs used by this package are experimental.
+package kem
+
+import (
+	"encoding/asn1"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// PublicKey is a public key of a KEM
+type PublicKey interface {
+	// Scheme returns scheme the key belongs to
+	Scheme() Scheme
+	// MarshalBinary returns encoding of the key, which can be decoded
+	// with Scheme().UnmarshalBinaryPublicKey
+	MarshalBinary() ([]byte, error)
+	// Equal returns true if both keys are equal
+	Equal(PublicKey) bool
+}
+
+// PrivateKey is a private key of a KEM
+type PrivateKey interface {
+	// Scheme returns scheme the key belongs to
+	Scheme() Scheme
+	// MarshalBinary returns encoding of the key, which can be decoded
+	// with Scheme().UnmarshalBinaryPrivateKey
+	MarshalBinary() ([]byte, error)
+	// Equal returns true if both keys are equal
+	Equal(PrivateKey) bool
+	// Public returns public key corresponding to the private key
+	Public() PublicKey
+}
+
+// Scheme is a key encapsulation mechanism
+type Scheme interface {
+	// Name of the scheme, e.g. "SIKEp503"
+	Name() string
+
+	// GenerateKeyPair generates random key pair using rng
+	GenerateKeyPair(rng io.Reader) (PublicKey, PrivateKey, error)
+	// DeriveKeyPair deterministically derives key pair from seed of
+	// SeedSize() bytes
+	DeriveKeyPair(seed []byte) (PublicKey, PrivateKey, error)
+
+	// Encapsulate generates shared secret and its encapsulation (ciphertext)
+	// for public key pk
+	Encapsulate(rng io.Reader, pk PublicKey) (ct, ss []byte, err error)
+	// Decapsulate returns shared secret encapsulated in ct
+	Decapsulate(sk PrivateKey, ct []byte) ([]byte, error)
+
+	// UnmarshalBinaryPublicKey decodes public key produced by
+	// PublicKey.MarshalBinary
+	UnmarshalBinaryPublicKey([]byte) (PublicKey, error)
+	// UnmarshalBinaryPrivateKey decodes private key produced by
+	// PrivateKey.MarshalBinary
+	UnmarshalBinaryPrivateKey([]byte) (PrivateKey, error)
+
+	// Size of encoded public key
+	PublicKeySize() int
+	// Size of encoded private key
+	PrivateKeySize() int
+	// Size of ciphertext
+	CiphertextSize() int
+	// Size of shared secret
+	SharedKeySize() int
+	// Size of seed required by DeriveKeyPair
+	SeedSize() int
+}
+
+var (
+	// ErrUnknownScheme is returned if scheme is not registered
+	ErrUnknownScheme = errors.New("kem: unknown scheme")
+	// ErrTypeMismatch is returned if key doesn't belong to the scheme
+	ErrTypeMismatch = errors.New("kem: key belongs to other scheme")
+	// ErrPubKeySize is returned if encoded public key has wrong size
+	ErrPubKeySize = errors.New("kem: wrong size of public key")
+	// ErrPrivKeySize is returned if encoded private key has wrong size
+	ErrPrivKeySize = errors.New("kem: wrong size of private key")
+	// ErrCiphertextSize is returned if ciphertext has wrong size
+	ErrCiphertextSize = errors.New("kem: wrong size of ciphertext")
+	// ErrSeedSize is returned if seed has wrong size
+	ErrSeedSize = errors.New("kem: wrong size of seed")
+)
+
+type entry struct {
+	scheme Scheme
+	oid    asn1.ObjectIdentifier
+}
+
+// Registered schemes, in order of registration. Guarded by registryMu.
+var registry []entry
+var registryMu sync.RWMutex
+
+// Register makes scheme s available under its name and oid. oid may be nil
+// if scheme doesn't have one. Returns error in case name or oid is taken.
+func Register(s Scheme, oid asn1.ObjectIdentifier) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, e := range registry {
+		if strings.EqualFold(e.scheme.Name(), s.Name()) {
+			return errors.New("kem: scheme name already registered")
+		}
+		if oid != nil && oid.Equal(e.oid) {
+			return errors.New("kem: scheme OID already registered")
+		}
+	}
+	registry = append(registry, entry{s, oid})
+	return nil
+}
+
+// ByName returns scheme registered under name. Comparison is case
+// insensitive.
+func ByName(name string) (Scheme, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, e := range registry {
+		if strings.EqualFold(e.scheme.Name(), name) {
+			return e.scheme, nil
+		}
+	}
+	return nil, ErrUnknownScheme
+}
+
+// ByOID returns scheme registered under oid
+func ByOID(oid asn1.ObjectIdentifier) (Scheme, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, e := range registry {
+		if e.oid != nil && e.oid.Equal(oid) {
+			return e.scheme, nil
+		}
+	}
+	return nil, ErrUnknownScheme
+}
+
+// OID returns object identifier of registered scheme s, or nil if s is not
+// registered or has no OID.
+func OID(s Scheme) asn1.ObjectIdentifier {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, e := range registry {
+		if e.scheme.Name() == s.Name() {
+			return append(asn1.ObjectIdentifier(nil), e.oid...)
+		}
+	}
+	return nil
+}
+
+// All returns all registered schemes, in order of registration
+func All() []Scheme {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ret := make([]Scheme, len(registry))
+	for i, e := range registry {
+		ret[i] = e.scheme
+	}
+	return ret
+}
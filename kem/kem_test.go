@@ -0,0 +1,170 @@
+package kem
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type SchemeTestingFunc func(*testing.T, Scheme)
+
+func Do(f SchemeTestingFunc, t *testing.T) {
+	for _, s := range All() {
+		fmt.Printf("\tTesting: %s\n", s.Name())
+		f(t, s)
+	}
+}
+
+func checkErr(t testing.TB, err error, msg string) {
+	if err != nil {
+		t.Error(msg)
+	}
+}
+
+func testRoundTrip(t *testing.T, s Scheme) {
+	pk, sk, err := s.GenerateKeyPair(rand.Reader)
+	checkErr(t, err, "key generation failed")
+	ct, ss, err := s.Encapsulate(rand.Reader, pk)
+	checkErr(t, err, "encapsulation failed")
+	if len(ct) != s.CiphertextSize() || len(ss) != s.SharedKeySize() {
+		t.Fatal("wrong size of ciphertext or shared secret")
+	}
+	ss2, err := s.Decapsulate(sk, ct)
+	checkErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss, ss2) {
+		t.Error("shared secrets differ")
+	}
+	if !sk.Public().Equal(pk) || sk.Public().Scheme() != s || sk.Scheme() != s {
+		t.Error("public key doesn't match private key")
+	}
+}
+
+func testMarshal(t *testing.T, s Scheme) {
+	pk, sk, err := s.GenerateKeyPair(rand.Reader)
+	checkErr(t, err, "key generation failed")
+
+	b, err := pk.MarshalBinary()
+	checkErr(t, err, "public key marshaling failed")
+	if len(b) != s.PublicKeySize() {
+		t.Error("wrong size of public key")
+	}
+	pk2, err := s.UnmarshalBinaryPublicKey(b)
+	checkErr(t, err, "public key unmarshaling failed")
+
+	b, err = sk.MarshalBinary()
+	checkErr(t, err, "private key marshaling failed")
+	if len(b) != s.PrivateKeySize() {
+		t.Error("wrong size of private key")
+	}
+	sk2, err := s.UnmarshalBinaryPrivateKey(b)
+	checkErr(t, err, "private key unmarshaling failed")
+	if !pk2.Equal(pk) || !sk2.Equal(sk) {
+		t.Fatal("keys changed by marshaling")
+	}
+
+	ct, ss, err := s.Encapsulate(rand.Reader, pk2)
+	checkErr(t, err, "encapsulation failed")
+	ss2, err := s.Decapsulate(sk2, ct)
+	checkErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss, ss2) {
+		t.Error("shared secrets differ")
+	}
+
+	// Public key part of private key must match
+	_, sk3, err := s.GenerateKeyPair(rand.Reader)
+	checkErr(t, err, "key generation failed")
+	b3, _ := sk3.MarshalBinary()
+	copy(b3[len(b3)-s.PublicKeySize():], b[len(b)-s.PublicKeySize():])
+	if _, err = s.UnmarshalBinaryPrivateKey(b3); err == nil {
+		t.Error("private key with wrong public key accepted")
+	}
+	if _, err = s.UnmarshalBinaryPublicKey(b[1:s.PublicKeySize()]); err != ErrPubKeySize {
+		t.Error("public key of wrong size accepted")
+	}
+	if _, err = s.UnmarshalBinaryPrivateKey(b[1:]); err != ErrPrivKeySize {
+		t.Error("private key of wrong size accepted")
+	}
+}
+
+func testDeriveKeyPair(t *testing.T, s Scheme) {
+	seed := make([]byte, s.SeedSize())
+	io.ReadFull(rand.Reader, seed)
+	pk1, sk1, err := s.DeriveKeyPair(seed)
+	checkErr(t, err, "key derivation failed")
+	pk2, sk2, err := s.DeriveKeyPair(seed)
+	checkErr(t, err, "key derivation failed")
+	if !pk1.Equal(pk2) || !sk1.Equal(sk2) {
+		t.Error("key derivation is not deterministic")
+	}
+	seed[0] ^= 1
+	pk3, _, err := s.DeriveKeyPair(seed)
+	checkErr(t, err, "key derivation failed")
+	if pk1.Equal(pk3) {
+		t.Error("different seeds produce the same key")
+	}
+	if _, _, err = s.DeriveKeyPair(seed[1:]); err != ErrSeedSize {
+		t.Error("seed of wrong size accepted")
+	}
+}
+
+func testTypeMismatch(t *testing.T, s Scheme) {
+	other := SIKEp434
+	if s == other {
+		other = SIKEp503
+	}
+	pk, sk, err := other.GenerateKeyPair(rand.Reader)
+	checkErr(t, err, "key generation failed")
+	if _, _, err = s.Encapsulate(rand.Reader, pk); err != ErrTypeMismatch {
+		t.Error("encapsulation to key of other scheme succeeded")
+	}
+	if _, err = s.Decapsulate(sk, make([]byte, s.CiphertextSize())); err != ErrTypeMismatch {
+		t.Error("decapsulation with key of other scheme succeeded")
+	}
+
+	pk, sk, err = s.GenerateKeyPair(rand.Reader)
+	checkErr(t, err, "key generation failed")
+	if _, err = s.Decapsulate(sk, make([]byte, s.CiphertextSize()+1)); err != ErrCiphertextSize {
+		t.Error("ciphertext of wrong size accepted")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"SIKEp434", "SIKEp503", "SIKEp610", "SIKEp751", "SIKEp964", "SIKEp503r3", "SIKEp751r3"} {
+		s, err := ByName(name)
+		if err != nil || s.Name() != name {
+			t.Errorf("scheme %s not registered", name)
+			continue
+		}
+		s2, err := ByOID(OID(s))
+		if err != nil || s2 != s {
+			t.Errorf("scheme %s not found by OID", name)
+		}
+	}
+
+	if s, err := ByName("sikep751"); err != nil || s != SIKEp751 {
+		t.Error("lookup by name is case sensitive")
+	}
+	if !OID(SIKEp503).Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44363, 46, 3, 503}) {
+		t.Errorf("unexpected OID of SIKEp503: %v", OID(SIKEp503))
+	}
+	if !OID(SIKEp503r3).Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44363, 46, 4, 3, 503}) {
+		t.Errorf("unexpected OID of SIKEp503r3: %v", OID(SIKEp503r3))
+	}
+	if _, err := ByName("SIKEp1"); err != ErrUnknownScheme {
+		t.Error("unknown scheme found by name")
+	}
+	if _, err := ByOID(asn1.ObjectIdentifier{1, 2, 3}); err != ErrUnknownScheme {
+		t.Error("unknown scheme found by OID")
+	}
+	if Register(SIKEp503, nil) == nil {
+		t.Error("scheme registered twice")
+	}
+}
+
+func TestRoundTrip(t *testing.T)     { Do(testRoundTrip, t) }
+func TestMarshal(t *testing.T)       { Do(testMarshal, t) }
+func TestDeriveKeyPair(t *testing.T) { Do(testDeriveKeyPair, t) }
+func TestTypeMismatch(t *testing.T)  { Do(testTypeMismatch, t) }
@@ -0,0 +1,173 @@
+package kem
+
+import (
+	"crypto/subtle"
+	"io"
+
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+)
+
+// SIKE schemes. Public key is encoded as by sidh.PublicKey.Export, private
+// key in NIST format: s || sk || pk.
+var (
+	SIKEp434 Scheme = &sikeScheme{sidh.FP_434}
+	SIKEp503 Scheme = &sikeScheme{sidh.FP_503}
+	SIKEp610 Scheme = &sikeScheme{sidh.FP_610}
+	SIKEp751 Scheme = &sikeScheme{sidh.FP_751}
+	SIKEp964 Scheme = &sikeScheme{sidh.FP_964}
+
+	// Round 3 versions of SIKEp503 and SIKEp751
+	SIKEp503r3 Scheme = &sikeScheme{sidh.FP_503_R3}
+	SIKEp751r3 Scheme = &sikeScheme{sidh.FP_751_R3}
+)
+
+type sikeScheme struct {
+	id uint8
+}
+
+type sikePublicKey struct {
+	scheme *sikeScheme
+	pub    *sidh.PublicKey
+}
+
+// Private key together with the corresponding public key
+type sikePrivateKey struct {
+	scheme *sikeScheme
+	prv    *sidh.PrivateKey
+	pub    *sidh.PublicKey
+}
+
+// OIDs of SIKE schemes are OIDs of SIKE keys, see sidh.OID
+func init() {
+	for _, s := range []Scheme{SIKEp434, SIKEp503, SIKEp610, SIKEp751, SIKEp964, SIKEp503r3, SIKEp751r3} {
+		oid, err := sidh.OID(s.(*sikeScheme).id, sidh.KeyVariant_SIKE)
+		if err == nil {
+			err = Register(s, oid)
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (s *sikeScheme) Name() string {
+	return sidh.Params(s.id).Name
+}
+
+func (s *sikeScheme) PublicKeySize() int {
+	return sidh.Params(s.id).PublicKeySize
+}
+
+func (s *sikeScheme) PrivateKeySize() int {
+	return sike.NewSIKEPrivateKey(s.id).Size()
+}
+
+func (s *sikeScheme) CiphertextSize() int {
+	params := sidh.Params(s.id)
+	return params.PublicKeySize + params.MsgLen
+}
+
+func (s *sikeScheme) SharedKeySize() int {
+	return sidh.Params(s.id).KemSize
+}
+
+func (s *sikeScheme) SeedSize() int {
+	return sidh.DeriveSeedSize
+}
+
+func (s *sikeScheme) GenerateKeyPair(rng io.Reader) (PublicKey, PrivateKey, error) {
+	prv := sidh.NewPrivateKey(s.id, sidh.KeyVariant_SIKE)
+	if err := prv.Generate(rng); err != nil {
+		return nil, nil, err
+	}
+	sk := &sikePrivateKey{scheme: s, prv: prv, pub: prv.GeneratePublicKey()}
+	return sk.Public(), sk, nil
+}
+
+// DeriveKeyPair derives key pair with sidh.DeriveKeyPair
+func (s *sikeScheme) DeriveKeyPair(seed []byte) (PublicKey, PrivateKey, error) {
+	if len(seed) != sidh.DeriveSeedSize {
+		return nil, nil, ErrSeedSize
+	}
+	prv, pub, err := sidh.DeriveKeyPair(s.id, sidh.KeyVariant_SIKE, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	sk := &sikePrivateKey{scheme: s, prv: prv, pub: pub}
+	return sk.Public(), sk, nil
+}
+
+func (s *sikeScheme) Encapsulate(rng io.Reader, pk PublicKey) (ct, ss []byte, err error) {
+	pub, ok := pk.(*sikePublicKey)
+	if !ok || pub.scheme != s {
+		return nil, nil, ErrTypeMismatch
+	}
+	return sike.Encapsulate(rng, pub.pub)
+}
+
+func (s *sikeScheme) Decapsulate(sk PrivateKey, ct []byte) ([]byte, error) {
+	prv, ok := sk.(*sikePrivateKey)
+	if !ok || prv.scheme != s {
+		return nil, ErrTypeMismatch
+	}
+	if len(ct) != s.CiphertextSize() {
+		return nil, ErrCiphertextSize
+	}
+	return sike.Decapsulate(prv.prv, prv.pub, ct)
+}
+
+func (s *sikeScheme) UnmarshalBinaryPublicKey(b []byte) (PublicKey, error) {
+	if len(b) != s.PublicKeySize() {
+		return nil, ErrPubKeySize
+	}
+	pub := sidh.NewPublicKey(s.id, sidh.KeyVariant_SIKE)
+	if err := pub.Import(b); err != nil {
+		return nil, err
+	}
+	return &sikePublicKey{scheme: s, pub: pub}, nil
+}
+
+// UnmarshalBinaryPrivateKey decodes s || sk || pk. Returns error if pk
+// doesn't correspond to sk.
+func (s *sikeScheme) UnmarshalBinaryPrivateKey(b []byte) (PrivateKey, error) {
+	if len(b) != s.PrivateKeySize() {
+		return nil, ErrPrivKeySize
+	}
+	key := sike.NewSIKEPrivateKey(s.id)
+	if err := key.Import(b); err != nil {
+		return nil, err
+	}
+	return &sikePrivateKey{scheme: s, prv: key.PrivateKey(), pub: key.PublicKey()}, nil
+}
+
+func (pk *sikePublicKey) Scheme() Scheme {
+	return pk.scheme
+}
+
+func (pk *sikePublicKey) MarshalBinary() ([]byte, error) {
+	return pk.pub.Export(), nil
+}
+
+func (pk *sikePublicKey) Equal(other PublicKey) bool {
+	o, ok := other.(*sikePublicKey)
+	return ok && o.scheme == pk.scheme && subtle.ConstantTimeCompare(o.pub.Export(), pk.pub.Export()) == 1
+}
+
+func (sk *sikePrivateKey) Scheme() Scheme {
+	return sk.scheme
+}
+
+// MarshalBinary returns s || sk || pk
+func (sk *sikePrivateKey) MarshalBinary() ([]byte, error) {
+	return append(sk.prv.Export(), sk.pub.Export()...), nil
+}
+
+func (sk *sikePrivateKey) Equal(other PrivateKey) bool {
+	o, ok := other.(*sikePrivateKey)
+	return ok && o.scheme == sk.scheme && subtle.ConstantTimeCompare(o.prv.Export(), sk.prv.Export()) == 1
+}
+
+func (sk *sikePrivateKey) Public() PublicKey {
+	return &sikePublicKey{scheme: sk.scheme, pub: sk.pub}
+}
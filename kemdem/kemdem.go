@@ -0,0 +1,291 @@
+// Package kemdem implements encryption of arbitrarily large streams to a SIKE
+// public key. Key of the AEAD is derived from the shared secret produced by
+// sike.Encapsulate, data is encrypted in chunks, each authenticated
+// separately, so that stream can be processed with constant memory.
+//
+// File format (integers are big-endian):
+//
+//	magic       7 bytes  "SIKEKDM"
+//	version     1 byte   0x01
+//	params      1 byte   SIKE parameter set (sidh.FP_434, ...)
+//	chunk size  4 bytes  size of plaintext of a chunk, 1 to MaxChunkSize
+//	ciphertext           SIKE ciphertext, its size depends on parameter set
+//	chunks               AES-256-GCM encrypted chunks
+//
+// Header is the concatenation of all the fields but chunks. The key is
+// derived from shared secret (ss) as:
+//
+//	key = cSHAKE256(ss || header, L = 256, N = "", S = "SIKE KEM-DEM v1")
+//
+// Each chunk, but the last one, carries exactly chunk size bytes of
+// plaintext, the last one carries 0 to chunk size bytes. Ciphertext of a
+// chunk is 16 bytes longer than its plaintext. Nonce of i-th chunk (counted
+// from 0) is:
+//
+//	i (11 bytes) || 0x01 for the last chunk, 0x00 otherwise
+//
+// which makes truncation, reordering and appending of chunks detectable.
+package kemdem
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/sidh/sidh"
+	"github.com/cloudflare/sidh/sike"
+	cshake "github.com/henrydcase/nobs/hash/sha3"
+)
+
+const (
+	// DefaultChunkSize is the chunk size used if 0 is passed to NewWriter
+	DefaultChunkSize = 64 * 1024
+	// MaxChunkSize is the maximal size of plaintext of a chunk
+	MaxChunkSize = 1 << 24
+
+	version  = 0x01
+	keySize  = 32
+	tagSize  = 16
+	nonceLen = 12
+)
+
+var magic = []byte("SIKEKDM")
+
+// Customization string of cSHAKE used for key derivation
+var kdfCustomization = []byte("SIKE KEM-DEM v1")
+
+var (
+	// ErrFormat is returned if input doesn't have expected format
+	ErrFormat = errors.New("kemdem: wrong format of input")
+	// ErrAuth is returned if chunk of data can't be authenticated
+	ErrAuth = errors.New("kemdem: message authentication failed")
+)
+
+// Derives AEAD from shared secret and header
+func newAEAD(ss, header []byte) (cipher.AEAD, error) {
+	var key [keySize]byte
+	h := cshake.NewCShake256(nil, kdfCustomization)
+	h.Write(ss)
+	h.Write(header)
+	h.Read(key[:])
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Returns nonce of chunk with sequence number seq
+func chunkNonce(seq uint64, last bool) []byte {
+	var nonce [nonceLen]byte
+	binary.BigEndian.PutUint64(nonce[nonceLen-9:], seq)
+	if last {
+		nonce[nonceLen-1] = 1
+	}
+	return nonce[:]
+}
+
+// Writer encrypts data written to it. Close must be called to write the
+// last chunk.
+type Writer struct {
+	w         io.Writer
+	aead      cipher.AEAD
+	chunkSize int
+	buf       []byte
+	seq       uint64
+	err       error
+}
+
+// NewWriter writes header to w and returns Writer encrypting data to pub.
+// chunkSize is the size of plaintext of a chunk, 0 selects DefaultChunkSize.
+func NewWriter(rng io.Reader, w io.Writer, pub *sidh.PublicKey, chunkSize int) (*Writer, error) {
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < 0 || chunkSize > MaxChunkSize {
+		return nil, errors.New("kemdem: wrong chunk size")
+	}
+	ct, ss, err := sike.Encapsulate(rng, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	var header bytes.Buffer
+	header.Write(magic)
+	header.WriteByte(version)
+	header.WriteByte(pub.Params().Id)
+	binary.Write(&header, binary.BigEndian, uint32(chunkSize))
+	header.Write(ct)
+
+	aead, err := newAEAD(ss, header.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(header.Bytes()); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:         w,
+		aead:      aead,
+		chunkSize: chunkSize,
+		buf:       make([]byte, 0, chunkSize+tagSize),
+	}, nil
+}
+
+// Encrypts and writes content of the buffer as a chunk
+func (w *Writer) flush(last bool) error {
+	out := w.aead.Seal(w.buf[:0], chunkNonce(w.seq, last), w.buf, nil)
+	w.seq++
+	w.buf = w.buf[:0]
+	_, err := w.w.Write(out)
+	return err
+}
+
+// Write encrypts p. Data is written to underlying writer in chunks, the
+// last chunk is written by Close.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	for len(p) != 0 {
+		// Chunk is flushed only if more data follows, as the last chunk
+		// may be a full one.
+		if len(w.buf) == w.chunkSize {
+			if w.err = w.flush(false); w.err != nil {
+				return n, w.err
+			}
+		}
+		k := copy(w.buf[len(w.buf):w.chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+k]
+		p = p[k:]
+		n += k
+	}
+	return n, nil
+}
+
+// Close writes the last chunk. It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.flush(true)
+	if w.err == nil {
+		w.err = errors.New("kemdem: write to closed writer")
+		return nil
+	}
+	return w.err
+}
+
+// Reader decrypts data encrypted by Writer. Read returns data only after it
+// has been authenticated. io.EOF is returned only after the last chunk has
+// been authenticated; truncated or modified stream results in ErrAuth or
+// ErrFormat.
+type Reader struct {
+	r    io.Reader
+	aead cipher.AEAD
+	// Ciphertext of a chunk and one byte more, which tells if the chunk is
+	// the last one
+	in    []byte
+	inLen int
+	// Decrypted chunk and its part not returned yet
+	buf  []byte
+	out  []byte
+	seq  uint64
+	last bool
+	err  error
+}
+
+// NewReader reads header from r and returns Reader decrypting data with
+// given keypair.
+func NewReader(r io.Reader, prv *sidh.PrivateKey, pub *sidh.PublicKey) (*Reader, error) {
+	params := pub.Params()
+	fixed := make([]byte, len(magic)+1+1+4)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, ErrFormat
+	}
+	if !bytes.Equal(fixed[:len(magic)], magic) || fixed[len(magic)] != version {
+		return nil, ErrFormat
+	}
+	if fixed[len(magic)+1] != params.Id {
+		return nil, errors.New("kemdem: data encrypted for other parameter set")
+	}
+	chunkSize := int(binary.BigEndian.Uint32(fixed[len(magic)+2:]))
+	if chunkSize < 1 || chunkSize > MaxChunkSize {
+		return nil, ErrFormat
+	}
+
+	ct := make([]byte, params.PublicKeySize+params.MsgLen)
+	if _, err := io.ReadFull(r, ct); err != nil {
+		return nil, ErrFormat
+	}
+	ss, err := sike.Decapsulate(prv, pub, ct)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(ss, append(fixed, ct...))
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		r:    r,
+		aead: aead,
+		in:   make([]byte, chunkSize+tagSize+1),
+		buf:  make([]byte, 0, chunkSize),
+	}, nil
+}
+
+// Reads and decrypts next chunk
+func (r *Reader) next() error {
+	n, err := io.ReadFull(r.r, r.in[r.inLen:])
+	r.inLen += n
+	switch err {
+	case nil:
+		// Full chunk followed by at least one more byte
+	case io.EOF, io.ErrUnexpectedEOF:
+		r.last = true
+	default:
+		return err
+	}
+
+	chunkLen := r.inLen
+	if !r.last {
+		chunkLen--
+	}
+	if chunkLen < tagSize {
+		return ErrFormat
+	}
+	out, err := r.aead.Open(r.buf[:0], chunkNonce(r.seq, r.last), r.in[:chunkLen], nil)
+	if err != nil {
+		return ErrAuth
+	}
+	r.seq++
+	r.out = out
+	if !r.last {
+		// The extra byte belongs to the next chunk
+		r.in[0] = r.in[chunkLen]
+		r.inLen = 1
+	}
+	return nil
+}
+
+// Read reads decrypted data
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.last {
+			r.err = io.EOF
+			return 0, io.EOF
+		}
+		if r.err = r.next(); r.err != nil {
+			return 0, r.err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
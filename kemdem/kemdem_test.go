@@ -0,0 +1,171 @@
+package kemdem
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+
+	"github.com/cloudflare/sidh/sidh"
+)
+
+func generateKeyPair(t *testing.T, id uint8) (*sidh.PrivateKey, *sidh.PublicKey) {
+	prv := sidh.NewPrivateKey(id, sidh.KeyVariant_SIKE)
+	if err := prv.Generate(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	return prv, prv.GeneratePublicKey()
+}
+
+func encrypt(t *testing.T, pub *sidh.PublicKey, pt []byte, chunkSize int) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(rand.Reader, &buf, pub, chunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write in pieces of various sizes
+	for i, n := 0, 1; i < len(pt); i, n = i+n, n*3+1 {
+		if i+n > len(pt) {
+			n = len(pt) - i
+		}
+		if _, err = w.Write(pt[i : i+n]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decrypt(prv *sidh.PrivateKey, pub *sidh.PublicKey, ct []byte) ([]byte, error) {
+	r, err := NewReader(iotest.HalfReader(bytes.NewReader(ct)), prv, pub)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(iotest.OneByteReader(r))
+}
+
+func TestRoundTrip(t *testing.T) {
+	prv, pub := generateKeyPair(t, sidh.FP_434)
+	params := pub.Params()
+	headerSize := len(magic) + 6 + params.PublicKeySize + params.MsgLen
+
+	for _, chunkSize := range []int{1, 16, 1000} {
+		for _, n := range []int{0, 1, 15, 16, 17, 32, 1000, 2500} {
+			pt := make([]byte, n)
+			rand.Read(pt)
+			ct := encrypt(t, pub, pt, chunkSize)
+
+			chunks := n/chunkSize + 1
+			if n != 0 && n%chunkSize == 0 {
+				chunks--
+			}
+			if len(ct) != headerSize+n+chunks*tagSize {
+				t.Errorf("wrong size of ciphertext: %d (chunk size %d, plaintext %d)", len(ct), chunkSize, n)
+			}
+
+			got, err := decrypt(prv, pub, ct)
+			if err != nil || !bytes.Equal(got, pt) {
+				t.Errorf("roundtrip failed (chunk size %d, plaintext %d): %v", chunkSize, n, err)
+			}
+		}
+	}
+}
+
+func TestLargeStream(t *testing.T) {
+	prv, pub := generateKeyPair(t, sidh.FP_503)
+	pt := make([]byte, 3*DefaultChunkSize+123)
+	rand.Read(pt)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(rand.Reader, &buf, pub, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.Copy(w, bytes.NewReader(pt)); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write([]byte{1}); err == nil {
+		t.Error("write to closed writer succeeded")
+	}
+
+	r, err := NewReader(&buf, prv, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(r)
+	if err != nil || !bytes.Equal(got, pt) {
+		t.Errorf("roundtrip failed: %v", err)
+	}
+}
+
+func TestTampering(t *testing.T) {
+	const chunkSize = 16
+	prv, pub := generateKeyPair(t, sidh.FP_434)
+	params := pub.Params()
+	headerSize := len(magic) + 6 + params.PublicKeySize + params.MsgLen
+	chunkLen := chunkSize + tagSize
+
+	pt := make([]byte, 3*chunkSize+5)
+	ct := encrypt(t, pub, pt, chunkSize)
+
+	swapped := append([]byte{}, ct...)
+	copy(swapped[headerSize:], ct[headerSize+chunkLen:headerSize+2*chunkLen])
+	copy(swapped[headerSize+chunkLen:], ct[headerSize:headerSize+chunkLen])
+
+	for name, bad := range map[string][]byte{
+		"truncated at chunk boundary": ct[:headerSize+3*chunkLen],
+		"truncated in the chunk":      ct[:len(ct)-1],
+		"only header":                 ct[:headerSize],
+		"appended data":               append(append([]byte{}, ct...), 0),
+		"reordered chunks":            swapped,
+	} {
+		if _, err := decrypt(prv, pub, bad); err == nil {
+			t.Errorf("%s: decryption succeeded", name)
+		}
+	}
+
+	// Modification of any byte is detected
+	for _, i := range []int{len(magic) + 4, headerSize - 1, headerSize, len(ct) - 1} {
+		ct[i] ^= 1
+		if _, err := decrypt(prv, pub, ct); err == nil {
+			t.Errorf("modification of byte %d not detected", i)
+		}
+		ct[i] ^= 1
+	}
+
+	// Other key
+	prv2, pub2 := generateKeyPair(t, sidh.FP_434)
+	if _, err := decrypt(prv2, pub2, ct); err != ErrAuth {
+		t.Error("decryption with other key succeeded")
+	}
+	prv3, pub3 := generateKeyPair(t, sidh.FP_503)
+	if _, err := decrypt(prv3, pub3, ct); err == nil {
+		t.Error("decryption with other parameter set succeeded")
+	}
+}
+
+func TestMalformedHeader(t *testing.T) {
+	prv, pub := generateKeyPair(t, sidh.FP_434)
+	ct := encrypt(t, pub, []byte("msg"), 0)
+	for name, bad := range map[string][]byte{
+		"empty":         nil,
+		"wrong magic":   append([]byte("SIKEKDX"), ct[len(magic):]...),
+		"wrong version": append(append([]byte{}, magic...), append([]byte{2}, ct[len(magic)+1:]...)...),
+		"zero chunk":    append(append([]byte{}, ct[:len(magic)+2]...), append([]byte{0, 0, 0, 0}, ct[len(magic)+6:]...)...),
+		"short":         ct[:len(magic)+10],
+	} {
+		if _, err := decrypt(prv, pub, bad); err != ErrFormat {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+	if _, err := NewWriter(rand.Reader, ioutil.Discard, pub, MaxChunkSize+1); err == nil {
+		t.Error("chunk size above maximum accepted")
+	}
+}
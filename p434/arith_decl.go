@@ -0,0 +1,46 @@
+// +build amd64,!noasm
+
+package p434
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+)
+
+// If choice = 0, leave x,y unchanged. If choice = 1, set x,y = y,x.
+// If choice is neither 0 nor 1 then behaviour is undefined.
+// This function executes in constant time.
+//go:noescape
+func fp434ConditionalSwap(x, y *FpElement, choice uint8)
+
+// Compute z = x + y (mod p).
+//go:noescape
+func fp434AddReduced(z, x, y *FpElement)
+
+// Compute z = x - y (mod p).
+//go:noescape
+func fp434SubReduced(z, x, y *FpElement)
+
+// Compute z = x + y, without reducing mod p.
+//go:noescape
+func fp434AddLazy(z, x, y *FpElement)
+
+// Compute z = x + y, without reducing mod p.
+//go:noescape
+func fp434X2AddLazy(z, x, y *FpElementX2)
+
+// Compute z = x - y, without reducing mod p.
+//go:noescape
+func fp434X2SubLazy(z, x, y *FpElementX2)
+
+// Reduce a field element in [0, 2*p) to one in [0,p).
+//go:noescape
+func fp434StrongReduce(x *FpElement)
+
+// Computes z = x * y.
+//go:noescape
+func fp434Mul(z *FpElementX2, x, y *FpElement)
+
+// Computes the Montgomery reduction z = x R^{-1} (mod 2*p). On return value
+// of x may be changed. z=x not allowed.
+//go:noescape
+func fp434MontgomeryReduce(z *FpElement, x *FpElementX2)
@@ -0,0 +1,99 @@
+package p434
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"math/big"
+	"testing"
+)
+
+func TestArith(t *testing.T) { field.TestArith(t) }
+
+func TestPrimeFieldElementToBigInt(t *testing.T) {
+	// Chosen so that p < xR < 2p
+	x := primeFieldElement{A: FpElement{
+		1, 1, 1, 1, 1, 1, 620258357900101,
+	},
+	}
+	// Computed using Sage:
+	// sage: p = 2^e2 * 3^e3 - 1
+	// sage: R = 2^448
+	// sage: from_radix_64 = lambda xs: sum((xi * (2**64)**i for i,xi in enumerate(xs)))
+	// sage: xR = from_radix_64([1]*6 + [620258357900101])
+	// sage: assert(p < xR)
+	// sage: assert(xR < 2*p)
+	// sage: (xR / R) % p
+	xBig, _ := new(big.Int).SetString("1128296765531023234751923916848904389157994738193968198263229476450164153854474120632917090449891225672349322294191656501334183040", 10)
+	if xBig.Cmp(field.ToBigInt(&x.A)) != 0 {
+		t.Error("Expected", xBig, "found", field.ToBigInt(&x.A))
+	}
+}
+
+func BenchmarkFp2Element(b *testing.B) { field.BenchmarkFp2(b, bench_x, bench_y) }
+
+// --- field operation functions
+
+func BenchmarkFp434Multiply(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp434Mul(&benchmarkFpElementX2, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp434MontgomeryReduce(b *testing.B) {
+	z := bench_z
+
+	// This benchmark actually computes garbage, because
+	// fp434MontgomeryReduce mangles its input, but since it's
+	// constant-time that shouldn't matter for the benchmarks.
+	for n := 0; n < b.N; n++ {
+		fp434MontgomeryReduce(&benchmarkFpElement, &z)
+	}
+}
+
+func BenchmarkFp434AddReduced(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp434AddReduced(&benchmarkFpElement, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp434SubReduced(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp434SubReduced(&benchmarkFpElement, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp434ConditionalSwap(b *testing.B) {
+	x, y := bench_x, bench_y
+	for n := 0; n < b.N; n++ {
+		fp434ConditionalSwap(&x, &y, 1)
+		fp434ConditionalSwap(&x, &y, 0)
+	}
+}
+
+func BenchmarkFp434StrongReduce(b *testing.B) {
+	x := bench_x
+	for n := 0; n < b.N; n++ {
+		fp434StrongReduce(&x)
+	}
+}
+
+func BenchmarkFp434AddLazy(b *testing.B) {
+	var z FpElement
+	x, y := bench_x, bench_y
+	for n := 0; n < b.N; n++ {
+		fp434AddLazy(&z, &x, &y)
+	}
+}
+
+func BenchmarkFp434X2AddLazy(b *testing.B) {
+	x, y, z := bench_z, bench_z, bench_z
+	for n := 0; n < b.N; n++ {
+		fp434X2AddLazy(&x, &y, &z)
+	}
+}
+
+func BenchmarkFp434X2SubLazy(b *testing.B) {
+	x, y, z := bench_z, bench_z, bench_z
+	for n := 0; n < b.N; n++ {
+		fp434X2SubLazy(&x, &y, &z)
+	}
+}
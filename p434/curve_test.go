@@ -0,0 +1,29 @@
+package p434
+
+import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"testing"
+)
+
+// j-invariant of the curve, computed using Sage
+// j = 10762326662368860923968698292301289006836992010331908341061631268852577922402859289832180391211491848728949853819444679146116283296*i + 21058657321905824498889265337598189018993845173204118033234231812341242148547982768617955303277973661396054024506357245585626182863
+var known_j = Fp2Element{
+	A: FpElement{0x31b0e313d02024fa, 0xf25051b36fd5fa05, 0x1b018b02a13f7e9d, 0xd59b24185b33aac7, 0xd28a5b393b2046a6, 0x868274ba4f27f001, 0x214a02edd7f6f},
+	B: FpElement{0xa1ef6f575dd92dc2, 0xa236286d589048d2, 0xee0115fda2c9dc, 0xec02d799c848e9ad, 0x2199628ee4aa7604, 0xfcb994db7f7b3fea, 0x1048164516b0e},
+}
+
+var curveVectors = &fieldtest.CurveVectors{
+	A: curve_A, C: curve_C, J: known_j,
+	XP: affine_xP, XP2: affine_xP2, XP4: affine_xP4, XP9: affine_xP9,
+	M: mScalarBytes[:], XmP: affine_xaP,
+}
+
+func TestCurve(t *testing.T) { field.TestCurve(t, curveVectors) }
+
+func BenchmarkThreePointLadder255BitScalar(b *testing.B) {
+	var mScalarBytes = [...]uint8{203, 155, 185, 191, 131, 228, 50, 178, 207, 191, 61, 141, 174, 173, 207, 243, 159, 243, 46, 163, 19, 102, 69, 92, 36, 225, 0, 37, 114, 19, 191, 0}
+	for n := 0; n < b.N; n++ {
+		kCurveOps.ScalarMul3Pt(&curve, &threePointLadderInputs[0], &threePointLadderInputs[1], &threePointLadderInputs[2], 255, mScalarBytes[:])
+	}
+}
@@ -0,0 +1,101 @@
+package p434
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"testing"
+)
+
+func TestFourIsogenyVersusSage(t *testing.T) {
+	var xR, xP4, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny4(kFieldOps)
+
+	// Given 4-torsion point on E, constructs a four-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP4 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x1CDD26CABCA81BE7, 0xCD6F505D5C4446AC, 0x8F225FC1DD61841, 0xE1D4E3E725C0146F, 0x65277E7716A0383F, 0x7EE9E495F1F751D7, 0x20D755621C297},
+			B: FpElement{0xC90096829A8F53B6, 0xA90E945A70BA3C5, 0x13C2B59DF508B6C6, 0x2E6762F249F5565F, 0x7CFEEEBE4E90D242, 0x5D95E26D08C73B38, 0x1A0D49F6DED83},
+		},
+		Z: Fp2Element{
+			A: FpElement{0xB04AF01FEAEE4102, 0x9516ADC84846E84, 0xB146516DC99C9B01, 0x48243C1FA040CF55, 0x1D5B375F609368EF, 0x3E9740F1A5B07DED, 0x39C0A201673D},
+			B: FpElement{0xC780D79B73DD9C81, 0x4C0853A3393A483D, 0x97B43AB9B9BA4B9F, 0x2CB939596436B686, 0xAC1765D29518E5EF, 0x631AB5D16A6B2DAA, 0x1F0B05224705D},
+		},
+	}
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x802C049E58CE33B, 0xB00B8E8499B647BA, 0xFF261B4C7CBB5A78, 0x234647FF26DB8CCE, 0xB25852CE951491F8, 0x284BECCF64424C88, 0x6A312331F565},
+			B: FpElement{0xAA9C90CE9F36467A, 0xFB65FBB2EF6A74EF, 0x8B9CD7D2FDCADE72, 0x7B3BF0541F64D9BE, 0xDD8D40EB96A33881, 0xA9C58AEAF8D56770, 0x13C255C58C14A},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x4AC867A411AEF192, 0x930B39C9811E5E8B, 0x9347A55F3280387F, 0x902F64F6185A75CA, 0x5AF64262A1F007BC, 0x9D530AB80407FE77, 0x12EEA953BD662},
+			B: FpElement{0x8939C585186E3241, 0x1EA74C9C3743E0EB, 0xA8806378053A5E51, 0x7424D46939ACBE12, 0x842C28BE4AB4DB86, 0xA1D0AB303296E992, 0x101EA69C8B355},
+		},
+	}
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xF60CE9AF1B94458A, 0x9F68711E604842B1, 0x5A1E5991A9FA44CF, 0x1453A7D490781A73, 0x2B92403699EA6D29, 0xB18D4510D313170F, 0x1DFCBD0868860},
+			B: FpElement{0xEAB718879BCD1C18, 0x6C6F81DCAE79B1AE, 0xBC8E3CE8E27D4437, 0x54B3701C32CB90B0, 0x5C930590DD784448, 0x2D950A6279CEACFF, 0x308ECD5E89F2},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x67CA15B53E7160C9, 0xDFBFDE2E9A065F3A, 0xFC79EF62BF33DE77, 0xA5D39136D26727E0, 0x92B871ACD4F0F62D, 0x5367BB8AAD3561F7, 0x20F84CDEEB775},
+			B: FpElement{0x364695B13CDF7707, 0x65F3C622BA3CB2DF, 0xEE54EEEFCA24771B, 0x39D430B838609F43, 0x5B6B28BD81E51E66, 0xCE7EE4CAC6FE9CFF, 0x1B8B5DD09B7B9},
+		},
+	}
+
+	phi.GenerateCurve(&xP4)
+	resPhiXr = phi.EvaluatePoint(&xR)
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
+
+func TestThreeIsogenyVersusSage(t *testing.T) {
+	var xR, xP3, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny3(kFieldOps)
+
+	// Given 3-torsion point on E, constructs a three-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP3 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x309AB7B595E64412, 0x9D4D1E3CF9AA0140, 0x8F5B03F8A6265C4F, 0x829B23F4E5BEE415, 0xCE28A200A6CB8F97, 0xF77A0BB4E42C2571, 0xC86D07330552},
+			B: FpElement{0x74B42BF4CE1232D0, 0xC679A77F07D88BEE, 0x5D6BAC75FFD06411, 0x6CA3A7D80AF6FD1D, 0xCB535CEC0736ED93, 0xF1892567DDDDDF09, 0x16B54330246BE},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x2C77076EE9C8F5CB, 0x3DA796C848AC0562, 0x76B818FFCB86CFED, 0x178B45EB76A3E175, 0x1F2032C689EF860B, 0xEDAAF9BEED899578, 0x9A122ABCDFFE},
+			B: FpElement{0x3E2F9BDEFFACC441, 0x8DD400B9D1A39676, 0x98DAEF3761DFE820, 0x8F30FD0FBB2770AC, 0x311DF82C85010767, 0xD2798597572BBB0B, 0x1B5E2739307A2},
+		},
+	}
+
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x5AE4BD45DC007DCD, 0x573E63FBE8D7191A, 0x12B373DC8DA44BD9, 0xBDC69E578763E6CF, 0xFCE3BBF10D53944C, 0x16CA1E6354236661, 0xB15DE8DDCB74},
+			B: FpElement{0x35535B42CB912121, 0x51128446C2169D91, 0x912D2F969DAB6657, 0x8ABB19B791C77DB3, 0x205962B777FCBD84, 0x63F8761D958CDAE5, 0x6E1F22101E05},
+		},
+		Z: Fp2Element{
+			A: FpElement{0xC45EF510C96BB29, 0xB78E3D30FC69D814, 0xCB133B76FC6FAC60, 0x68FA56FDAC2C10DB, 0xDE4123E62D4707F0, 0x3972869F40AE8B2B, 0x137849C72C2D6},
+			B: FpElement{0x6C1B0A89EE69C613, 0xFDDB7F0A8528390C, 0xDD2D9726BCF226A3, 0x4956C5464E9C3FFA, 0x6938AD31BA6C9646, 0xFFF749BEE74F466, 0x22F6DD2C0CB},
+		},
+	}
+
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xA47950AB8D7D18ED, 0x226B8E519DCD0B97, 0x2AB642BF5EE53ACD, 0x912C9B410DE2A07C, 0x5674D20F77A32472, 0xDEAFAE723A5B6DCA, 0x92C33404136},
+			B: FpElement{0x4578F12451ED91D7, 0x860BC9A710D607B9, 0x891D778087A2FE0E, 0x8FE78145BCBCADA0, 0x987305ADA4A65E44, 0x3CDB4F5CC68EEFC0, 0x26E957A7137F},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x53BE2A5C2ABE742D, 0xECBABB01E51C76D5, 0xE62247FAACB2864D, 0xCB9D438DA20D91B6, 0xD367311135E9E83F, 0x5E6C54A68D48ACC0, 0x13520CB789498},
+			B: FpElement{0x6E7228D1E31F5C36, 0xEC293D6DCF70BB8B, 0x2896F0602AFA3767, 0xFDEA834BDE391E35, 0x1C2E0D55772A2F3E, 0x143A8995264AA2A3, 0xBC95F85D702C},
+		},
+	}
+
+	phi.GenerateCurve(&xR)
+	resPhiXr = phi.EvaluatePoint(&xP3)
+
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
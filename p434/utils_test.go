@@ -0,0 +1,127 @@
+package p434
+
+// Tools used for testing and debugging
+
+import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"math/big"
+)
+
+/* -------------------------------------------------------------------------
+   Underlying field configuration
+   -------------------------------------------------------------------------*/
+var (
+	field = &fieldtest.Field{
+		Prime:    p434BigIntPrime,
+		NumWords: NumWords,
+		Bytelen:  P434_Bytelen,
+		OneFp2:   P434_OneFp2,
+		HalfFp2:  P434_HalfFp2,
+		Op:       FieldOperations(),
+		Mul: func(z, x, y *FpElement) {
+			*z = new(primeFieldElement).Mul(&primeFieldElement{A: *x}, &primeFieldElement{A: *y}).A
+		},
+		P34: func(z, x *FpElement) {
+			*z = new(primeFieldElement).P34(&primeFieldElement{A: *x}).A
+		},
+		MontgomeryReduce: fp434MontgomeryReduce,
+		StrongReduce:     fp434StrongReduce,
+		CondSwap:         fp434ConditionalSwap,
+	}
+	kFieldOps = field.Op
+	kCurveOps = field.CurveOps()
+)
+
+// Used by tests of assembly implementation
+var quickCheckConfig = fieldtest.QuickCheckConfig
+
+// A = 9611791263128369049064171874853458864956503561720863910852003724852077792087916235559017297588967860687320820664644150197285371324*i + 15221325938666368451530666120225721277353388910737701572799647734961977074585464937639791068119878913374016970493124073531562479916
+var curve_A = Fp2Element{
+	A: FpElement{0x77ab69df169217c6, 0x4de4b7e4ec6b85, 0x4765f6d4f8351263, 0x152c8023a0350bdf, 0x4a72458bbab6aa99, 0x92ff92d966bb5363, 0x35a37c27932c},
+	B: FpElement{0x1e1c5f1b116a6bca, 0x2a608b19cc7548ac, 0xd40d7f777e1c8573, 0x8d5f0176f0d5f9bd, 0x77758c6957ac11c6, 0xff56f4016fee252d, 0x1060ae84e75cb}}
+
+// C = 1759104415288021249090959964574336386629141855212913439348438882222061466030142743347317828013080431811279126259697420341150835392*i + 12841239873393732947032761866851734367023291916594667469524389254390564567638460330334670188818513358397470754293515631295245115734
+var curve_C = Fp2Element{
+	A: FpElement{0xfe082694e6811663, 0x244d25fa2a255a38, 0x8d7a385bb7de1281, 0xd43bde95e433558c, 0x3978476c95a2543a, 0x2b2c867c1e20b340, 0x4c230bef7121},
+	B: FpElement{0xbede6d058675300e, 0x131ec2e57f430750, 0xcce78272f4adc3d0, 0x1f0e9e4e24c70028, 0x57fe78846446c72d, 0x3037e68ed136dda1, 0x224e972544f8e}}
+
+// x(P) = 22317949671583894534037946301573759236082291984406518730326238798691484906583526512792391102444431111734700359736192225990921386081*i + 19223388730141001680464825656640600171341130309924732272293581381078340584135307892679769945808131254976910649531805984873089071264
+var affine_xP = Fp2Element{
+	A: FpElement{0x16498391babcb117, 0xaab7a4c5ec8037c9, 0x5ddbeb6fc9bfa999, 0x4c27423cbd9803f2, 0x26a52b3883c03e8e, 0x29075e9d35985fe2, 0x17758f0e54dd6},
+	B: FpElement{0xac3674239a756eb9, 0x4e964ad24db3a31, 0xfb54bb977ec95edc, 0xbeefedf93ed0471e, 0xac565702fbe9ce8f, 0x5e5467a03f0b965b, 0x90c84a9f1ca0}}
+
+// x(Q) = 19702154976534203440206016182665136816837647528150372136388406638515775106315904336346241374963083703259221293347304097284135580675*i + 11111065558323976176460047950126962405566749450981033842046645757785029284173326792134123139979418431296662714945900134360450303392
+var affine_xQ = Fp2Element{
+	A: FpElement{0x32d3dbd111481c24, 0xec0e7c64bec0d687, 0xa6a148677b3f186b, 0x65c8de9e870788e5, 0x84ae321a5e2ffbeb, 0xda13cd189786ea6a, 0x1d3b3e2c0e703},
+	B: FpElement{0xa842d1882116f73a, 0x2fa15cf637d13585, 0xa1a432ebf17d0e98, 0x5879b1ee4f67156e, 0xffefa157de01f9bb, 0x4e0562edfc65541f, 0xc0c38f196f96}}
+
+var affine_xPmQ = Fp2Element{
+	A: FpElement{0xe95f7d04ed944273, 0xc0f4e768ebc3923f, 0x4a1e7893aec56b33, 0x488b3bea43e0f9e9, 0x7b2bb67f5740dc6d, 0xa357453fe40d44df, 0x5f095516541f},
+	B: FpElement{0x83041b54258d9ebc, 0xaab8805ac1ccfa0, 0xc14e79c32db5c342, 0x972f740240f427f2, 0xc4ca3bc522cb9a92, 0x33bd481cbc9c4139, 0x4598a721c36f}}
+
+var affine_xP2 = Fp2Element{
+	A: FpElement{0x27a7741d43577c7b, 0x5488c9cb2b78c37e, 0x81af184582591b9b, 0x8d4f83a595be69e8, 0x854a4841a57c0cea, 0x3aeec0588cabfe39, 0xbd8754fc70ac},
+	B: FpElement{0x29ac20051861e010, 0x8f2e364647d02e24, 0xee55cc018867363c, 0x8636218701d6a55b, 0x18fe52ee7970cc09, 0x58c9beda248b0357, 0x3eb187f5b08}}
+
+var affine_xP3 = Fp2Element{
+	A: FpElement{0x99fdeef714849cc1, 0x4ea4a3b046394d3d, 0x68820f082d8e964f, 0xefe279aa89c8f43e, 0x7a0980d25eb7eee4, 0x955e397c579e588f, 0x9b24be3a4699},
+	B: FpElement{0x7f60ee6f3df325da, 0xa1ded11fba8e0424, 0x1749e40ecfdf2f0c, 0xe441948735461b3b, 0x71f3fffcea8d3b8a, 0xae833d4f821b96ca, 0x1edc72f9d7cd}}
+
+var affine_xP4 = Fp2Element{
+	A: FpElement{0x900c196eb5c9877a, 0x129b93fbd2dc208, 0x73a243e2ca553481, 0x1e31f2750ce07c83, 0x4939f4194f2ea2ec, 0xd11158258236086f, 0xcd85e314054c},
+	B: FpElement{0x699d0f71136546e, 0xea0c65829fd814d0, 0x3d99215dcf52ca33, 0x87f47d576f2c622f, 0xa50c54e25873a736, 0xf3159071552d3af6, 0x1a5eff18a67cb}}
+
+var affine_xP9 = Fp2Element{
+	A: FpElement{0xb190fe3a0844fba0, 0x6b9a8c5104f94d62, 0xd232af60fbaa4945, 0x723833e269bb49ca, 0xacb4627cb1378a1, 0xe7928e2f13b4f756, 0x1da0e05157a09},
+	B: FpElement{0xfaeefb190dbb6458, 0x76398700af698270, 0x4d76280334110d1, 0x63db9bdd69e295c6, 0xd4e3e3aa5faf61, 0x87fb526df33549a9, 0x1e0ba0f72a520}}
+
+// m = 4496879016704295246227825384202436150199587577534182331259
+var mScalarBytes = [...]uint8{0x7b, 0x13, 0x6c, 0x4f, 0xce, 0xb3, 0xa3, 0x29, 0x53, 0x5f, 0xe0, 0xef, 0x62, 0xb3, 0x6b, 0x63, 0x6b, 0x1d, 0x47, 0x99, 0x8a, 0x9b, 0x65, 0xb7}
+
+var affine_xaP = Fp2Element{
+	A: FpElement{0x4b6fcc657be550ba, 0x773a9430bd17b368, 0xe33b56024554fc9f, 0x6fa8f00fccec70af, 0xd7f38f2c6151852e, 0xfa179df728620057, 0x1b2da9b52cac},
+	B: FpElement{0x6e58f5bc2dfcb031, 0x8442f1a06e3fca65, 0xb64d959a28162fae, 0x26ba040a8e4b0266, 0x5990485597edcb9e, 0x8c455e813dae83a5, 0xb17d4acc64e9}}
+
+// Inputs for testing 3-point-ladder
+var threePointLadderInputs = []ProjectivePoint{
+	// x(P)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x6B4C7ED2F2588E2E, 0x3ABBACE0E6598442, 0xAF2F460A824C350B, 0x4BB7A8BA1DB45D0C, 0x14AE21FECC4D86A9, 0xFF73FA32674DA52C, 0x6808F89A08B2},
+			B: FpElement{0x663186148E37CA7D, 0x8D6084D807141915, 0x16BC1A5CBBFC8A91, 0x9FB592580476F18E, 0x26026C217658C28E, 0xE39FE1D602516AC4, 0x83572183D5BA}},
+		Z: P434_OneFp2,
+	},
+	// x(Q)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x893BCC571B1AAAF5, 0x2BBA9D2C56907787, 0xDC6DDDBA6693A9CB, 0xCD277D89D82CEAD, 0x82709FB8222222E5, 0x92C0075DC217B327, 0x1E89C9E26F379},
+			B: FpElement{0x59BE5300C3404E4F, 0x9CD3DB6C9CEC5A54, 0x38C6134A143E8297, 0xB15C2652BAD368B8, 0xB3568DFF95CB0D34, 0xDDF3CA5211368E7C, 0x63F50E99C691}},
+		Z: P434_OneFp2,
+	},
+	// x(P-Q)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xEA788EEEBA5CC655, 0xEB563E7100BC9F1D, 0xF5218336112BAD25, 0x9CE42D7D2BF802A2, 0x70340973E2A6BB55, 0x6D8D38FCB10809A8, 0x9FCFDD0883D9},
+			B: FpElement{0x6E5D8B42A8F3BDF, 0xF29D93C7AAE9755E, 0xA4A5A64DA18AB76, 0xE96088E7FA7953C1, 0x3062E0483374B6F8, 0xE8EBA5EC7C8B0714, 0x127724B6B373E}},
+		Z: P434_OneFp2,
+	},
+}
+var curve = ProjectiveCurveParameters{A: curve_A, C: curve_C}
+
+// prime p434
+var p434BigIntPrime, _ = new(big.Int).SetString("24439423661345221551909145011457493619085780243761596511325807336205221239331976725970216671828618445898719026692884939342314733567", 10)
+
+/* -------------------------------------------------------------------------
+   Values used by benchmarking tools
+   -------------------------------------------------------------------------*/
+
+// Package-level storage for this field element is intended to deter
+// compiler optimizations.
+var (
+	benchmarkFpElement   FpElement
+	benchmarkFpElementX2 FpElementX2
+	bench_x              = FpElement{15303244950615830452, 2413421726762226004, 12981650542984745363, 182547901693623239, 711560457100792980, 208069936698220870, 2778181189893492682, 4130644707174153467, 16179804727543145818, 12661891175058022518, 9612443985918843164, 5295782260832923678}
+	bench_y              = FpElement{15350712046446586054, 2336176529549769130, 4530017101217429023, 11004575764949015435, 13535735122898441476, 12171236853478681997, 7674111207737502527, 1975471986718340299, 6349530671699933226, 12364075166134766912, 17239190473832079866, 169700385082089042}
+	bench_z              = FpElementX2{2415316326663656414, 4076551168937886212, 16508419512315111175, 16867107972140249007, 14107981065573055667, 6006465082813267294, 16163346115922101304, 11960650577271293339, 5884094038588279203, 13410525116589019571, 6233227347254113639, 8007073901330625138, 11469941817991334358, 909810338422724552, 13095142321458051810, 11194172201943295563, 7459883531686066027, 10086359155274188417, 4258257768292583181, 11200756471388559835, 2079891322426053886, 13015020552982700963, 12232865683539323384, 633243526619569353}
+)
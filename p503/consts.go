@@ -107,6 +107,78 @@ var P503_affine_RB = Fp2Element{
 	},
 }
 
+// The x-coordinate of PA on the starting curve of SIKE round 3
+var P503_R3_affine_PA = Fp2Element{
+	A: FpElement{
+		0x5D083011589AD893, 0xADFD8D2CB67D0637, 0x330C9AC34FFB6361, 0xF0D47489A2E805A2,
+		0x27E2789259C6B8DC, 0x63866A2C121931B9, 0x8D4C65A7137DCF44, 0x003A183AE5967B3F,
+	},
+	B: FpElement{
+		0x7E3541B8C96D1519, 0xD3ADAEEC0D61A26C, 0xC0A2219CE7703DD9, 0xFF3E46658FCDBC52,
+		0xD5B38DEAE6E196FF, 0x1AAC826364956D58, 0xEC9F4875B9A5F27A, 0x001B0B475AB99843,
+	},
+}
+
+// The x-coordinate of QA on the starting curve of SIKE round 3
+var P503_R3_affine_QA = Fp2Element{
+	A: FpElement{
+		0x4D83695107D03BAD, 0x221F3299005E2FCF, 0x78E6AE22F30DECF2, 0x6D982DB5111253E4,
+		0x504C80A8AB4526A8, 0xEFD0C3AA210BB024, 0xCB77483501DC6FCF, 0x001052544A96BDF3,
+	},
+	B: FpElement{
+		0x0D74FE3402BCAE47, 0xDF5B8CDA832D8AED, 0xB86BCF06E4BD837E, 0x892A2933A0FA1F63,
+		0x9F88FC67B6CCB461, 0x822926EA9DDA3AC8, 0xEAC8DDE5855425ED, 0x000618FE6DA37A80,
+	},
+}
+
+// The x-coordinate of RA = PA-QA on the starting curve of SIKE round 3
+var P503_R3_affine_RA = Fp2Element{
+	A: FpElement{
+		0x1D9D32D2DC877C17, 0x5517CD8F71D5B02B, 0x395AFB8F6B60C117, 0x3AE31AC85F9098C8,
+		0x5F5341C198450848, 0xF8C609DBEA435C6A, 0xD832BC7EDC7BA5E4, 0x002AD98AA6968BF5,
+	},
+	B: FpElement{
+		0xC466CAB0F73C2E5B, 0x7B1817148FB2CF9C, 0x873E87C099E470A0, 0xBB17AC6D17A7BAC1,
+		0xA146FDCD0F2E2A58, 0x88B311E9CEAB6201, 0x37604CF5C7951757, 0x0006804071C74BF9,
+	},
+}
+
+// The x-coordinate of PB on the starting curve of SIKE round 3
+var P503_R3_affine_PB = Fp2Element{
+	A: FpElement{
+		0xDF630FC5FB2468DB, 0xC30C5541C102040E, 0x3CDC9987B76511FC, 0xF54B5A09353D0CDD,
+		0x3ADBA8E00703C42F, 0x8253F9303DDC95D0, 0x62D30778763ABFD7, 0x001CD00FB581CD55,
+	},
+	B: FpElement{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	},
+}
+
+// The x-coordinate of QB on the starting curve of SIKE round 3
+var P503_R3_affine_QB = Fp2Element{
+	A: FpElement{
+		0x2E3457A12B429261, 0x311F94E89627DCF8, 0x5B71C98FD1DB73F6, 0x3671DB7DCFC21541,
+		0xB6D1484C9FE0CF4F, 0x19CD110717356E35, 0xF4F9FB00AC9919DF, 0x0035BC124D38A70B,
+	},
+	B: FpElement{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	},
+}
+
+// The x-coordinate of RB = PB - QB on the starting curve of SIKE round 3
+var P503_R3_affine_RB = Fp2Element{
+	A: FpElement{
+		0x2E08BB99413D2952, 0xD3021467CD088D72, 0x21017AF859752245, 0x26314ED8FFD9DE5C,
+		0x4AF43C73344B6686, 0xCFA1F91149DF0993, 0xF327A95365587A89, 0x000DBF54E03D3906,
+	},
+	B: FpElement{
+		0x03E03FF342F5F304, 0x993D604D7B4B6E56, 0x80412F4D9280E71F, 0x0FFDC9EF990B3982,
+		0xE584E64C51604931, 0x1374F42AC8B0BBD7, 0x07D5BC37DFA41A5F, 0x00396CCFD61FD34C,
+	},
+}
+
 // 2-torsion group computation strategy
 var P503_AliceIsogenyStrategy = [strategySizeA]uint32{
 	0x3D, 0x20, 0x10, 0x08, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x02,
@@ -176,3 +248,10 @@ var P503_HalfFp2 = Fp2Element{
 		0x00000000000001FC, 0x0000000000000000, 0x0000000000000000, 0xB000000000000000,
 		0x3B69BB2464785D2A, 0x36824A2AF0FE9896, 0xF5899F427A94F309, 0x0033B15203C83BB8},
 }
+
+// 6*R mod p
+var P503_SixFp2 = Fp2Element{
+	A: FpElement{
+		0x00000000000017D8, 0x0000000000000000, 0x0000000000000000, 0xE000000000000000,
+		0x30B1E6E3A51520FA, 0xB13BC3BF6FFB3992, 0x8045412EEB3E3DED, 0x0069182E2159DBB8},
+}
@@ -46,7 +46,7 @@ func TestFourIsogenyVersusSage(t *testing.T) {
 
 	phi.GenerateCurve(&xP4)
 	resPhiXr = phi.EvaluatePoint(&xR)
-	if !VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
 		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
 	}
 }
@@ -95,7 +95,7 @@ func TestThreeIsogenyVersusSage(t *testing.T) {
 	phi.GenerateCurve(&xR)
 	resPhiXr = phi.EvaluatePoint(&xP3)
 
-	if !VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
 		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
 	}
 }
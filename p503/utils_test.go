@@ -3,43 +3,38 @@ package p503
 // Tools used for testing and debugging
 
 import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
 	. "github.com/cloudflare/sidh/internal/isogeny"
 	"math/big"
-	"math/rand"
-	"reflect"
-	"testing/quick"
 )
 
 /* -------------------------------------------------------------------------
    Underlying field configuration
    -------------------------------------------------------------------------*/
 var (
-	kFieldOps = FieldOperations()
-	kParams   = &SidhParams{
-		Op:      kFieldOps,
-		OneFp2:  P503_OneFp2,
-		HalfFp2: P503_HalfFp2,
-		Bytelen: P503_Bytelen,
+	field = &fieldtest.Field{
+		Prime:    p503BigIntPrime,
+		NumWords: NumWords,
+		Bytelen:  P503_Bytelen,
+		OneFp2:   P503_OneFp2,
+		HalfFp2:  P503_HalfFp2,
+		Op:       FieldOperations(),
+		Mul: func(z, x, y *FpElement) {
+			*z = new(primeFieldElement).Mul(&primeFieldElement{A: *x}, &primeFieldElement{A: *y}).A
+		},
+		P34: func(z, x *FpElement) {
+			*z = new(primeFieldElement).P34(&primeFieldElement{A: *x}).A
+		},
+		MontgomeryReduce: fp503MontgomeryReduce,
+		StrongReduce:     fp503StrongReduce,
+		CondSwap:         fp503ConditionalSwap,
 	}
-	kCurveOps = &CurveOperations{Params: kParams}
+	kFieldOps = field.Op
+	kCurveOps = field.CurveOps()
 )
 
-/* -------------------------------------------------------------------------
-   Configure testing/quick
-   -------------------------------------------------------------------------*/
-var (
-	quickCheckScaleFactor = uint8(3)
-	quickCheckConfig      = &quick.Config{MaxCount: (1 << (12 + quickCheckScaleFactor))}
-)
-
-/* -------------------------------------------------------------------------
-   Structure used by tests
-   -------------------------------------------------------------------------*/
-type GeneratedTestParams struct {
-	Point   ProjectivePoint
-	Cparam  ProjectiveCurveParameters
-	ExtElem Fp2Element
-}
+// Used by tests of assembly implementation
+var quickCheckConfig = fieldtest.QuickCheckConfig
 
 // A = 8752234765512331234913716743014562460822083005386252003333602919474238975785850965349950219277942402920758585086620525443539725921333735154674119646075*i + 6339624979889725406021454983012408976766782818694212228554611573314701271183857175866122275755278397694585249002282183018114967373119429936587424396917
 var curve_A = Fp2Element{
@@ -130,206 +125,3 @@ var (
 	bench_y              = FpElement{4227467157325093378, 10699492810770426363, 13500940151395637365, 12966403950118934952, 16517692605450415877, 13647111148905630666, 14223628886152717087, 7167843152346903316, 15855377759596736571, 4300673881383687338, 6635288001920617779, 30486099554235}
 	bench_z              = FpElementX2{1595347748594595712, 10854920567160033970, 16877102267020034574, 12435724995376660096, 3757940912203224231, 8251999420280413600, 3648859773438820227, 17622716832674727914, 11029567000887241528, 11216190007549447055, 17606662790980286987, 4720707159513626555, 12887743598335030915, 14954645239176589309, 14178817688915225254, 1191346797768989683, 12629157932334713723, 6348851952904485603, 16444232588597434895, 7809979927681678066, 14642637672942531613, 3092657597757640067, 10160361564485285723, 240071237}
 )
-
-/* -------------------------------------------------------------------------
-   Helpers
-   -------------------------------------------------------------------------*/
-
-// Given xP = x(P), xQ = x(Q), and xPmQ = x(P-Q), compute xR = x(P+Q).
-//
-// Returns xR to allow chaining.  Safe to overlap xP, xQ, xR.
-func AddProjFp2(xP, xQ, xPmQ *ProjectivePoint) ProjectivePoint {
-	// Algorithm 1 of Costello-Smith.
-	var v0, v1, v2, v3, v4 Fp2Element
-	var xR ProjectivePoint
-	kFieldOps.Add(&v0, &xP.X, &xP.Z) // X_P + Z_P
-	kFieldOps.Sub(&v1, &xQ.X, &xQ.Z)
-	kFieldOps.Mul(&v1, &v1, &v0)     // (X_Q - Z_Q)(X_P + Z_P)
-	kFieldOps.Sub(&v0, &xP.X, &xP.Z) // X_P - Z_P
-	kFieldOps.Add(&v2, &xQ.X, &xQ.Z)
-	kFieldOps.Mul(&v2, &v2, &v0) // (X_Q + Z_Q)(X_P - Z_P)
-	kFieldOps.Add(&v3, &v1, &v2)
-	kFieldOps.Square(&v3, &v3) // 4(X_Q X_P - Z_Q Z_P)^2
-	kFieldOps.Sub(&v4, &v1, &v2)
-	kFieldOps.Square(&v4, &v4)         // 4(X_Q Z_P - Z_Q X_P)^2
-	kFieldOps.Mul(&v0, &xPmQ.Z, &v3)   // 4X_{P-Q}(X_Q X_P - Z_Q Z_P)^2
-	kFieldOps.Mul(&xR.Z, &xPmQ.X, &v4) // 4Z_{P-Q}(X_Q Z_P - Z_Q X_P)^2
-	xR.X = v0
-	return xR
-}
-
-// Given xP = x(P) and cached curve parameters Aplus2C = A + 2*C, C4 = 4*C,
-// compute xQ = x([2]P).
-//
-// Returns xQ to allow chaining.  Safe to overlap xP, xQ.
-func DoubleProjFp2(xP *ProjectivePoint, Aplus2C, C4 *Fp2Element) ProjectivePoint {
-	// Algorithm 2 of Costello-Smith, amended to work with projective curve coefficients.
-	var v1, v2, v3, xz4 Fp2Element
-	var xQ ProjectivePoint
-	kFieldOps.Add(&v1, &xP.X, &xP.Z) // (X+Z)^2
-	kFieldOps.Square(&v1, &v1)
-	kFieldOps.Sub(&v2, &xP.X, &xP.Z) // (X-Z)^2
-	kFieldOps.Square(&v2, &v2)
-	kFieldOps.Sub(&xz4, &v1, &v2)     // 4XZ = (X+Z)^2 - (X-Z)^2
-	kFieldOps.Mul(&v2, &v2, C4)       // 4C(X-Z)^2
-	kFieldOps.Mul(&xQ.X, &v1, &v2)    // 4C(X+Z)^2(X-Z)^2
-	kFieldOps.Mul(&v3, &xz4, Aplus2C) // 4XZ(A + 2C)
-	kFieldOps.Add(&v3, &v3, &v2)      // 4XZ(A + 2C) + 4C(X-Z)^2
-	kFieldOps.Mul(&xQ.Z, &v3, &xz4)   // (4XZ(A + 2C) + 4C(X-Z)^2)4XZ
-	// Now (xQ.x : xQ.z)
-	//   = (4C(X+Z)^2(X-Z)^2 : (4XZ(A + 2C) + 4C(X-Z)^2)4XZ )
-	//   = ((X+Z)^2(X-Z)^2 : (4XZ((A + 2C)/4C) + (X-Z)^2)4XZ )
-	//   = ((X+Z)^2(X-Z)^2 : (4XZ((a + 2)/4) + (X-Z)^2)4XZ )
-	return xQ
-}
-
-// Given x(P) and a scalar m in little-endian bytes, compute x([m]P) using the
-// Montgomery ladder.  This is described in Algorithm 8 of Costello-Smith.
-//
-// This function's execution time is dependent only on the byte-length of the
-// input scalar.  All scalars of the same input length execute in uniform time.
-// The scalar can be padded with zero bytes to ensure a uniform length.
-//
-// Safe to overlap the source with the destination.
-func ScalarMult(curve *ProjectiveCurveParameters, xP *ProjectivePoint, scalar []uint8) ProjectivePoint {
-	var x0, x1, tmp ProjectivePoint
-	var Aplus2C, C4 Fp2Element
-
-	kFieldOps.Add(&Aplus2C, &curve.C, &curve.C) // = 2*C
-	kFieldOps.Add(&C4, &Aplus2C, &Aplus2C)      // = 4*C
-	kFieldOps.Add(&Aplus2C, &Aplus2C, &curve.A) // = 2*C + A
-
-	x0.X = P503_OneFp2
-	x1 = *xP
-
-	// Iterate over the bits of the scalar, top to bottom
-	prevBit := uint8(0)
-	for i := len(scalar) - 1; i >= 0; i-- {
-		scalarByte := scalar[i]
-		for j := 7; j >= 0; j-- {
-			bit := (scalarByte >> uint(j)) & 0x1
-			kCurveOps.Params.Op.CondSwap(&x0.X, &x0.Z, &x1.X, &x1.Z, (bit ^ prevBit))
-			//sProjectivePointConditionalSwap(&x0, &x1, (bit ^ prevBit))
-			tmp = DoubleProjFp2(&x0, &Aplus2C, &C4)
-			x1 = AddProjFp2(&x0, &x1, xP)
-			x0 = tmp
-			prevBit = bit
-		}
-	}
-	// now prevBit is the lowest bit of the scalar
-	kCurveOps.Params.Op.CondSwap(&x0.X, &x0.Z, &x1.X, &x1.Z, prevBit)
-	return x0
-}
-
-// Returns true if lhs = rhs.  Takes variable time.
-func VartimeEqFp2(lhs, rhs *Fp2Element) bool {
-	a := *lhs
-	b := *rhs
-
-	fp503StrongReduce(&a.A)
-	fp503StrongReduce(&a.B)
-	fp503StrongReduce(&b.A)
-	fp503StrongReduce(&b.B)
-
-	eq := true
-	for i := 0; i < len(a.A) && eq; i++ {
-		eq = eq && (a.A[i] == b.A[i])
-		eq = eq && (a.B[i] == b.B[i])
-	}
-	return eq
-}
-
-// Returns true if lhs = rhs.  Takes variable time.
-func VartimeEqProjFp2(lhs, rhs *ProjectivePoint) bool {
-	var t0, t1 Fp2Element
-	kFieldOps.Mul(&t0, &lhs.X, &rhs.Z)
-	kFieldOps.Mul(&t1, &lhs.Z, &rhs.X)
-	return VartimeEqFp2(&t0, &t1)
-}
-
-func (GeneratedTestParams) generateFp2p503(rand *rand.Rand) Fp2Element {
-	// Generation strategy: low limbs taken from [0,2^64); high limb
-	// taken from smaller range
-	//
-	// Size hint is ignored since all elements are fixed size.
-	//
-	// Field elements taken in range [0,2p).  Emulate this by capping
-	// the high limb by the top digit of 2*p-1:
-	//
-	// sage: (2*p-1).digits(2^64)[-1]
-	// 36255204122967100
-	//
-	// This still allows generating values >= 2p, but hopefully that
-	// excess is OK (and if it's not, we'll find out, because it's for
-	// testing...)
-	//
-	highLimb := rand.Uint64() % 36255204122967100
-	fpElementGen := func() FpElement {
-		return FpElement{
-			rand.Uint64(),
-			rand.Uint64(),
-			rand.Uint64(),
-			rand.Uint64(),
-			rand.Uint64(),
-			rand.Uint64(),
-			rand.Uint64(),
-			highLimb,
-		}
-	}
-	return Fp2Element{A: fpElementGen(), B: fpElementGen()}
-}
-
-func (c GeneratedTestParams) Generate(rand *rand.Rand, size int) reflect.Value {
-	return reflect.ValueOf(
-		GeneratedTestParams{
-			ProjectivePoint{
-				X: c.generateFp2p503(rand),
-				Z: c.generateFp2p503(rand),
-			},
-			ProjectiveCurveParameters{
-				A: c.generateFp2p503(rand),
-				C: c.generateFp2p503(rand),
-			},
-			c.generateFp2p503(rand),
-		})
-}
-
-func (x primeFieldElement) Generate(rand *rand.Rand, size int) reflect.Value {
-	return reflect.ValueOf(primeFieldElement{A: new(GeneratedTestParams).generateFp2p503(rand).A})
-}
-
-// Convert an FpElement to a big.Int for testing.  Because this is only
-// for testing, no big.Int to FpElement conversion is provided.
-func radix64ToBigInt(x []uint64) *big.Int {
-	radix := new(big.Int)
-	// 2^64
-	radix.UnmarshalText(([]byte)("18446744073709551616"))
-
-	base := new(big.Int).SetUint64(1)
-	val := new(big.Int).SetUint64(0)
-	tmp := new(big.Int)
-
-	for _, xi := range x {
-		tmp.SetUint64(xi)
-		tmp.Mul(tmp, base)
-		val.Add(val, tmp)
-		base.Mul(base, radix)
-	}
-
-	return val
-}
-
-func toBigInt(x *FpElement) *big.Int {
-	// Convert from Montgomery form
-	return toBigIntFromMontgomeryForm(x)
-}
-
-func toBigIntFromMontgomeryForm(x *FpElement) *big.Int {
-	// Convert from Montgomery form
-	a := FpElement{}
-	aR := FpElementX2{}
-	copy(aR[:], x[:])              // = a*R
-	fp503MontgomeryReduce(&a, &aR) // = a mod p  in [0,2p)
-	fp503StrongReduce(&a)          // = a mod p  in [0,p)
-	return radix64ToBigInt(a[:])
-}
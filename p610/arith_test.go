@@ -0,0 +1,99 @@
+package p610
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"math/big"
+	"testing"
+)
+
+func TestArith(t *testing.T) { field.TestArith(t) }
+
+func TestPrimeFieldElementToBigInt(t *testing.T) {
+	// Chosen so that p < xR < 2p
+	x := primeFieldElement{A: FpElement{
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 10669696873,
+	},
+	}
+	// Computed using Sage:
+	// sage: p = 2^e2 * 3^e3 - 1
+	// sage: R = 2^640
+	// sage: from_radix_64 = lambda xs: sum((xi * (2**64)**i for i,xi in enumerate(xs)))
+	// sage: xR = from_radix_64([1]*9 + [10669696873])
+	// sage: assert(p < xR)
+	// sage: assert(xR < 2*p)
+	// sage: (xR / R) % p
+	xBig, _ := new(big.Int).SetString("1054252786648387398112136546165899007861166941094572158972545456370420084421104290052027755812684063077723464000231339938968104755731159994344453437670209583176202960414434659396300816", 10)
+	if xBig.Cmp(field.ToBigInt(&x.A)) != 0 {
+		t.Error("Expected", xBig, "found", field.ToBigInt(&x.A))
+	}
+}
+
+func BenchmarkFp2Element(b *testing.B) { field.BenchmarkFp2(b, bench_x, bench_y) }
+
+// --- field operation functions
+
+func BenchmarkFp610Multiply(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp610Mul(&benchmarkFpElementX2, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp610MontgomeryReduce(b *testing.B) {
+	z := bench_z
+
+	// This benchmark actually computes garbage, because
+	// fp610MontgomeryReduce mangles its input, but since it's
+	// constant-time that shouldn't matter for the benchmarks.
+	for n := 0; n < b.N; n++ {
+		fp610MontgomeryReduce(&benchmarkFpElement, &z)
+	}
+}
+
+func BenchmarkFp610AddReduced(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp610AddReduced(&benchmarkFpElement, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp610SubReduced(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp610SubReduced(&benchmarkFpElement, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp610ConditionalSwap(b *testing.B) {
+	x, y := bench_x, bench_y
+	for n := 0; n < b.N; n++ {
+		fp610ConditionalSwap(&x, &y, 1)
+		fp610ConditionalSwap(&x, &y, 0)
+	}
+}
+
+func BenchmarkFp610StrongReduce(b *testing.B) {
+	x := bench_x
+	for n := 0; n < b.N; n++ {
+		fp610StrongReduce(&x)
+	}
+}
+
+func BenchmarkFp610AddLazy(b *testing.B) {
+	var z FpElement
+	x, y := bench_x, bench_y
+	for n := 0; n < b.N; n++ {
+		fp610AddLazy(&z, &x, &y)
+	}
+}
+
+func BenchmarkFp610X2AddLazy(b *testing.B) {
+	x, y, z := bench_z, bench_z, bench_z
+	for n := 0; n < b.N; n++ {
+		fp610X2AddLazy(&x, &y, &z)
+	}
+}
+
+func BenchmarkFp610X2SubLazy(b *testing.B) {
+	x, y, z := bench_z, bench_z, bench_z
+	for n := 0; n < b.N; n++ {
+		fp610X2SubLazy(&x, &y, &z)
+	}
+}
@@ -0,0 +1,195 @@
+package p610
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+)
+
+const (
+	// SIDH public key byte size
+	P610_PublicKeySize = 462
+	// SIDH shared secret byte size.
+	P610_SharedSecretSize = 154
+	// Max size of secret key for 2-torsion group, corresponds to 2^e2 - 1
+	P610_SecretBitLenA = 305
+	// Size of secret key for 3-torsion group, corresponds to log_2(3^e3) - 1
+	P610_SecretBitLenB = 304
+	// Size of a compuatation strategy for 2-torsion group
+	strategySizeA = 151
+	// Size of a compuatation strategy for 3-torsion group
+	strategySizeB = 191
+	// ceil(610+7/8)
+	P610_Bytelen = 77
+	// Number of limbs for a field element
+	NumWords = 10
+)
+
+// Torsion basis points on the starting curve E_6 (in Montgomery domain).
+// Following [SIKE], 1.3.3, PA = [3^192](8+i) and QA = [3^192](9+i) generate
+// E[2^305], PB = [2^304](2) and QB = [2^304](11) generate E[3^192]. RA and RB
+// are x-coordinates of PA-QA and PB-QB respectively.
+
+// The x-coordinate of PA
+var P610_affine_PA = Fp2Element{
+	A: FpElement{
+		0x5019EC96A75AC57A, 0x8AEA0E717712C6F1, 0x03C067C819D29E5E, 0x59F454425FE307D9,
+		0x6D29215D9AD5E6D4, 0xD8C5A27CDC9DD34A, 0x972DC274DAB435B3, 0x82A597C70A80E10F,
+		0x48175986EFED547F, 0x00000000671A3592,
+	},
+	B: FpElement{
+		0xE4BA9CC3EEEC53F4, 0xBD34E4FEDB0132D3, 0x1B7125C87BEE960C, 0x25D615BF3CFAA355,
+		0xFC8EC20DC367D66A, 0xB44F3FD1CC73289C, 0xD84BF51195C2E012, 0x38D7C756EB370F48,
+		0xBBC236249F94F72A, 0x000000013020CC63,
+	},
+}
+
+// The x-coordinate of QA
+var P610_affine_QA = Fp2Element{
+	A: FpElement{
+		0x1D7C945D3DBCC38C, 0x9A5F7C12CA8BA5B9, 0x1E8F87985B01CBE3, 0xD2CABF82F5BC5235,
+		0x3BDE474ECCA9FAA2, 0xB98CD975DF9FB0A8, 0x444E4464B9C67790, 0xCB2E888565CE6AD9,
+		0xDB64FFE2A1C350E2, 0x00000001D7532756,
+	},
+	B: FpElement{
+		0x1E8B3AA2382C9079, 0x28CB31E08A943C00, 0xE04D02266E8A63E1, 0x84A2D260214EF65F,
+		0xD5933DA25018E226, 0xBC8BF038928C4BA9, 0x91E9D0CB7EAF58A9, 0x04A4627B75E008E1,
+		0x58CEF27583E50C2E, 0x00000002170DDF44,
+	},
+}
+
+// The x-coordinate of RA = PA-QA
+var P610_affine_RA = Fp2Element{
+	A: FpElement{
+		0x261DD0782CEC958D, 0xC25B3AE64BBC0311, 0x9F21B8A8981B15FE, 0xA3C0B52CD5FFC45B,
+		0x5D2E65A016702C6A, 0x8C5586CA98722EDE, 0x61490A967A6B4B1A, 0xFA64E30231F719AF,
+		0x9CEAB8B6301BB2DF, 0x00000000CF5AEA7D,
+	},
+	B: FpElement{
+		0xB980435A77B912C0, 0x2B4A97F70E0FC873, 0x415C7FA4DE96F43C, 0xE5EED95643E443FD,
+		0xCBE18DB57C51B354, 0x51C96C3FFABD2D46, 0x5C14637B9A5765D6, 0x45D2369C4D0199A5,
+		0x25A1F9C5BBF1E683, 0x000000025AD7A11B,
+	},
+}
+
+// The x-coordinate of PB
+var P610_affine_PB = Fp2Element{
+	A: FpElement{
+		0xC6C8E180E41884BA, 0x2161D2F4FBC32B95, 0xCBF83091BDB34092, 0xD742CC0AD4CC7E38,
+		0x61A1FA7E1B14FBD7, 0xF0E5FC70137597C4, 0x1F0C8F2585E20B1F, 0xC68E44A1C032A4C2,
+		0xE3C65FB8AF155A0D, 0x00000001409EE8D5,
+	},
+	B: FpElement{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000,
+	},
+}
+
+// The x-coordinate of QB
+var P610_affine_QB = Fp2Element{
+	A: FpElement{
+		0xF586DB4A16BE1880, 0x712F10D95E6C65A9, 0x9D5AAC3B83584B87, 0x4ECDAA98182C8261,
+		0xAD7D4C15588FD230, 0x4197C54E96B7D926, 0xED15BB13E8C588ED, 0x3E299AEAD5AAD7C7,
+		0xF36B25F1BD579F79, 0x000000021CE65B5B,
+	},
+	B: FpElement{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000,
+	},
+}
+
+// The x-coordinate of RB = PB - QB
+var P610_affine_RB = Fp2Element{
+	A: FpElement{
+		0x7A87897A0C4C3FD7, 0x3C1879ECD4D33D76, 0x595C28A36FFBA1A0, 0xF53FF66A2A7FD0FB,
+		0xB39F5A91230E56FA, 0x81F21610DA3EA8B5, 0xEBB3B9A627428A90, 0x8661123B35748010,
+		0xE196173B9C48781D, 0x00000002198166AC,
+	},
+	B: FpElement{
+		0xA1C33864C8FF9295, 0x1FCA75654D1586DC, 0xC48DA34A6AE7F6AE, 0xF8DB9C80E22F3E6E,
+		0xF251CE49825462E6, 0x5DAB9529DB7D7BF8, 0xAC89C74518957FA1, 0x28B6D77C852D9065,
+		0x74EC820DA392D1D6, 0x000000013358C59B,
+	},
+}
+
+// 2-torsion group computation strategy
+var P610_AliceIsogenyStrategy = [strategySizeA]uint32{
+	0x41, 0x26, 0x15, 0x0C, 0x07, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x05, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x09, 0x05,
+	0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x11, 0x09, 0x05, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x04,
+	0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x08, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01,
+	0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x1C, 0x10, 0x09, 0x05, 0x03, 0x02, 0x01, 0x01, 0x01,
+	0x01, 0x02, 0x01, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x07, 0x04, 0x02,
+	0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x0C, 0x07, 0x04, 0x02,
+	0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x05, 0x03, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01}
+
+// 3-torsion group computation strategy
+var P610_BobIsogenyStrategy = [strategySizeB]uint32{
+	0x3C, 0x29, 0x1C, 0x13, 0x0D, 0x09, 0x06, 0x04, 0x03, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x06,
+	0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01,
+	0x01, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x0D, 0x09, 0x06, 0x04,
+	0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01,
+	0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x13, 0x0D, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x06, 0x04, 0x03,
+	0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01}
+
+// Used internally by this package
+// -------------------------------
+
+var p610 = FpElement{
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0x6E01FFFFFFFFFFFF, 0xB1784DE8AA5AB02E, 0x9AE7BF45048FF9AB, 0xB255B2FA10C4252A,
+	0x819010C251E7D88C, 0x000000027BF6A768,
+}
+
+// 2*p610
+var p610x2 = FpElement{
+	0xFFFFFFFFFFFFFFFE, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xDC03FFFFFFFFFFFF, 0x62F09BD154B5605C, 0x35CF7E8A091FF357, 0x64AB65F421884A55,
+	0x03202184A3CFB119, 0x00000004F7ED4ED1,
+}
+
+// p610 + 1
+var p610p1 = FpElement{
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x6E02000000000000, 0xB1784DE8AA5AB02E, 0x9AE7BF45048FF9AB, 0xB255B2FA10C4252A,
+	0x819010C251E7D88C, 0x000000027BF6A768,
+}
+
+// R^2=(2^640)^2 mod p
+var p610R2 = FpElement{
+	0xE75F5D201A197727, 0xE0B85963B627392E, 0x6BC1707818DE493D, 0xDC7F419940D1A0C5,
+	0x7358030979EDE54A, 0x84F4BEBDEED75A5C, 0x7ECCA66E13427B47, 0xC5BB4E65280080B3,
+	0x7019950F516DA19A, 0x000000008E290FF3,
+}
+
+// 1*R mod p
+var P610_OneFp2 = Fp2Element{
+	A: FpElement{
+		0x00000000670CC8E6, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x9A34000000000000, 0x4D99C2BD28717A3F, 0x0A4A1839A323D41C, 0xD2B62215D06AD1E2,
+		0x1369026E862CAF3D, 0x000000010894E964},
+}
+
+// 1/2 * R mod p
+var P610_HalfFp2 = Fp2Element{
+	A: FpElement{
+		0x0000000033866473, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0xCD1A000000000000, 0x26CCE15E9438BD1F, 0x05250C1CD191EA0E, 0xE95B110AE83568F1,
+		0x09B481374316579E, 0x00000000844A74B2},
+}
+
+// 6*R mod p
+var P610_SixFp2 = Fp2Element{
+	A: FpElement{
+		0x000000026A4CB566, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0xC134000000000000, 0x6EA9F49D9DF37D20, 0x07ED12CFC9B70552, 0x8B99668EC0F8A0F7,
+		0x7155ED12813C6A59, 0x000000013B902987},
+}
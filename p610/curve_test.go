@@ -0,0 +1,29 @@
+package p610
+
+import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"testing"
+)
+
+// j-invariant of the curve, computed using Sage
+// j = 2289115233638372101530938791865400079286029480689975650309538155027705766073089845601688903112627863998535796031069774838548301012232793599287863000674056727028856080421293068716131375*i + 302582455208570183597486216960969502173519140037077553189032366267328347796445393026243689767264140036865760193912808176747017555660789001599450394227068494197443854120595159504205008
+var known_j = Fp2Element{
+	A: FpElement{0xbdf6c596b5b5ab95, 0x25ea62d1d8db67f6, 0x4589bd3a4dbd1a5c, 0xdb0245f72b294d66, 0xdfe527f83d0d9f3f, 0x63327cb8a5443638, 0x41593cb5f7e6c763, 0x20d2393e4b949c81, 0x9af920b3f2c490e2, 0xb8667037},
+	B: FpElement{0x43f5006784c9f8d7, 0x6eea7d0278b9c2f3, 0xa2e8664057670393, 0x8df5078cac80c329, 0xbd6a121d4d00b10b, 0x7e9860ecc3d1407f, 0x1c4e9829ee928824, 0x41b601702ccc0c20, 0x92630cd2693c7bc0, 0x1454346c2},
+}
+
+var curveVectors = &fieldtest.CurveVectors{
+	A: curve_A, C: curve_C, J: known_j,
+	XP: affine_xP, XP2: affine_xP2, XP4: affine_xP4, XP9: affine_xP9,
+	M: mScalarBytes[:], XmP: affine_xaP,
+}
+
+func TestCurve(t *testing.T) { field.TestCurve(t, curveVectors) }
+
+func BenchmarkThreePointLadder255BitScalar(b *testing.B) {
+	var mScalarBytes = [...]uint8{203, 155, 185, 191, 131, 228, 50, 178, 207, 191, 61, 141, 174, 173, 207, 243, 159, 243, 46, 163, 19, 102, 69, 92, 36, 225, 0, 37, 114, 19, 191, 0}
+	for n := 0; n < b.N; n++ {
+		kCurveOps.ScalarMul3Pt(&curve, &threePointLadderInputs[0], &threePointLadderInputs[1], &threePointLadderInputs[2], 255, mScalarBytes[:])
+	}
+}
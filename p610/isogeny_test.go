@@ -0,0 +1,149 @@
+package p610
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"testing"
+)
+
+func TestFourIsogenyVersusSage(t *testing.T) {
+	var xR, xP4, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny4(kFieldOps)
+
+	// Given 4-torsion point on E, constructs a four-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP4 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x8DF944DD369108E6, 0x4922397249049DE5, 0xBD0CB00774AE1D05, 0xAB6A45D4EA5514F6, 0xD99D060E54FE4648, 0x9152E47A5BD00395, 0x3425C9DF044AD002, 0x4BB186DB1C8F0C89, 0x690B3A189A49A565, 0x19EF15E36},
+			B: FpElement{0x2D3DECB2C41E94A8, 0x6E10B83029A62E30, 0x33EA854E9CA999BB, 0xE1EF8DFA5D30571C, 0xCB66776C59380A5C, 0x94B1B73B18E6CF7C, 0x455D092FF691D939, 0x8D1B313255C0C9C8, 0x4E3AD673C670A083, 0x1D2842654},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x1E7FEE3552C018E0, 0x4DA76C3666C81D84, 0x95DFEA5850935BE5, 0x43C7DE888CBDD6FF, 0x860D0A5972A0165D, 0x32EBB231327ADC33, 0x5C99272348599391, 0x9B94977C1C65C3F5, 0xA636D3B0072C8CF9, 0x49C0141B},
+			B: FpElement{0xD814736B850EC761, 0xB912305AE1EBE099, 0x1E26CA8527927340, 0xA50A7A8DA9E70EAE, 0xCAE561E87D09951E, 0xF021AC3CB7D4E441, 0x29DD35718164F0FC, 0xDB9517CB2D22358D, 0x2013E8346A065A2F, 0x157DEDF14},
+		},
+	}
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x43FD1F2C97BD727E, 0x8438A31A97531586, 0x32D6841099B14CB1, 0xF13B2D8CDA880D7E, 0x1E67A16D64C237F, 0x584C3753CFF44C6C, 0xA1FE75134F9E2CC6, 0x1CB1300141A96BBC, 0xEAD110F54CCA75BC, 0x1D14CFEC0},
+			B: FpElement{0xEE64A1881CC49179, 0x7D52558C7E8C8197, 0xDC994066434C6B57, 0xFB834A18301DA514, 0x7E3B2398D3A214D0, 0x9742D9D19279BA28, 0xD0E5DAE3091D3961, 0x89282590224A764A, 0x550F45798F19B944, 0x174619C4B},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x49EA8C5FB176801B, 0xCAEC77DAE34D7E1B, 0x8A0E01548E1097E2, 0x986A2D4CD6A0130D, 0x57A5E6FDCB83988F, 0x71F292B345112672, 0x20F19B3232858AFF, 0xAF7F056F5BE1919C, 0x65393F2DEC2CE0B, 0x1B1D1A0DD},
+			B: FpElement{0xA08814AC418F69C, 0xBD31D4F7DF7A4D9A, 0x5F39F91C84AEFF06, 0xABD143808B0E433B, 0x1BEE1A0B9741BE1C, 0x2011313A4D9A6AEE, 0x14E8CB93615F07DE, 0xA7D05493A2B6D347, 0xEDC1A723CD3C302, 0x14D9AF889},
+		},
+	}
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xD9981D76C048EB1B, 0xDADB08D23828DC9D, 0x78130C2A00831576, 0xBE9884235CFA2EBF, 0xDABBD4F411478A9F, 0x9C04B937F4C85851, 0xAE74C805D26C5312, 0x9FB6FA736C2B7A17, 0xE074AB4E3AD38302, 0x8CA0E7AE},
+			B: FpElement{0x9E71F47B76514ECB, 0x73746E0050B13EAD, 0x37A5C5A3CD15D464, 0x4CE6DA24360475BB, 0x4DAFDFF47583535C, 0xEE6C37100BF4716A, 0xEEA6F0ED8840B017, 0xDA800E510716A911, 0x69834A5377FE1740, 0x3B3C5DBC},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x29C1DE49C1EFBB71, 0x896284A0E384A822, 0x1C81A4922B9694E2, 0xE6091D280E880E88, 0x4C9B39511793B3E9, 0x4F6C0B6DF880503, 0xAF11AD8E3840EF39, 0xE542C7581FDD59CF, 0x10A23F238690A394, 0x2398F8205},
+			B: FpElement{0xC0DBB9BAA4A2A301, 0x55FB9D797CE919D3, 0xCDC6819AEA141E5B, 0xBF3E1CC1C432A92, 0xE1AF6365752517B2, 0xCF65D530977A4A1, 0x68F778F6DA80362F, 0x738C79276A9A29E5, 0xB0A1B57BA7DFE671, 0x87749489},
+		},
+	}
+
+	phi.GenerateCurve(&xP4)
+	resPhiXr = phi.EvaluatePoint(&xR)
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
+
+func TestThreeIsogenyVersusSage(t *testing.T) {
+	var xR, xP3, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny3(kFieldOps)
+
+	// Given 3-torsion point on E, constructs a three-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP3 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xBCFDAD4AAEEDB917, 0x9A654C9E18BC59EF, 0x5AECF6C43C43BEC1, 0x9E5928E8976B273D, 0x76B7259277B975CB, 0x5F916D7578B41632, 0xB2243F453EA7EC15, 0xCBBCB35C4F39F914, 0x46643A2E29D3602C, 0x269DEDCA2},
+			B: FpElement{0xC76E122E41E4A1B5, 0xAAACEAF599838CA0, 0x34A4DC1B1F559BB9, 0xFC840414A9C20FE8, 0xD107B2DDD98BE3F4, 0x7D30A4CDBCFF4C85, 0x7AAA497986851BB9, 0xA703CCA96BAB8F77, 0xB5719DB7F29ED2F6, 0x7C39F18C},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x79BCDF37EC32961A, 0x39B5C45D477A2D77, 0xE865A4B887F0C278, 0x4A3DB64BE3258A8F, 0xFA4A313626ECEBE4, 0xE9C3DEF7793D09E5, 0x2269C474DFB264CF, 0x35FBDB0B2EB65C62, 0xB451EE01562DC9F0, 0x975CF28D},
+			B: FpElement{0x8010824D509B0EC7, 0x12AE6A4B53E0C5D2, 0x6DCC208DE5AF6D2, 0x4DB7685E2413B3B6, 0x97983D910D56D722, 0x728D7E8E5D60427B, 0xFDDF5A68BDD7ACF3, 0x3D631E1B4C72A66F, 0xB3B27BF791FF3C06, 0xBF923BC5},
+		},
+	}
+
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x2F2DBBBC9D7212BB, 0xBE95A4E1090F7623, 0xD0B5E46EF1367B2E, 0x46966C4A20E5407, 0x1AA187A845E80CD7, 0x5F65425E82B22115, 0x9EFC14988D09EDCE, 0x9687C2B16D00CB87, 0xA90A7CBED51A4ECB, 0x1D4FC7A95},
+			B: FpElement{0xDA9502865EB5FE99, 0x14DA240DE8002899, 0x918987241AEF55CA, 0xF5D2965AF60A1E4E, 0x4DF7EAF619771D1C, 0xBE6F50BB599C65ED, 0x908E505029751E40, 0x14FFB6718D534776, 0xF7FC7DA062CED98E, 0x1949766B8},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x775542B975E47B25, 0xFFBBEBA17DC3BFBF, 0x4939119D16077A8D, 0xF1B4E328F827890C, 0x2E27BD4BD2319233, 0x1C7DB711F15A9B70, 0xBBCA367DA64D793A, 0xCD14A35870C95AC0, 0xABC9782A14A346C7, 0x1BC3E94E5},
+			B: FpElement{0xD550E2DC4698FEFD, 0xED523BA2C62B1737, 0x4CFEE5E89DBE7E6D, 0xCA04333C2E7AA86A, 0xCC047B8CC84E78B6, 0x9C6FFCFFC9AC04B, 0x1F6B384EB652D54E, 0x306FA2AC7BD705C6, 0xF0633C48BB9E4381, 0x1E0326B01},
+		},
+	}
+
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xF1963B4B50D5C8B2, 0x6223A330DDE91561, 0xEA30739F3F33D788, 0x1EFCB2BC4DCB1387, 0x81D479CF2DF25A7B, 0xFA2773EA8D5FFC77, 0x62703C40D783722F, 0x77075D505956A0B2, 0x87885D386571689A, 0x114E0C68C},
+			B: FpElement{0x5D8E8BAC2BF8C148, 0x8EDFD1DB04F96B4E, 0xBEDF695128D2016B, 0xD09DD59CDFF02C22, 0x50F38A8EFDDC61EF, 0xEE2CC444BDC9F5D8, 0xD30A2C912F2FB28, 0xBEE0D87E6E75FB11, 0x4B8ECF3687799DD5, 0x76792AD1},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x1AC9B792ABC1B218, 0xDDCFFE4C777C697, 0xFBAE5717DF12A0CB, 0x2D1001D31F344B09, 0xA2D120FE19B182E8, 0x86590B17510667CD, 0xA97724E0007E5AD1, 0x53D2314609A35F05, 0x68B6F11C24696E96, 0x8ECE31F3},
+			B: FpElement{0x89170233870FEBEF, 0x2B47C891EE58A37D, 0xC6C5789BE0128F77, 0x6EAB1C6B025C937B, 0xA38CDFD4A1F8134A, 0xB56E34F530A8745C, 0x7F2C3482B339BB11, 0x9398102B9B40E148, 0x81BB6EFDE1BC91A5, 0xF1ADB6A5},
+		},
+	}
+
+	phi.GenerateCurve(&xR)
+	resPhiXr = phi.EvaluatePoint(&xP3)
+
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
+
+func TestTwoIsogenyVersusSage(t *testing.T) {
+	var xR, xP2, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny2(kFieldOps)
+
+	// Given 2-torsion point on E, constructs a two-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP2 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xE03C2106B412668, 0xE2B8542DFCA72D55, 0xB4CB6AF1F78C57EB, 0xDF9F3B03DD7C0450, 0x81CF658A5CA24489, 0xE33788A4AEA011F, 0xC36F71564FD8705A, 0x3A2379D15317A35B, 0x573A9C36D2AF21FA, 0x20600F41C},
+			B: FpElement{0xCD70BC08919597A, 0xC58730E54D5C31F6, 0xE28565DEF8DB7B45, 0x181A46A2B85CD169, 0xE343B59DFBC0BD25, 0x1630428B7E892E09, 0xE48815B4F30A07B0, 0xBCA451EFE739BF11, 0x4D451C787499882, 0x16F9F1694},
+		},
+		Z: Fp2Element{
+			A: FpElement{0xD5AC0B673CAA87CB, 0xF178036BC08765FC, 0x812FEEC9F070B8D8, 0x7CD450243571772A, 0x1E82846CA083381A, 0xDD5E9ADCE491FAB0, 0x7A37A60E81909378, 0x6F4140A161A135DD, 0x8E4EDDBAC0D985B3, 0x730A6C40},
+			B: FpElement{0xF916C96A35276B74, 0xC8686B1CD6BAE9AE, 0xD772CC3E3D5CA63E, 0x523720D84D4483D0, 0x62E4D276E4CBF26E, 0x654A2781E6CD8F14, 0x39764DE510E718CF, 0x4C707DBFEBF19D8A, 0xA8F9C05D809EC115, 0x159350777},
+		},
+	}
+
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x67975823BB8053E8, 0x794C9C2593D3CF82, 0xE591B661A3B175ED, 0xE7B5E86D64E4675D, 0xDE8A1CB44D506021, 0xA7D8EEA0626BE84D, 0x5F9A486B2C323C3E, 0xCA0DFA924D5D5316, 0x516F20AEEE4ED2B8, 0x1F06B93D2},
+			B: FpElement{0x9CF33C1483985045, 0x598CAAE4CB8D9EE2, 0xE7E52B8079F811EC, 0x1DC97148667BBC15, 0xB4ACF956A1829E05, 0xAC700C03E8851FAD, 0x7CA951D17EFD5051, 0xEAFDA387C005D454, 0x197D7578C58152BA, 0xD1D652EA},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x5B17CD9434D54865, 0x99588C9202445A1B, 0x418BE023C40C08D6, 0xDB35A50D0C9F5593, 0x7CFE9B07EFF5257C, 0xAC217B000CF9036D, 0xA71A3688CB6F096B, 0x35C7E3625E528A70, 0xCB32034E399DF7DF, 0x837E8DD6},
+			B: FpElement{0xFB54DAD97005B436, 0x6AADEFF3ECB9B9D8, 0x95B3B579156A405B, 0x8395D9268938BB1A, 0xABADEC1D1113E727, 0x752C3B6EE9B2EE71, 0xF6AF5455E8841051, 0x3E11D3442F171142, 0xC246787F6E2C492C, 0x22F45A4A3},
+		},
+	}
+
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x2D489BD925A3AF62, 0xBA2E9CA2662A7E6B, 0x3FAFA3054A1D6D3E, 0xB6E6D4A3A5323267, 0xC99616D5027363B7, 0xD729AB58691F1802, 0x179503FA35A5B693, 0x9B11A39398B9FD6D, 0x87EB830B2F4A95FA, 0x2D224435},
+			B: FpElement{0x2984F4B009F93A0C, 0xF66C7323CE4C586C, 0x8F096D0C7DA58646, 0x844D3FA0F28C0853, 0xF07E5888B3E10D02, 0xE8CCCD670C1EE0B9, 0x8EBC5606F4E2F520, 0xE24BA751BFE23DFE, 0xD2D25AE6F29A8B87, 0x152C69CF3},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x57D84267834E24E3, 0x5EAF72F1682F5D73, 0xC5FC4F5A48B50B43, 0x562EA585320D59B6, 0xA40BC9D28B99F4DC, 0x9E08DF22F0CD9F42, 0x65411A9F4DB2A675, 0xDEF468AE3888EA25, 0x4E82A85942196320, 0x83B73351},
+			B: FpElement{0x85F357B5ED5515A7, 0xF8FC2EB00AFFDD03, 0x7DDB4B6F52C2050D, 0x683F6492DB76BF95, 0xD4EB4D0593BEC06C, 0x6FD27AA972A0A144, 0xED8BCFBDB620B4F0, 0x96991BEFC30A0964, 0xF9FF6BB3CC137E25, 0x13D3B40C4},
+		},
+	}
+
+	phi.GenerateCurve(&xP2)
+	resPhiXr = phi.EvaluatePoint(&xR)
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
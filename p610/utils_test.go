@@ -0,0 +1,124 @@
+package p610
+
+// Tools used for testing and debugging
+
+import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"math/big"
+)
+
+/* -------------------------------------------------------------------------
+   Underlying field configuration
+   -------------------------------------------------------------------------*/
+var (
+	field = &fieldtest.Field{
+		Prime:    p610BigIntPrime,
+		NumWords: NumWords,
+		Bytelen:  P610_Bytelen,
+		OneFp2:   P610_OneFp2,
+		HalfFp2:  P610_HalfFp2,
+		Op:       FieldOperations(),
+		Mul: func(z, x, y *FpElement) {
+			*z = new(primeFieldElement).Mul(&primeFieldElement{A: *x}, &primeFieldElement{A: *y}).A
+		},
+		P34: func(z, x *FpElement) {
+			*z = new(primeFieldElement).P34(&primeFieldElement{A: *x}).A
+		},
+		MontgomeryReduce: fp610MontgomeryReduce,
+		StrongReduce:     fp610StrongReduce,
+		CondSwap:         fp610ConditionalSwap,
+	}
+	kFieldOps = field.Op
+	kCurveOps = field.CurveOps()
+)
+
+// A = 1317993198358872797765089116830327780319058645754510107123685262888478108803893875846486458664793635871818354823307861820430527379211777909936314623658346765026075603701411118431055596*i + 897237650900107113779478373145543271747526467657948362764787475632317630359595865413075856124788520404638684451327606187361599959922217414250993358769149287943722424067157139130407266
+var curve_A = Fp2Element{
+	A: FpElement{0xdf53d34bb2bd6702, 0x7373dc2e1ee6bc21, 0xf74e2631863c8f1a, 0x8325484f6dcd24ac, 0xdcd52db09c7f659a, 0x62783240d4896337, 0xd58c19a2eb1902aa, 0xc4b8001e35ae4d8, 0xec89ba1777ba97bc, 0x2604058d8},
+	B: FpElement{0x93ce998916003e4, 0x81d72ffeb437d43, 0x131e9b271ee46d9e, 0x82f84e11bad9817f, 0x85d0f1f201e1f643, 0xc9e5865c66f45144, 0x74c96625e321662a, 0xa1a9001574b03056, 0x26b4a997d3132b22, 0x1b61c9288}}
+
+// C = 82221706465990463640336211512193760202393079847651106150661548854907960206523397992834879652326064972519834603202020329249850712546935045560146202658791012396249564311615774576026177*i + 57383846484335245584715589556747304170311846715350762823532629624303542709744253435169942498848465872074759533678631281506929063654198006721011782361309649643605005917164955133667054
+var curve_C = Fp2Element{
+	A: FpElement{0xf05ca4b2a9a8dcfe, 0xd08367e23901c667, 0x469260f127f5e8b, 0x52aaa786d1b8425d, 0xe3824198451f22c9, 0x508f088559c891a5, 0x28c9ce3015dda025, 0x8fecbf834740924d, 0xc0ee846be7188080, 0x241d189bb},
+	B: FpElement{0x714d3ebee7394801, 0x73df169284af4639, 0xb08dc88ac0aa8281, 0x1ac21f99f4d8a42c, 0x264a11140e26f6d3, 0x3fdfba013921eba0, 0xc3235aac2ec335df, 0x6cf11f70e7293f5f, 0x82beb1024ed5e0ca, 0x25111d6b0}}
+
+// x(P) = 500408454163464091534054708738832115532075485097353795891565131904424183028661749821265444557445297203949733751511310652401562107981457821725871744750921862899429460931693138211722404*i + 652784978362407093892703359419656620079877131121072246502311960163086938064385299529899322431781905299568419319620892564242295983201758016531365633669117560207466678503586933061067743
+var affine_xP = Fp2Element{
+	A: FpElement{0x2f8c354ca6d6d43c, 0x43d2e309985ab76e, 0xd67c2b816857f0be, 0x99682930d6c0e718, 0xbba336766eecc105, 0x3fb32ee6e44df980, 0x4b182c80ada7aab6, 0x91ded16146d9ec36, 0x9396c5c5ee042337, 0x2633e2aa7},
+	B: FpElement{0xc0714932625c7314, 0xa52e80f38487920f, 0xf55656222c26a6c8, 0x62cca0ac83645421, 0x8dad139f3be2804f, 0x8af5387865acd70d, 0x1282b162e9050f3a, 0xed1521eec4df525f, 0x631da8f1f1208ac0, 0x1a72b390e}}
+
+// x(Q) = 1996797518204991755354094438307237603964375923940735620199408884263445976466071808773664609145193502303577026976477173886973188315546313898171126319562738828742269430704761666035015764*i + 1073161509560037646910002056146807439040781707012982032173369475045691962745909284294177510937549038266660068436626209122291589382841972804681050728837375059213333692987805435168608377
+var affine_xQ = Fp2Element{
+	A: FpElement{0x5a6068c7443ad381, 0xddf9fc953cf18881, 0xb797c4528bbd7906, 0xfeee4212b84f991d, 0x9c025c609c895a96, 0x9385cedb20497db6, 0xd887564927724c0d, 0x8a68737b457962cf, 0xfe672e16203db30e, 0x19410cbe6},
+	B: FpElement{0xf276a28596e19f45, 0x7e1244bd84778034, 0xf978739f9f4e420b, 0x8a3f757cd1fa0379, 0xd26c9696be84a8be, 0x2b7585a80a8a9410, 0xd726181d1271385f, 0xf98fda97a647931c, 0x401b51a229e453f3, 0x144eb9135}}
+
+var affine_xPmQ = Fp2Element{
+	A: FpElement{0x76e494517bcd5a2b, 0xa0c6bf3c92cc72ed, 0xfcc919f5cb5bf729, 0x4bd5de91ef61e598, 0x5e83ce475ed1ec69, 0xbb5096f70b67607d, 0xb637d30533797725, 0x8ae60e5335bfd35f, 0x4681ed95c2af9b3b, 0x5030e5e5},
+	B: FpElement{0x9a78b933e2822ff9, 0xa216a871d9d11e50, 0x7165db319c6207b1, 0xca3832a2f9a21fe, 0xab4765187fb323ad, 0x8825881ca7bb3ecd, 0xc614f2ef553c2299, 0x7073fb12d602c8dd, 0x7bda9db9b7f6c561, 0xff641416}}
+
+var affine_xP2 = Fp2Element{
+	A: FpElement{0x34d26a23b9cadad8, 0xa762b2534437d565, 0xf72c02edf81b7c15, 0x75ebeff6a200b079, 0x9bd35128ceb594e0, 0x5b356cd695bbf91b, 0x19f64e0c3cf5ecb0, 0x559272302ab4f8ed, 0xe89a96b9e2971da0, 0xc74c48c},
+	B: FpElement{0x2913741299ca1190, 0x3a913d744c7837a7, 0xc5b1baa744f4f5ec, 0x55718b87cbb5d9f1, 0x5850394ca6e0f944, 0x2d9d5a5416b508e, 0x5234d4f434d21a96, 0x254e988a6fb374e6, 0x546135c352324add, 0x17f0b008a}}
+
+var affine_xP3 = Fp2Element{
+	A: FpElement{0x3b8d8f73fe9e7452, 0xf63e2c06b08bb848, 0xba9869270968125b, 0x26aeae0e66666ee9, 0xbeb466e9d9693f47, 0x4ef00e3a8631ad03, 0x90550e3f53a87158, 0xf1a76024329d54f6, 0x8692cc95a9899511, 0x46e6c536},
+	B: FpElement{0xb898153ea484cdef, 0x55b53295c9d6284b, 0x188c25bc986f3c76, 0xd53ad6bded42134b, 0x1a2d53f3f8d5019, 0x600e4ae51da8387e, 0x64285a0b1bda627e, 0xdc7bc55d41154a8e, 0x2f1492da50a5f7dc, 0x1bfc886c3}}
+
+var affine_xP4 = Fp2Element{
+	A: FpElement{0xd0a07c4d663791, 0xe100c9bfdca06932, 0x9abf27823c2ea638, 0xc68f331d0c1b2341, 0xa66a231278edeafa, 0x795b00d18c56f5ce, 0xbc4946575478e31e, 0xb8bc0a24963c1b3c, 0x70aa07bb6802b120, 0x1e3c50142},
+	B: FpElement{0x543e2d604ddc8cc7, 0xc4e7a7f918daa242, 0x83e41addc04658f6, 0xe8c6e593dae6dccc, 0xd8c0714e091d2b3, 0xc68386880785c18c, 0x99095615ff2e3a10, 0x4f7d37ecc35ab38c, 0x81c6911022e78938, 0x276be2aef}}
+
+var affine_xP9 = Fp2Element{
+	A: FpElement{0x46feee4b0cb3aaec, 0x9c2a263ab47acc0d, 0x68c1ae044cb2944a, 0x6f598d962bb7841e, 0x5bc7bf195e858af6, 0xb6fbf4425eefc08, 0xfd7996f8985b83ac, 0x4957aead3532ba0d, 0x1f75729bab8d3359, 0x82fa98c5},
+	B: FpElement{0x5e1d34022a16062c, 0x825c3b386adb107c, 0x32036db9e1fb8904, 0x8a5408d9b29b0d86, 0x21e6b0f674db63e5, 0x81570e8dca281e89, 0x47040bc492e60f76, 0xb4f8edf3f9018edf, 0x7b855010143b2d98, 0x1762e99c9}}
+
+// m = 4099623861512783182801585169342462196246932915297354209871
+var mScalarBytes = [...]uint8{0x4f, 0x5a, 0xeb, 0x32, 0x6b, 0xc9, 0x63, 0xb8, 0xa2, 0xe2, 0xdb, 0x5, 0xca, 0x5d, 0x82, 0x20, 0xd8, 0xaa, 0xe7, 0x50, 0xfb, 0x11, 0x32, 0xa7}
+
+var affine_xaP = Fp2Element{
+	A: FpElement{0xbe2520d27d985ae2, 0x3c238e72c7ffce2c, 0x23e3c0b6a8781201, 0x5f7b9c33bac7186f, 0xbcc8d0a533eab4cf, 0xf2f9f7f73847382f, 0x20603d5ff6c0ab41, 0x9ef6bf5041d2156, 0x6958626a25ba25f3, 0x60e213b5},
+	B: FpElement{0x634e88208a5a39a, 0xa90dc952d55b6fc8, 0x2e33825fec05b030, 0xf3d716440bf7018b, 0x5e665006a0b9d0ce, 0x6165220c5f99a2ba, 0x447a329ad5c56a8b, 0xb8118faa1b81bcf8, 0xc49632cb0f9d5d6, 0x4b304564}}
+
+// Inputs for testing 3-point-ladder
+var threePointLadderInputs = []ProjectivePoint{
+	// x(P)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x2A4DFAD391983D2D, 0x62357D2D95588559, 0x4A470077E059BC20, 0x6C6D35376609262B, 0xBCC6918C762CC176, 0x41FAB888E8413F94, 0xF9AA2E92710108C7, 0x865EBA42C93AA0A6, 0x325E6A3261F6BEB0, 0x39819E8E},
+			B: FpElement{0xBAA5D0FEB3A6BE99, 0xFD276121F50C67F0, 0x7CDADC77265E239F, 0xDCED223B7E7A095, 0x1F45B9CB4612CCE0, 0x33964A6E56C335D7, 0xA42E2E5C3D0884E, 0x989C68AB35B8AB70, 0x70197A59504F6AF2, 0x21A7D79B4}},
+		Z: P610_OneFp2,
+	},
+	// x(Q)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x2BDDBB79BE5B0E75, 0xD4847EB362A41B88, 0x93EAEDFFD4118F6D, 0x958A5EA204E624A, 0xA3860CCA653BFCBF, 0xC99BCAC290A9AFFE, 0xC11FD18E5A844152, 0x78FF970FF53B47B0, 0x24F0DFCF012ACF3F, 0x713CD08D},
+			B: FpElement{0x89A5AD42BB0B4F8C, 0x7D29862B490F7D57, 0x80F889245D684118, 0x4726808C168F35B2, 0x795E4994127AF226, 0x6FD9B071611727E2, 0xF70BB7B43B7CA95, 0x6D4265FC723AAF9F, 0x765B39BA7F982412, 0x1818DBF95}},
+		Z: P610_OneFp2,
+	},
+	// x(P-Q)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xA6B79085BCB7B00C, 0xB1087489D814FF75, 0x3EB137BE0A959EE9, 0x99DC9B49AB932D62, 0xCB198198B7B58461, 0x59B9607FF0F61BF3, 0x9BA66588E14DB309, 0x975A58BEB5BB528D, 0x76EB27AFD3BEE000, 0xD45E42A9},
+			B: FpElement{0x54E68FC7FA77185D, 0x82F0616525C06ED9, 0x22BA079E525A1FBC, 0x2C5D7CD9C067F418, 0xA4AFFECE29F61FBB, 0xFA778477389DEC92, 0xA405535368B5E1DA, 0x51FFD13A0DFB1187, 0x6C978852424FC773, 0x186483C6E}},
+		Z: P610_OneFp2,
+	},
+}
+var curve = ProjectiveCurveParameters{A: curve_A, C: curve_C}
+
+// prime p610
+var p610BigIntPrime, _ = new(big.Int).SetString("2638940411073262671963620699288286770183560231187222316750407556465639836010558150163225530335162533481049256757217964651333810422125728537407397155806079217346919294449255613110157311", 10)
+
+/* -------------------------------------------------------------------------
+   Values used by benchmarking tools
+   -------------------------------------------------------------------------*/
+
+// Package-level storage for this field element is intended to deter
+// compiler optimizations.
+var (
+	benchmarkFpElement   FpElement
+	benchmarkFpElementX2 FpElementX2
+	bench_x              = FpElement{2973622794513647825, 15286717930859048122, 200897410660467221, 11400052390784725826, 8635539275790414041, 621722235376471070, 12598829835238234171, 11311323366561553868, 6566181459793038955, 17736807243664456475, 17033634626132157780, 14697549750143075806}
+	bench_y              = FpElement{1505732829913583273, 1254644195818318690, 16492799016029839958, 8878679435115026708, 5617302334048777850, 11400763101885362977, 6451566739250422739, 2472285718364088421, 11655834356774333753, 5650075150238047357, 512741125731086337, 15954706336587970081}
+	bench_z              = FpElementX2{12423012985853235656, 15799230932515308544, 17329763891300168793, 7905326826005528539, 12160762306837732757, 4020691056278905614, 11533690281569856272, 9255866223406672651, 151413327803393426, 7291829126741943344, 4890840649858064057, 10353839891347095465, 12189992009160436596, 6953205498336643553, 4567540879033258136, 11368918495497669691, 14942388010599305188, 17332112665989109194, 10293713135634810158, 5304327247953116653, 7011945979206121691, 15947426570416049840, 11277800643961575690, 13582466753358116608}
+)
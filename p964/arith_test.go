@@ -0,0 +1,99 @@
+package p964
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"math/big"
+	"testing"
+)
+
+func TestArith(t *testing.T) { field.TestArith(t) }
+
+func TestPrimeFieldElementToBigInt(t *testing.T) {
+	// Chosen so that p < xR < 2p
+	x := primeFieldElement{A: FpElement{
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 9,
+	},
+	}
+	// Computed using Sage:
+	// sage: p = 2^e2 * 3^e3 - 1
+	// sage: R = 2^1024
+	// sage: from_radix_64 = lambda xs: sum((xi * (2**64)**i for i,xi in enumerate(xs)))
+	// sage: xR = from_radix_64([1]*15 + [9])
+	// sage: assert(p < xR)
+	// sage: assert(xR < 2*p)
+	// sage: (xR / R) % p
+	xBig, _ := new(big.Int).SetString("11313489031804814810468407855252006916221732575067005987466252454492398994108386983596139016693774997905455932622667814857418620581451580437320496244328631017046385116530961914808373933010273966140476624375543930003742501989261132041212853766262806832584850028672576782687989553523930409875", 10)
+	if xBig.Cmp(field.ToBigInt(&x.A)) != 0 {
+		t.Error("Expected", xBig, "found", field.ToBigInt(&x.A))
+	}
+}
+
+func BenchmarkFp2Element(b *testing.B) { field.BenchmarkFp2(b, bench_x, bench_y) }
+
+// --- field operation functions
+
+func BenchmarkFp964Multiply(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp964Mul(&benchmarkFpElementX2, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp964MontgomeryReduce(b *testing.B) {
+	z := bench_z
+
+	// This benchmark actually computes garbage, because
+	// fp964MontgomeryReduce mangles its input, but since it's
+	// constant-time that shouldn't matter for the benchmarks.
+	for n := 0; n < b.N; n++ {
+		fp964MontgomeryReduce(&benchmarkFpElement, &z)
+	}
+}
+
+func BenchmarkFp964AddReduced(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp964AddReduced(&benchmarkFpElement, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp964SubReduced(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		fp964SubReduced(&benchmarkFpElement, &bench_x, &bench_y)
+	}
+}
+
+func BenchmarkFp964ConditionalSwap(b *testing.B) {
+	x, y := bench_x, bench_y
+	for n := 0; n < b.N; n++ {
+		fp964ConditionalSwap(&x, &y, 1)
+		fp964ConditionalSwap(&x, &y, 0)
+	}
+}
+
+func BenchmarkFp964StrongReduce(b *testing.B) {
+	x := bench_x
+	for n := 0; n < b.N; n++ {
+		fp964StrongReduce(&x)
+	}
+}
+
+func BenchmarkFp964AddLazy(b *testing.B) {
+	var z FpElement
+	x, y := bench_x, bench_y
+	for n := 0; n < b.N; n++ {
+		fp964AddLazy(&z, &x, &y)
+	}
+}
+
+func BenchmarkFp964X2AddLazy(b *testing.B) {
+	x, y, z := bench_z, bench_z, bench_z
+	for n := 0; n < b.N; n++ {
+		fp964X2AddLazy(&x, &y, &z)
+	}
+}
+
+func BenchmarkFp964X2SubLazy(b *testing.B) {
+	x, y, z := bench_z, bench_z, bench_z
+	for n := 0; n < b.N; n++ {
+		fp964X2SubLazy(&x, &y, &z)
+	}
+}
@@ -0,0 +1,227 @@
+package p964
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+)
+
+const (
+	// SIDH public key byte size
+	P964_PublicKeySize = 726
+	// SIDH shared secret byte size.
+	P964_SharedSecretSize = 242
+	// Max size of secret key for 2-torsion group, corresponds to 2^e2 - 1
+	P964_SecretBitLenA = 486
+	// Size of secret key for 3-torsion group, corresponds to log_2(3^e3) - 1
+	P964_SecretBitLenB = 477
+	// Size of a compuatation strategy for 2-torsion group
+	strategySizeA = 242
+	// Size of a compuatation strategy for 3-torsion group
+	strategySizeB = 300
+	// ceil(964+7/8)
+	P964_Bytelen = 121
+	// Number of limbs for a field element
+	NumWords = 16
+)
+
+// Torsion basis points on the starting curve E_6 (in Montgomery domain).
+// Following [SIKE], 1.3.3, PA = [3^301](11+i) and QA = [3^301](1+i) generate
+// E[2^486], PB = [2^485](6) and QB = [2^485](3) generate E[3^301]. RA and RB
+// are x-coordinates of PA-QA and PB-QB respectively.
+
+// The x-coordinate of PA
+var P964_affine_PA = Fp2Element{
+	A: FpElement{
+		0x1B387EBF9CB321D4, 0x0315864182968C08, 0x2DF129120B5600D4, 0xFEFBA11BE1816241,
+		0xF6AE6B15586F6354, 0x5F701D62EC6C4525, 0xA633639A29F82D38, 0x42231671434B6D28,
+		0xEE23054D82269146, 0x978B9F24A1195EC3, 0x31F2B10C54D02890, 0x75F028ADC32C0B30,
+		0x7BE97A0661809486, 0x71F2E31A196EAF28, 0xC8224ECE2E80DB5D, 0x0000000000000001,
+	},
+	B: FpElement{
+		0xDA95D602FCBA6AEB, 0xF513012DACB171E3, 0x52DE9FD9D67D2831, 0x9D20F4F9400B1036,
+		0x0975FFAC38F5E59D, 0x6421E8C9CA6E2DE5, 0x493373E3C9CE3265, 0x889918BD08987C79,
+		0xFC774BC4BE495838, 0xB91C41478C685E18, 0x8EA262412B42D95A, 0xB4EA7361BE073B0A,
+		0xF5E9FBA6B2FD2BE6, 0x542BFE0A7A4EB2C1, 0x5CAC409840B4D5E6, 0x0000000000000003,
+	},
+}
+
+// The x-coordinate of QA
+var P964_affine_QA = Fp2Element{
+	A: FpElement{
+		0x7A538B891852BA25, 0x3CF30E9E20F44E05, 0x2BADEE90264635C9, 0xFEE3978E84001A32,
+		0x2681076571C2B7C8, 0x295A9080D7A36A28, 0xA2DFD83E4D5ECDFE, 0x16F89AAC3915B17F,
+		0xE35DBA5F49AC990C, 0x7F2C6D71FC91D4A5, 0xBA74475DF07FEC12, 0x6CB3D42FA763081F,
+		0x5AA9CC126CB7E228, 0x34CADDDBE355F4AA, 0x4C14EFC85C1021DB, 0x0000000000000005,
+	},
+	B: FpElement{
+		0xBCE775A877BE33D9, 0xAC7415F89327A727, 0xCACF6C3F201E9326, 0xA6EBD82AE112D04F,
+		0xFAEC9D2A6632D2EA, 0x7EB3E037B47671AB, 0x13BA1CDB0C4751D9, 0x590EF24A261348FE,
+		0x01032955505A6AD5, 0x8EC9FFB63F2F0232, 0x1C3C4C6BACAADCE3, 0x720635001B1D16E3,
+		0x18A3782E29DE13DA, 0xB48B2A83EDC008E2, 0xDAFFE365694DD438, 0x0000000000000007,
+	},
+}
+
+// The x-coordinate of RA = PA-QA
+var P964_affine_RA = Fp2Element{
+	A: FpElement{
+		0x50042DEA9DC2DAD1, 0x9C9BDD93A128BC99, 0xE1630FDE3F62A839, 0x3CF17F3EB358D0AA,
+		0xCA42BD48239C0934, 0xC103104A3E1FF569, 0x8EA9F17CC7CE1976, 0xD3EAAD961D9A595D,
+		0xABC7E506680E7523, 0x819CD1638BCE29AB, 0x0398A421EEF92612, 0x362D6F2C4AB57269,
+		0x3441013D1B0B2684, 0x3A0087EE9CF9167E, 0xA9E5898801A5603D, 0x0000000000000005,
+	},
+	B: FpElement{
+		0xA348AF60C9809898, 0x1F79D6C4833654DF, 0xD35543AC8FA41520, 0x16C13FB1ADC9CBDC,
+		0xD3178B7C8556CA24, 0xD24F93CD05616F9B, 0xFF526D6688678A83, 0xE9A0459394900538,
+		0x3BE9B9DFAA0DAD75, 0xEFA3BFF171552BAD, 0x6FE1DE1F59E52A4C, 0x50FFF43A330B4130,
+		0x9EA92CE446FEF570, 0xFC04D71020DE953B, 0x3E1786BACC4C1500, 0x0000000000000006,
+	},
+}
+
+// The x-coordinate of PB
+var P964_affine_PB = Fp2Element{
+	A: FpElement{
+		0xE7B6FAA4FB857F72, 0x3C7BDBA4940B6F0C, 0x831090C9D5B62408, 0x5D885D67B19495D5,
+		0x2A83B484683A51CA, 0xBA40918F560493EB, 0x2B650C4652612854, 0xF5624448043507D0,
+		0xBA729D801A84EA46, 0x1B51C86E5B60EB65, 0x5D606424CC9A2FB6, 0x1312F69730752E32,
+		0x3662F089DA7D4234, 0x3789A365F1C022E6, 0xC602E0A79A85EB38, 0x0000000000000003,
+	},
+	B: FpElement{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	},
+}
+
+// The x-coordinate of QB
+var P964_affine_QB = Fp2Element{
+	A: FpElement{
+		0x1C65802CD7F9B4E6, 0x2EA5F6A4F6E407C4, 0xF6516B2232BA6B16, 0x6ACC709DAD28FC00,
+		0x71195B0C9CDB956A, 0x18D924E9DB839094, 0xBBBF366C8525084D, 0xACA0C58A599D34BB,
+		0x55AEEC010D9546AB, 0x26E79C8A58B339D4, 0x5E9B44AFCFA460DE, 0xB5AE485FE9682C38,
+		0x51EB16927DA29BD7, 0x7ED9137C669332C5, 0x0C97B6FFF25BFF00, 0x0000000000000008,
+	},
+	B: FpElement{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	},
+}
+
+// The x-coordinate of RB = PB - QB
+var P964_affine_RB = Fp2Element{
+	A: FpElement{
+		0xF63DDE18660F8E44, 0x78524D4389CC09EC, 0x9E2332A233FE7526, 0xBF7DC0C9249474D5,
+		0x048A98B3911A3DD4, 0x35858ACED66AE3FB, 0xA55A87ACC2E6B73C, 0x7DD3A30647755439,
+		0xA9FE56E67FAC38C5, 0x09F8643FC46BE431, 0x0BC224FA286791F7, 0x3EB034A02F5F26B7,
+		0xE766613C61A6DC1F, 0x9A1D752F94071E07, 0x9B4CBF70EEBF8E45, 0x0000000000000001,
+	},
+	B: FpElement{
+		0xDA802642FC51F79D, 0xE1207A3070968D93, 0x1E95726E11B1A38A, 0x82582B55318316E8,
+		0x5761D74B2F5EF454, 0x6A2A60420868B750, 0xCC20014008A3C224, 0xF3555AEA329AFC5A,
+		0x3996D30AA2EA9E57, 0xC410F7C4B99D7CF1, 0xC8B521A31F5613CF, 0xE95FDC6678927B4B,
+		0x34931075D44C46BD, 0x8C01644C526DDC94, 0x1026BAA19D9D6287, 0x0000000000000005,
+	},
+}
+
+// 2-torsion group computation strategy
+var P964_AliceIsogenyStrategy = [strategySizeA]uint32{
+	0x6B, 0x3B, 0x20, 0x12, 0x0C, 0x07, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x05, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x08, 0x04,
+	0x02, 0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x0F,
+	0x08, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01,
+	0x07, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x1B, 0x0F,
+	0x08, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01,
+	0x07, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x0C, 0x07,
+	0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x05, 0x03, 0x02,
+	0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x30, 0x1B, 0x0F, 0x08, 0x04, 0x02, 0x01, 0x01,
+	0x01, 0x02, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x07, 0x04, 0x02, 0x01, 0x01,
+	0x02, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x0C, 0x07, 0x04, 0x02, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x05, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x01, 0x15, 0x0C, 0x07, 0x04, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x03, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x05, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x09, 0x05,
+	0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x04, 0x02, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01}
+
+// 3-torsion group computation strategy
+var P964_BobIsogenyStrategy = [strategySizeB]uint32{
+	0x59, 0x3D, 0x2A, 0x1D, 0x15, 0x12, 0x0D, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x05, 0x04,
+	0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x06,
+	0x04, 0x03, 0x02, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x0D,
+	0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x04, 0x03, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x13, 0x0D, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01, 0x03, 0x02,
+	0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x01, 0x01, 0x1C, 0x13, 0x0D, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01,
+	0x01, 0x01, 0x01, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x06,
+	0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02, 0x01, 0x01, 0x01,
+	0x01, 0x09, 0x06, 0x04, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x02,
+	0x01, 0x01, 0x01, 0x01, 0x03, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01}
+
+// Used internally by this package
+// -------------------------------
+
+var p964 = FpElement{
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0x451CD4BFFFFFFFFF,
+	0xABB38EAB467ACDE5, 0xE56EF6AA57A94749, 0x093F2B8DAD5281E7, 0xCBAB245135469BAB,
+	0x50CBAA75A2A1FA44, 0x10028248AD4FC4B1, 0x6B5BFF7643C64F7A, 0x0000000000000008,
+}
+
+// 2*p964
+var p964x2 = FpElement{
+	0xFFFFFFFFFFFFFFFE, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0x8A39A97FFFFFFFFF,
+	0x57671D568CF59BCA, 0xCADDED54AF528E93, 0x127E571B5AA503CF, 0x975648A26A8D3756,
+	0xA19754EB4543F489, 0x200504915A9F8962, 0xD6B7FEEC878C9EF4, 0x0000000000000010,
+}
+
+// p964 + 1
+var p964p1 = FpElement{
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x451CD4C000000000,
+	0xABB38EAB467ACDE5, 0xE56EF6AA57A94749, 0x093F2B8DAD5281E7, 0xCBAB245135469BAB,
+	0x50CBAA75A2A1FA44, 0x10028248AD4FC4B1, 0x6B5BFF7643C64F7A, 0x0000000000000008,
+}
+
+// R^2=(2^1024)^2 mod p
+var p964R2 = FpElement{
+	0x49BEAB58E287A9F1, 0x86A242D12EA5A11A, 0xB12512C92DD30800, 0x83D81C6BF9BC092E,
+	0x5AFA951F0F780370, 0x36BAB97634D25944, 0x130638EF637CB27F, 0x0CDB7689C4452519,
+	0xEF58B1B27E0542F8, 0x6109A63CB14FD223, 0x7E60914AB79A734B, 0x282640CE02415051,
+	0x8E76DB8220153984, 0xDE7F01881434E82D, 0xB0AA8606EF7C00DE, 0x0000000000000006,
+}
+
+// 1*R mod p
+var P964_OneFp2 = Fp2Element{
+	A: FpElement{
+		0x1E67F3F78730D81B, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x3E738FC000000000,
+		0x0A21FE854AE027A8, 0x853FB7B621CC75C3, 0xDB8515C38E354F0C, 0x6232C1D569A850B5,
+		0x377C1534E2EF4915, 0x964EE9BA62138CAD, 0xE011B4007E0E88AA, 0x0000000000000004},
+}
+
+// 1/2 * R mod p
+var P964_HalfFp2 = Fp2Element{
+	A: FpElement{
+		0x0F33F9FBC3986C0D, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0xC1C8324000000000,
+		0x5AEAC69848AD7AC6, 0x355757303CBADE86, 0x726220A89DC3E87A, 0x16EEF3134F777630,
+		0x4423DFD542C8A1AD, 0x5328B60187B1A8AF, 0xA5B6D9BB60EA6C12, 0x0000000000000006},
+}
+
+// 6*R mod p
+var P964_SixFp2 = Fp2Element{
+	A: FpElement{
+		0xB66FB7CD2B2510A5, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0xA75EE04000000000,
+		0x39B14B1DEDD08441, 0x6F316A45C3CEECB5, 0x0960FFEC4D485493, 0xEA2F1E0CDA1E1142,
+		0x5A857FDC69B5C7B1, 0x55D1F3844485FDFB, 0xFE5639A029044591, 0x0000000000000003},
+}
@@ -0,0 +1,29 @@
+package p964
+
+import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"testing"
+)
+
+// j-invariant of the curve, computed using Sage
+// j = 3555617998939097502706615520136446866439811344109449478797944556869672717027177099183208658558668642022509704677156456647553534664473268260154474741531160669331409258644912647484962788698376414627596729670031991706860363747670904711995281983869558219753811769524750060247384018864144198957*i + 54570927095234162605175917885856452062814365387834448652712049880435877953220550460110906564325191044952385058260678156259376087225982118865053816867301495685208230179139160898219859569924880943937280396434559436873527399201849414143399826932785995520577185728316381383173491178247995024997
+var known_j = Fp2Element{
+	A: FpElement{0x5525e4dd870249bd, 0xeef882e007f8e10d, 0xc1db32afc00b0872, 0x55c051b61ae4ef06, 0x3652e4eec6db6ce, 0xe8fa2c0562755b83, 0x2ec5578cfa088382, 0xa0981919dcbdfd6f, 0xc08f847f132a9c41, 0x37a0ca04bb0993a3, 0x4b4f485a954aee00, 0xdfe452df4ea2edf6, 0x63e058f4d23e50ab, 0x7dc3f11005914a7d, 0xfa3dad7ee9672599, 0x7},
+	B: FpElement{0xfa65f72e49fad17b, 0xe19f24b16a9a7e05, 0xb061be9a36373d27, 0xaacb9d17edcf3cb, 0xaf48d638f2265d8c, 0x7458cfc03c34a71a, 0x761dd293a78e86, 0xa2d68e1b5a093e60, 0x95553fb6f5bd5dd4, 0xe46db55ccaeecf64, 0x17e67a4673b4c92a, 0xd938eafa2a9461be, 0xb24773441eacb2da, 0xb2e1a34c9466b5a2, 0x38ac39aa28ca4277, 0x3},
+}
+
+var curveVectors = &fieldtest.CurveVectors{
+	A: curve_A, C: curve_C, J: known_j,
+	XP: affine_xP, XP2: affine_xP2, XP4: affine_xP4, XP9: affine_xP9,
+	M: mScalarBytes[:], XmP: affine_xaP,
+}
+
+func TestCurve(t *testing.T) { field.TestCurve(t, curveVectors) }
+
+func BenchmarkThreePointLadder255BitScalar(b *testing.B) {
+	var mScalarBytes = [...]uint8{203, 155, 185, 191, 131, 228, 50, 178, 207, 191, 61, 141, 174, 173, 207, 243, 159, 243, 46, 163, 19, 102, 69, 92, 36, 225, 0, 37, 114, 19, 191, 0}
+	for n := 0; n < b.N; n++ {
+		kCurveOps.ScalarMul3Pt(&curve, &threePointLadderInputs[0], &threePointLadderInputs[1], &threePointLadderInputs[2], 255, mScalarBytes[:])
+	}
+}
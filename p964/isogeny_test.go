@@ -0,0 +1,101 @@
+package p964
+
+import (
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"testing"
+)
+
+func TestFourIsogenyVersusSage(t *testing.T) {
+	var xR, xP4, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny4(kFieldOps)
+
+	// Given 4-torsion point on E, constructs a four-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP4 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x446AFBB9DD0D3449, 0x304A18C55926B858, 0x899212EED976A121, 0xDF0D00CEDBCF1F8C, 0xF31A9143EDE32885, 0xBFE4E44542D0C524, 0x639020F5E4FE17AB, 0x2C846A8F1D765A60, 0xE2D315ED25C1C989, 0x8E54F85716E3E3BE, 0x8CE4FA5F21CC3A2A, 0x9D240B40AAB99EE4, 0xCAB05A8809B6576E, 0xF4921C26D6D43232, 0xACFE6B7B4F8AED20, 0x0},
+			B: FpElement{0x807F62739E4D7A34, 0x31B63E126F140C13, 0xED0FB46749E63C2C, 0x8E7CB4F3A5C541AE, 0xD4B4692DE96E1605, 0xE2021225DB6899CE, 0x195A60441DB7CEE3, 0xC4F6B74868DAECBD, 0x8DA7E5305A396476, 0x1278D434E23B4B74, 0x2E5EE67B4D74A47A, 0xC24FDA311F2E9DDE, 0x199DA1C7E2F00CA9, 0xAA081E3F04257E18, 0x1AA0DF76986EC3AF, 0x6},
+		},
+		Z: Fp2Element{
+			A: FpElement{0xECBD065E0364B108, 0xFCF3AD04A38C086E, 0xB7382E463BAFD8AE, 0x76B07B8655E9F2C6, 0xB741B209B900EC10, 0x68A2770281D529A0, 0x8BFA6032B1209C03, 0x95F34FD3303FC85B, 0x38EA2181F419178A, 0x9759E8F0E7FFC05C, 0x6BE1528D3AECC978, 0x8D09C7F50F4E8276, 0xD3972603896A1D41, 0xA1989312EEB844AF, 0x6E3B7E974EE24F93, 0x4},
+			B: FpElement{0xDBF319964B99849A, 0x4D4EBDE6105B45A2, 0x615F1906DD1AD4AC, 0x532C10878FCC26EE, 0x7ADFA5C71D27A36A, 0xDB561B2EDAD73660, 0xE4A4410E35E594E1, 0xA9E43CA8A484EE8B, 0x22842F842B6E0A8F, 0xD90F2B4AB13B398, 0x62E992C6EA3DF536, 0xB5FD8EB0C6D93326, 0x24F8BC4C7A5AE17, 0xE3C6C364DE67FB70, 0x6689C6DFEF603C91, 0x7},
+		},
+	}
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xBC141EB1EA6D8371, 0xF7ADA0E490C08B3, 0x282F2B32A50A0E5A, 0x2148ACB2DAB39574, 0x3EC8489B1CDFE6D4, 0xEA242CC75DB5C805, 0x8B60B0A944140408, 0x14E125D8FCC6D6B5, 0x73EF6D42C1145C1A, 0x3886C52662E03064, 0x9837E68E1F774D0E, 0x9C68710E6C5EC6AD, 0x4A189CC1763E11D, 0xE085C836D0D3D47D, 0xD62C0F665E3564F6, 0x1},
+			B: FpElement{0x4DC37255BFB9D7A9, 0x27091A5DA56EFDDA, 0x3AF337969F8D3C7A, 0x178D2E7614E008FC, 0xFD960B8FE52B3417, 0x13C29150C8784D91, 0x47094B71F6576985, 0xCADF0901BD93499E, 0xBBFF958AE5B224C0, 0x620D0E4C69C9017B, 0xDA5CAB7F87CF148, 0x253BEC73ABC67DB4, 0x1361BED5C8C9D2E7, 0x10FCF39EA4E1B78C, 0xBBF93CCA0EF129B7, 0x1},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x2EB06CD6D23BA879, 0x36552D74BA5FDB1F, 0xF841FB5FFE6F4187, 0x513552A6A750496A, 0x821483567D942994, 0xEB710AE808D2EF67, 0x6BD41382F65BA0C, 0xE16BD789D701DAA8, 0x9895C0EB57C76BD0, 0x29FD33416FC50C40, 0x8B2E875B7025E39E, 0x8F39BBEDA0AD354, 0x7D18C5803746C53E, 0xF8BD21C661E5FD4A, 0x747A64BA01CA8160, 0x6},
+			B: FpElement{0x1E6A4F3A3E8D1F95, 0x3618DE16E6FF0BFA, 0x3149BBC41FE5B38A, 0xDE0C1BF1786EB007, 0x40E168D89D000993, 0x829DB197FE32F179, 0x1A2546C8F1AA6B43, 0x663533BD1FF6BCBB, 0xD2CA67B88632734F, 0x2F3F278FBE410719, 0x3D82535455C882A6, 0x45522D47E7DEA0, 0xBBBC0725D9D509BA, 0x4FA69FC3DF61D8A4, 0x5DFBA0C2B90D43DA, 0x3},
+		},
+	}
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0x59AF8496F2C20267, 0x79711E99FC7780DD, 0x7E742C38343ED5BF, 0x4092499FB8CFCFEE, 0x691CF33C65DCA17E, 0xF9C52F1A336CD8E4, 0x957C895D35AB474B, 0x1016731852C592C0, 0xF86666FC2A318A29, 0xC11B1311FA1D6645, 0xDCD78EEB651F405E, 0x64925A75A539EFEB, 0x6D838888518B0BFD, 0x298BCC11998A1D66, 0x6CBC28E979E79E5A, 0x2},
+			B: FpElement{0x4950A9C11833A268, 0x7B1AA24F14D846F9, 0x9E651423BFF8ABF0, 0xCFF80A7FA8257CC5, 0x9BF03271F17E0F2D, 0x1FF476DCBC2C80B5, 0x4B292860BC4935C3, 0xBDC6FF5E899F2760, 0x4E643CCEC33E97C1, 0xCD6D0FB4A753FFA2, 0x427DF4784FCF043F, 0x5EBBD2083B25CB80, 0x96CC7F08C3B8E07, 0x831A322DA034B9F, 0x383EB0C62D18B90D, 0x0},
+		},
+		Z: Fp2Element{
+			A: FpElement{0xE33D62157DCEF11D, 0xB8F762495478F47C, 0xB3A27A301FA18AA1, 0xDB11ED3EB4B8AB60, 0x29E3DF81D30EE42, 0xEFAC92E4048C4FA5, 0xD01DD11F77E770F6, 0xCA471261080F9703, 0x386E3220542FF32C, 0x952D1ECF95B610EF, 0xFACD35A249BE0313, 0x1AE1B1FA05C5F7FC, 0x3A890EABE2EFCAEF, 0x1B1B640D1E31EA69, 0x5B990A9174C4633C, 0x6},
+			B: FpElement{0xA9790036D79473AD, 0x97E0C8B86927F71B, 0x8A685A6B80D48EE1, 0xF70A25052FBBBED7, 0xE41CECE524F6CD36, 0x9FA16DCA4EE40FAB, 0xE092ACFC94FA58CF, 0x557F985817239C87, 0xD3C4EE568282B3BD, 0x4549FA88C9463F4A, 0x32F345C80D3925D2, 0x645907E6952FD61A, 0x4B50AA33F71D3D27, 0x1E5B21716CE32DE5, 0x14AFE43FD60CAAF2, 0x8},
+		},
+	}
+
+	phi.GenerateCurve(&xP4)
+	resPhiXr = phi.EvaluatePoint(&xR)
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
+
+func TestThreeIsogenyVersusSage(t *testing.T) {
+	var xR, xP3, resPhiXr, expPhiXr ProjectivePoint
+	var phi = Newisogeny3(kFieldOps)
+
+	// Given 3-torsion point on E, constructs a three-isogeny phi. Then evaluates
+	// point xR on the isogeny and compares with expected result.
+	// Values generated with reference implementation.
+
+	xP3 = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xA8BEAE5B00FF599B, 0x92C6F9561B94B00C, 0xD52A85E302B748F3, 0xD8E08C93330BF52F, 0x82A6753BDDA6F0C1, 0x4A9B1AE9600DA4F4, 0xF3BF57DFE7004670, 0x78D7841740A045C4, 0x293F8DFF07E5CFAE, 0xFF3AC1A1AA3B3E28, 0xC7BD00406B703F90, 0x64CB69CA07B16380, 0x503DA94F276DCC82, 0x4A51C477F4241B72, 0x8200F6DA46C9D1EF, 0x1},
+			B: FpElement{0xD0061EC026C042A, 0x2C43D24C3BB2CBD5, 0x43E22E6CD71CE266, 0x2F0085CB5B66B068, 0x7C02E9EFB9BD1C4F, 0x549AC492B776CEFA, 0xA4C2E1BEFC4E7317, 0x1B1A093A23D94921, 0x3D5D592F745F1F4, 0x64BBB602FB3C6462, 0x38C0667D45A6557A, 0xAEF6B5A540AF6A89, 0x7A8E14CB88997F38, 0xD51E7FBAD537CED, 0xD330321DC00EE09C, 0x4},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x2E39B5C534F21548, 0x9DD802815DFE4BF0, 0xDC80D2491275C118, 0x7DB7757AF03505A8, 0x39A3A1D4815371FF, 0xEBABD7A96302D239, 0x88C31A9A21CC98A2, 0x78AF7D4D14C3C57A, 0xB390D61A10768882, 0xC4ED68737B73D69E, 0xDBA26FE04EA7A148, 0x2DCB849D5D93F84F, 0xDECC29CF5DF6B05F, 0x384E6FA8852E9A2B, 0x194254976205D75, 0x1},
+			B: FpElement{0xFB367A1070A9FE03, 0xB9AA552263A413F7, 0x2D43C82D9203A932, 0xDDFDFAC34B33B2F8, 0x15271664DB13CB1E, 0x4293F28ACCF6D5DB, 0x3A9CD0BB0EE299DE, 0xFAA3FDAA6F869B8D, 0x46D4E8E84538E255, 0x199B44C37C10FAE8, 0x2D3E7820D50E13C0, 0xDFFEF828A98973EF, 0xC66DF4A2077A0954, 0x987B981A1CE3AD33, 0xB2CDAC52415F8FC2, 0x0},
+		},
+	}
+
+	xR = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xF4EF3EACA5B185F, 0x16B6C73C3FE392A4, 0xF4B36AFFA7E2537D, 0xC37C7F9877829ED5, 0xD5F803D5FD7DE9BD, 0xDB9FD7BB4EED34AF, 0x94AB4ED9D774ED93, 0x27B59EC25CA28412, 0xA9C0CB4E22B4EB58, 0x76CD1316F81D3736, 0x81FA5002064C7A4A, 0x1B805ABB0B40421B, 0xF9D698B81E58A076, 0x7741D207913CB2DD, 0xDD7781E48B28C2B7, 0x2},
+			B: FpElement{0x67C75781FE8FB328, 0x92BF8BC2B70CAD38, 0x2783ADF07AFB0445, 0x56D086C5F7074E3D, 0x40AB659424B8AFF3, 0x93F85DD205DD7D09, 0x76E2FF81FF09926B, 0xB62C43B31D50A229, 0x3DB08D610D3842BF, 0x5FA6C68F9ABEFE9, 0xA27E8B012F64DE14, 0x5673CD749FB626C0, 0xAC754EB3B16D667F, 0xFEE7D55FED0CB3AE, 0x81DF9FCD664E3E78, 0x0},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x82F1F11C0DDBBACD, 0xD06C7A8CB882D0B4, 0x79CE07D37F38262F, 0xE0BDAA8100D3071E, 0xBF366CA766AA02D6, 0x7EE8282A41D684EB, 0x8805A36167B30D, 0xD6798EF3A32CB405, 0xF21ABC55104D206B, 0x3F3908F1CF0ECA95, 0x85A84D5F95F6447E, 0xA031FB9EF89922BE, 0x9D5528500403CFA7, 0xC52A1A400F5EDC47, 0xBABBBB93ECC74DAA, 0x5},
+			B: FpElement{0x259246D4EDFE8132, 0x1C0D9F4939766E4, 0x53FFCF72B8D53E58, 0xEF286C60826E56BB, 0xF664830282F16DC4, 0xFACF823A566E0293, 0x58C8CE4BBBE950C, 0xC47D2C53EE865FBF, 0xC94C57D473B362EE, 0x4DE8611943120DF9, 0x4EC5D7BFEEBB35DF, 0x97E52DFCFABF5C37, 0xF4E0E47D128D8269, 0xCB10E57BFEA7B45F, 0x8BA55D4CB3B6B9DD, 0x3},
+		},
+	}
+
+	expPhiXr = ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xDD852CA96946A726, 0x9EC80F1F454366C, 0x8B23670BD68A8BED, 0xD03F10DD1BB904AF, 0xA938CC1E0372CBEE, 0x709A2007E569ADBF, 0x2152F276B654A799, 0xAA91043596D962F4, 0xDBC2566B58D67B7A, 0xE1013F1B17A6F552, 0x1CA6734EEF14976E, 0xE1227E479559F5C, 0x38EFB66D0ACC5984, 0xBF35BA939228E7A9, 0x799F55B2C4161756, 0x2},
+			B: FpElement{0x74CC1C471B61A453, 0x955758378CC68616, 0x7F87C4E533DBCDF9, 0x8CFE1EAEA6F028BC, 0x49EE0B7ACD7C955D, 0x6322775C22E07B20, 0x3B3A0E62BDBF8DED, 0x64C787660EAA7F0A, 0x1A45773697C3998E, 0x95E6802FBE8C229F, 0xBCD4E787B9BD2293, 0xA5837BA2B5D35190, 0xE99F4E24D8700E4B, 0x90C9097623ADB194, 0x69F83909369DD108, 0x2},
+		},
+		Z: Fp2Element{
+			A: FpElement{0x8F5D9F2A540183C0, 0xF90ACE8A7BA9BFAB, 0x243F4B4F6C70203F, 0x46BC97366E856A09, 0xC04E06E326D63EFD, 0xA30014324102809D, 0x1F3E44EC1000D27E, 0x714485E8E4524224, 0x1B6F8F586BCA872E, 0xB2729B635F8FBB00, 0xADBE543B1DD0D1DE, 0xF1F6874F537885A8, 0x16A97B4B8B477543, 0x7AD1C13BF7098D16, 0x705DD12D576BA6B, 0x3},
+			B: FpElement{0x5A89B3F2735DFE2C, 0x516C135ADB1738AB, 0xDAE49A457667A01A, 0x7689D800A8DFFB6D, 0x2931F4F38A7129BF, 0xE9B97B67FB4CA6C8, 0x4EEC3EA99BC62493, 0xDCD00A57DE52D29, 0xBEA540BAB765D5CC, 0x1FE10CA8B80C09DE, 0xCB1553598C0C07B2, 0xA2749E592F7E0940, 0xD71E14BF5AA97EFF, 0x3013CBBDEB7E3D66, 0x6075FB48CADBEA85, 0x0},
+		},
+	}
+
+	phi.GenerateCurve(&xR)
+	resPhiXr = phi.EvaluatePoint(&xP3)
+
+	if !field.VartimeEqProjFp2(&expPhiXr, &resPhiXr) {
+		t.Error("\nExpected\n", expPhiXr.ToAffine(kCurveOps), "\nfound\n", resPhiXr.ToAffine(kCurveOps))
+	}
+}
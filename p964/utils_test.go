@@ -0,0 +1,124 @@
+package p964
+
+// Tools used for testing and debugging
+
+import (
+	"github.com/cloudflare/sidh/internal/fieldtest"
+	. "github.com/cloudflare/sidh/internal/isogeny"
+	"math/big"
+)
+
+/* -------------------------------------------------------------------------
+   Underlying field configuration
+   -------------------------------------------------------------------------*/
+var (
+	field = &fieldtest.Field{
+		Prime:    p964BigIntPrime,
+		NumWords: NumWords,
+		Bytelen:  P964_Bytelen,
+		OneFp2:   P964_OneFp2,
+		HalfFp2:  P964_HalfFp2,
+		Op:       FieldOperations(),
+		Mul: func(z, x, y *FpElement) {
+			*z = new(primeFieldElement).Mul(&primeFieldElement{A: *x}, &primeFieldElement{A: *y}).A
+		},
+		P34: func(z, x *FpElement) {
+			*z = new(primeFieldElement).P34(&primeFieldElement{A: *x}).A
+		},
+		MontgomeryReduce: fp964MontgomeryReduce,
+		StrongReduce:     fp964StrongReduce,
+		CondSwap:         fp964ConditionalSwap,
+	}
+	kFieldOps = field.Op
+	kCurveOps = field.CurveOps()
+)
+
+// A = 23484648120221498642738353980977607529987536665122859526926851906121133667574785341154300592092781093356091520213041936894398711380035221933339470783208347010255464321827315334307282454647026121105548933339474105147474042233433518012730954666509209698590620214349000842860380123792910855247*i + 64043783847082910224898446108834058281116716047855450262012483803802477860789651734988962228306887193106480690982216499524741974363557976617550964056524430924344968306744958215706544892456157107755930724754119209037489593646939759288870428747362143795839908767092616353472069926752068819855
+var curve_A = Fp2Element{
+	A: FpElement{0x9d84a60bb4a79f75, 0xb75ffba9392067eb, 0xc2ff12210204829, 0xc7460259f781bc93, 0xd1e280fd5ec7a8ec, 0x739b37546fee9fdc, 0xe0923151507dfb15, 0x24ad8b31bce40ce1, 0xef63237aac3ec0f0, 0x774107ea922e892b, 0x266b95f19dc868c4, 0x46644ae1e0244218, 0x69f3f475e86ce48e, 0xf08af08fd65af27a, 0x4a8f9eaa88b53b69, 0x4},
+	B: FpElement{0xad0484cf20034540, 0xd13257dce3063f36, 0xaab84344a6f19766, 0x63ef200cd27a6ced, 0xc08e03a5329a33c5, 0x9d12f0da5854e488, 0x38ceb82ee8204344, 0x9c6609b0cf78d5f8, 0xea974099b43e3234, 0x37b89e8ddad26016, 0xbe8dbdc4357c00, 0x8fa01d32b785568b, 0xdedffc2251d4c2ae, 0x26b63c69f3f8cd9c, 0x2a3a9b8c698e9b09, 0x2}}
+
+// C = 30791921332194264788248382568790915603814733839003696620066669129403249564517206609364600065027241666404186622685794282605112594045346044623996979648348275029668473468949908258433867527689999372521229528546038891299637446732490645395705209292604114946164237672287384576555142487297195960118*i + 24938770939082738447330358734452413558262920921670982538599436928771086477710117820913949431979268756723826456936867113251271300560104786301220283347550322465976810892953428644424339472884778125082789925877198076800709154979663987248088146673228518932205577819225385687550486549000583290979
+var curve_C = Fp2Element{
+	A: FpElement{0xe6f6556223744657, 0x5a2e009cb3979259, 0x2c1df696edf4518e, 0xcfaa36e6635889c1, 0xe85da5476c577dc6, 0x5e01bbb09beb5bad, 0x43fad13c243ba29d, 0x8f83d4be6eed7493, 0x2e3adf20dbf7fc8c, 0xf90c643efb192a21, 0x61e9d9f09ad058f7, 0xf8c06aea1705c336, 0x5286c8bda1c67510, 0x8fe86a4f38e16f38, 0xcda6ffbc30728411, 0x2},
+	B: FpElement{0xbcd453128df62141, 0x69eaf9ab2b72b542, 0x4a712261dec7baef, 0x8a8ec9b606bc3c3e, 0x61a68774bdbdd536, 0x876ae502b685fc52, 0x730daee40a40ca87, 0xbfb10b0ba19c0111, 0x58a2211c5c96799, 0x213634e26641f4d4, 0x5350348851830046, 0xab9e4d29bec1667b, 0x6716cb02b915ee1f, 0x1d8c51d7f7b75c8c, 0x3fa1c97782fdd75e, 0x2}}
+
+// x(P) = 31837699209180787787567117499266362476964445942202447098508271230445602981852704351814186715684159146332995939062083867210741484490844347816624953210498977106731722998495731513584796826386203874874672720699852777410140412697322893489641026485599700311923855132281049736974037286557255326379*i + 52925816694219817723528166974831318781748552585067667877212228872545581791715367987000039254078776510508017945346466496009710659392252602765434848974008912947482315832290600319668655138662048037015991104526253620051284824645307172389991452665514729924145121007466345099611822705051761668340
+var affine_xP = Fp2Element{
+	A: FpElement{0xce8b596becc23026, 0x9d2d54efa75a55ef, 0x55c6bbf101b2342a, 0x673f5bea943739c6, 0x141a8b2e539fba91, 0x3a2ff94acc69b9d, 0xd42876839ff5b424, 0x85cb9eaebeace052, 0x1d634f628cbc4bed, 0x8b91fc0d29eebb2, 0x57b25853a8560845, 0xa0428e93c2f2c9d8, 0xa2e1e41a7f47d8d4, 0x9c1583a671e8465b, 0x9619b3fcf21be418, 0x1},
+	B: FpElement{0x83b353c65af113ff, 0x3c4c0526c139c16a, 0x30d4a424af777bd5, 0x23f7c5bac182038e, 0x18dcc9bf215ad3e8, 0x6f8e10b5080bc12e, 0x375e54121c2a628f, 0xdabab0e7b00347f, 0xb1544daea9ad2415, 0x827580f2bbd6cbe7, 0x4627d5349332f43e, 0x73f7a6d9f39e1f2f, 0xfe86c39e440c367f, 0x53c57a5cb4ab5b2a, 0xcb44fb8fbf633f0c, 0x5}}
+
+// x(Q) = 29264614097789581805681277713358664800349725295355989215029200784054014477024631045993759607323881609534029433911843706258223287481169744263419786550552631656160742837180779614571943475924228989223470043035914584120770083843124616008026079565342442366923125601858319574840400869389858877441*i + 43801573633539649097435419639288157574531187697579794611387692221577591299309242050059565062655931265831541354308922810547725362626514137358716276720029052139217034183543031501323750737483894366500062101761408639239864430625130286442458381508718692723295133617975676452509653818625097970866
+var affine_xQ = Fp2Element{
+	A: FpElement{0xec5facc3ef52bd20, 0xdf36a9924d136b22, 0x3f76da97049cddb6, 0x5136ceacd777a6f4, 0x2fc1e129fad8f543, 0x706afea552d06bbd, 0x1cdc7d2a7e9b8be6, 0xab33bfb7ebd69a4, 0xc776580d85f2c771, 0xe92c0fb832da0db, 0x56ed0ba09c19db38, 0x1d00251584137682, 0x661af797c310fb76, 0x1ca7955cadf2c88, 0x9fc677af447009d8, 0x0},
+	B: FpElement{0x996c64ade153c247, 0x3e437013f0178e8a, 0x727d75532b85cc75, 0x6e337df1ce7ac9c5, 0x7ec545b12d2ff3c6, 0xa4fa281be126b83b, 0xeacffe25696b225, 0xcd6b036e9ac220c3, 0x996edb12c58b0f7e, 0x80db802382ee6d, 0x82dcf7461bbacb8d, 0x4000ffb81a759649, 0x7ebe9da9b420dfef, 0xad73786af6dc7f48, 0x18df22f3ba2e46fc, 0x0}}
+
+var affine_xPmQ = Fp2Element{
+	A: FpElement{0xf397248da01fbde7, 0xb9c600905e42a438, 0xdd3ef6548df28fec, 0x1bd48a55cd381db7, 0x53401f9164301b99, 0x5fa914b783357f96, 0xe6d8749540106ea7, 0x967dec02eb1bc594, 0x525448db9280b1c2, 0xd3d2da5341184dd5, 0xc64a81a446254375, 0x4d5aeb77a1adcc72, 0xc2f201e82758e7d1, 0xaf814d9f42adc535, 0x3e8a1a797731283f, 0x1},
+	B: FpElement{0x47bae3a6cdb27ab5, 0x385a26bce3b8efd1, 0x2226282f480a6420, 0x52cc5727281527b, 0x9898d399f3f99a9c, 0x40e53d8d5e87f62a, 0x9b519abeb566b76f, 0xd88050ac4b655a1d, 0xa5d258acff543792, 0x87a6e0bb02cc846a, 0xfcd4817b8ac72c9b, 0xf32093c3c514b88a, 0x1c5d1e28c7dd83b7, 0x7db12d69ea228992, 0xe69c37b949d1b1e9, 0x4}}
+
+var affine_xP2 = Fp2Element{
+	A: FpElement{0x1428ea281f02a67d, 0x5b5df05f30aaad1e, 0x70c56be9a441a979, 0x5b613455a23b11fe, 0xa3d5069a42b1adee, 0x106afb2a06d72f70, 0x5fe1a05c6df167f7, 0x3091d6def52dffa8, 0x775be92a7b03bc12, 0xf2c3e614c3ac7a3c, 0x693d210035aa1ff6, 0xc36b76bf1686200e, 0x7d996c3e8a891ca6, 0x3b2144db0bee9c8, 0xdb8a8f70faaabeaa, 0x4},
+	B: FpElement{0x871da72e625a9223, 0x6da0d3af7e0d2f67, 0xefacd03bff22094c, 0x75c94df50ebac451, 0x356518bf81bb3034, 0x9e8488ea44ba13b0, 0xab2738732a27f97a, 0x92a1af63420fd7cc, 0x1016db75f8d7dc19, 0xc11e1e8c21a4ee9b, 0x47e13faa2780c20a, 0x70e8010801ee319a, 0x4d8e1f9cc6f055c1, 0x5f9e73e99ab90981, 0x7a4cf7fb4842f7e7, 0x0}}
+
+var affine_xP3 = Fp2Element{
+	A: FpElement{0x9ad7f81439bb0f23, 0x4621b2989b2358dc, 0xb18b8a4ba141aada, 0x1a0cab48bc8b5f0f, 0x97aa170b05d9b742, 0xb5a969d07ea2ad7c, 0xb5009c5ceb2538ed, 0xd34eecf172774785, 0xa435052859a4d679, 0xd43cbc1de1cca32, 0xf1b64ebb8052f938, 0xcff65b9fffd3dc79, 0xa3c0dd5e4186632e, 0x43025814a527c0d, 0x6fed79aed678095a, 0x2},
+	B: FpElement{0xb0ebb42f7a48f415, 0x519ad995ba08bb23, 0x33d83456203ab3fc, 0x3eca776dffc881bd, 0xa3b1ceaebfa7d3a2, 0x9bbbcf61ddfd18e2, 0xef6bd82faf749eae, 0xa72da35fde99a426, 0x7e7bfe7720236a31, 0x14e140c87efc582e, 0xb846cba9c5c04c95, 0xdb40ecaf1ad4eaee, 0x3f226bf0c5cd725, 0x28207991a0fd7496, 0x88f9518ceeed976, 0x6}}
+
+var affine_xP4 = Fp2Element{
+	A: FpElement{0x52e1cb99b339de17, 0x81f5bd47f504e6e9, 0xcd6192cc0599df99, 0x2d91f332257d3b5e, 0x299db8e5b43b534e, 0x4dc572529c367e2f, 0xd347c04bfa6a2396, 0x6be9ec62ba23e8e, 0xe065e57c5cbc154f, 0xc526884a3eb90b08, 0xb464415bbc3ed49d, 0xbe6aca8526575ffb, 0x7b752c34db007e0, 0x3b0ecfd6c041a554, 0xf8f082121e9c5534, 0x6},
+	B: FpElement{0x20b4a65580369ecf, 0x98dcc9071d30a4e7, 0x238579e6cf0ae1e4, 0xbdf18db5c7b4b34c, 0xdc36c8e1caa98ef4, 0x8d539a042d1094ae, 0x5fc4a65a969c0446, 0x3df64e89b5508b28, 0xecf6e70cc07ab894, 0x9af4862920561507, 0x1fc525d41b7399d5, 0x6503008d11863f3c, 0x4039c234b347c285, 0xa621cbcbad51cdac, 0xd7b731beb54ab881, 0x4}}
+
+var affine_xP9 = Fp2Element{
+	A: FpElement{0xfd535eef73d240f5, 0x44afe68a70e8af6c, 0x2f40a9f9dbb41c9f, 0x3620a17f28c1c70c, 0x1027228432e852c1, 0xb51ff07db8706705, 0xa1f2d52673d3cf44, 0x46d03895b42c8a5b, 0x16abc7e6a00ae2f9, 0x516fa0a4d3ea6023, 0x23f1b3889309e580, 0xe54d016c008bbee0, 0xe94c7ec07f0a7bd0, 0x3a2eb3e6631fb072, 0xcccfaee28b5bb243, 0x6},
+	B: FpElement{0xcab04168e51e4658, 0x111bd9f286db5780, 0x5dd731ce142a4102, 0x769234c898acd79, 0x4f4e8c406dc7ee01, 0x77f8629922693801, 0xfc48e811b21a2409, 0xa293ae7d27575580, 0xa1e24486d21c8ba5, 0xec450ef1899edfcb, 0x499dfa4750b3023a, 0x9ab13042116d3bd9, 0xac975abd2ab7e84d, 0x231c861a15cf506a, 0x4482dd0f535b2adb, 0x3}}
+
+// m = 3292559289330037538267175805746722405299112365461736993625
+var mScalarBytes = [...]uint8{0x59, 0x33, 0xc7, 0xde, 0x75, 0x69, 0xb5, 0x7c, 0xf3, 0x97, 0xfb, 0x51, 0x34, 0x22, 0x6e, 0xf5, 0x8, 0xf7, 0x92, 0x3f, 0x46, 0xec, 0x47, 0x86}
+
+var affine_xaP = Fp2Element{
+	A: FpElement{0xc8edbde5cc9e750a, 0x7792ded9def6bb98, 0x3d3b37cc3991cfc7, 0xb3decdd1098c274e, 0x54f7595b5f5c4559, 0xab12427560a38d86, 0x73fe6b229f6c5a2b, 0x78107dd11b714cb9, 0x17f8e46f4446bbb3, 0xcc67d36572cfc751, 0xaa7e64a00521913f, 0xfe280d758bbf1a2a, 0x2e38cc84f9fa7458, 0xbc26f20cea534cd8, 0x10beb2d2133c3ae0, 0x2},
+	B: FpElement{0xa8fdb24de1818d0d, 0xa1a9568f3bea525e, 0xf007a606da805d86, 0x82fa9d316fb229d, 0x8ba760728e59223d, 0xd6c3463dc93d8cdf, 0x82044ad934ada7ca, 0xab70ef49666ef93a, 0x8055479a2f917ad4, 0xd584718511f00a66, 0x27fbec9e4806c551, 0x22a6f332fbd6cc47, 0xc7f9e7bfb172364b, 0x9cccd3c20311a9d, 0xa9cfa498fa5d8569, 0x0}}
+
+// Inputs for testing 3-point-ladder
+var threePointLadderInputs = []ProjectivePoint{
+	// x(P)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xBE53C7CA98A9FA90, 0x82E05410B7906BF5, 0x3A1EB1E426FEC9F6, 0xB55177DF464917E5, 0x41FCC86C3385CC2, 0x36EE7C58EA85B477, 0x17CD8671953F17C4, 0xC2F81D1F17DB33, 0xDF7DC7E75B2F59A5, 0xC4AEC568A1AE8B03, 0x92F7936551F945E9, 0x657BCC8E32A50D26, 0xF7B4F6328D5C4559, 0x3F9B778D8E9BA835, 0x3B1897A8C1B60EF5, 0x0},
+			B: FpElement{0xA7FB61F6B8EA8765, 0x69403C3DBC474753, 0x43903A84311A7A00, 0x81F6B10BEB7F0E38, 0x6F91555F5AC042EB, 0xB413C939D49DC62A, 0x32E826233D57B896, 0x6A96F735E824DB74, 0xFBD82DA3C07BEFD2, 0x347F57E865F297D3, 0xD7D5F1C2CA5B35C4, 0x728546D132BF84C9, 0x8DBCD2B4A2D4B1C6, 0x6A7AA9D5C2AD5DE2, 0xE45D35BBE3315D93, 0x3}},
+		Z: P964_OneFp2,
+	},
+	// x(Q)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xDB8CC0D79601D3A1, 0xB90BE1862502864B, 0x7CE2D41D78E42B40, 0xB0CFF63CE32535B6, 0x10B3DB31D2979E64, 0x3F3043826F59225C, 0xCA7C911190441BAA, 0xD6F7A37EEDBCE567, 0x71951375A8789569, 0x4B54F783407AA981, 0x7C860012BC8E7DF5, 0x11DFF3402CEFE111, 0x8E3910A6119B4CC4, 0x52156D6F4043CB51, 0xBF40B157E2115D57, 0x2},
+			B: FpElement{0x13174D4E0CA65C11, 0xCD1970A8201C07B4, 0x1A655F2521DB821B, 0x5988E79CFE27B33E, 0x1F069213ED7B397A, 0x65B7F9DEF83879B9, 0x3432AC5C4A6B1AD3, 0x8A13337BD90AEDB9, 0xA3E5554079BC4E07, 0x2223979113BA1BAF, 0xE2AB4F0959E8FE0D, 0xC0B7183242CE6E0F, 0x30E201D639DFF58F, 0x88E296DED8822B07, 0x1C6738DCE52C9314, 0x7}},
+		Z: P964_OneFp2,
+	},
+	// x(P-Q)
+	ProjectivePoint{
+		X: Fp2Element{
+			A: FpElement{0xE8FB8FF1275FB8D3, 0x68F2FC0BF4EE0DC9, 0xCD6062FEA4AD0593, 0xE6BBEA6DFF500914, 0x526ED8D9E98894C0, 0x1777BB9F928DFDDB, 0x3C2B51B8B7891762, 0x73683A489C2B1A9, 0x7AF374E959F3B100, 0x9C7058502305E228, 0xA49C112768552389, 0x9C976BC5F0FBE602, 0xD19946D2A43A976D, 0xC63E4CB00268809F, 0x5003C1988F9EA055, 0x1},
+			B: FpElement{0xD0C1ADC035D41BE, 0x4884BB4E03876841, 0xC0BCBE7B3724068B, 0x96C1E9C4937AC96F, 0x962A1498E7560498, 0xA6E7E7D2C1663A96, 0x462484D8ED5AC5D5, 0xE81087B30961FFED, 0x5D82760254A6B77A, 0x346BE7ABAB6D7E4B, 0x17D7553D6F5B358B, 0xF01F09BE715FFBE5, 0x516AA008392A062F, 0x71F09FCB79279FD8, 0xA1F780343BD7A8CF, 0x6}},
+		Z: P964_OneFp2,
+	},
+}
+var curve = ProjectiveCurveParameters{A: curve_A, C: curve_C}
+
+// prime p964
+var p964BigIntPrime, _ = new(big.Int).SetString("82049429049937972170744454730593345160514304243739159904783417843550315750231928581899391756256783736403989271048847542926715937076900048775072545358407957727845645357780713979087402767756607414183416359287811818280895339396273490061030555956264309782382325666413874887621917291859821985791", 10)
+
+/* -------------------------------------------------------------------------
+   Values used by benchmarking tools
+   -------------------------------------------------------------------------*/
+
+// Package-level storage for this field element is intended to deter
+// compiler optimizations.
+var (
+	benchmarkFpElement   FpElement
+	benchmarkFpElementX2 FpElementX2
+	bench_x              = FpElement{8761131970162991263, 3128165173056734655, 431895145231159993, 4221930765967545576, 6461213405477458584, 17374209993458447375, 3477620061192623381, 2734496777056759864, 16531822350994175308, 9347649458615470525, 12671278575965753703, 6068410795285663144}
+	bench_y              = FpElement{17735408160104000443, 14552777169377538140, 12486003552723987969, 9177486762972180482, 10863597977331259229, 14232866515019745817, 17439222005234167308, 998955813315640962, 11211170235730108119, 14888305979368327698, 4672233284383573341, 1746431752626528284}
+	bench_z              = FpElementX2{5826383184795658232, 7609866966076238404, 1004873513906191022, 17861365964374125675, 17277388762497219727, 13924220314956147454, 3995517865385049652, 18081061187302572110, 9110522895160158479, 6940190486073590805, 4110157659022690303, 4005979126136491585, 361932358868054416, 9350913605178341799, 6276877615642225762, 4241170929885565251, 4056206925714517862, 2868565117801855274, 9340621997210301234, 1897014018806299373, 3627091452938362372, 15874517214323749003, 5262378297811452970, 15566080607883717255}
+)
@@ -1,8 +1,10 @@
 package sidh
 
 import (
+	"bytes"
 	"errors"
 	. "github.com/cloudflare/sidh/internal/isogeny"
+	cshake "github.com/henrydcase/nobs/hash/sha3"
 	"io"
 )
 
@@ -10,11 +12,16 @@ import (
 type KeyVariant uint
 
 // Id's correspond to bitlength of the prime field characteristic
-// Currently FP_751 is the only one supported by this implementation
+// Currently FP_434, FP_503, FP_610, FP_751 and FP_964 are supported by this implementation
 const (
 	FP_503 uint8 = iota
 	FP_751
 	FP_964
+	FP_434
+	FP_610
+	// SIKEp503 and SIKEp751 as updated by round 3 of SIKE
+	FP_503_R3
+	FP_751_R3
 	maxPrimeFieldId
 )
 
@@ -30,6 +37,50 @@ const (
 	KeyVariant_SIKE = 1<<2 | KeyVariant_SIDH_B
 )
 
+// ValidationError is returned by PublicKey.Validate. Errors can be compared
+// with constants below.
+type ValidationError string
+
+func (e ValidationError) Error() string {
+	return string(e)
+}
+
+// Errors returned by PublicKey.Validate
+const (
+	// Encoding of a coordinate is not reduced modulo p
+	ErrNonCanonical ValidationError = "sidh: public key coordinate not reduced modulo p"
+	// Curve recovered from the public key is singular
+	ErrSingularCurve ValidationError = "sidh: public key defines singular curve"
+	// Curve recovered from the public key is not supersingular
+	ErrNotSupersingular ValidationError = "sidh: public key defines curve which is not supersingular"
+	// Point of the public key doesn't have order 2^e2 (3^e3 for KeyVariant_SIDH_A)
+	ErrWrongOrder ValidationError = "sidh: public key point has wrong order"
+	// Points of the public key don't generate whole torsion subgroup
+	ErrDependentPoints ValidationError = "sidh: public key points are linearly dependent"
+)
+
+// Names of public key coordinates, in order of encoding
+var coordinateNames = [3]string{"xP", "xQ", "xQmP"}
+
+// CoordinateError is returned by PublicKey.ImportStrict in case encoding of
+// a coordinate is not reduced modulo p. errors.Is reports it as
+// ErrNonCanonical.
+type CoordinateError struct {
+	// One of "xP", "xQ" or "xQmP"
+	Coordinate string
+}
+
+func (e *CoordinateError) Error() string {
+	return "sidh: public key coordinate " + e.Coordinate + " not reduced modulo p"
+}
+
+func (e *CoordinateError) Is(target error) bool {
+	return target == ErrNonCanonical
+}
+
+// Size of the seed used by DeriveKeyPair
+const DeriveSeedSize = 32
+
 // Base type for public and private key. Used mainly to carry domain
 // parameters.
 type key struct {
@@ -45,6 +96,8 @@ type PublicKey struct {
 	affine_xP   Fp2Element
 	affine_xQ   Fp2Element
 	affine_xQmP Fp2Element
+	// Set by Import if encoding of coordinates wasn't reduced modulo p
+	nonCanonical bool
 }
 
 // Defines operations on private key
@@ -68,6 +121,7 @@ func (key *key) Variant() KeyVariant {
 
 // NewPrivateKey initializes private key.
 // Usage of this function guarantees that the object is correctly initialized.
+// Function panics in case `id` wasn't registered, see CreatePrivateKey.
 func NewPrivateKey(id uint8, v KeyVariant) *PrivateKey {
 	prv := &PrivateKey{key: key{params: Params(id), keyVariant: v}}
 	if (v & KeyVariant_SIDH_A) == KeyVariant_SIDH_A {
@@ -81,15 +135,57 @@ func NewPrivateKey(id uint8, v KeyVariant) *PrivateKey {
 	return prv
 }
 
+// Names of key variants, used by DeriveKeyPair
+var variantNames = map[KeyVariant]string{
+	KeyVariant_SIDH_A: "SIDH-A",
+	KeyVariant_SIDH_B: "SIDH-B",
+	KeyVariant_SIKE:   "SIKE",
+}
+
+// Returns true if v is one of KeyVariant_SIDH_A, KeyVariant_SIDH_B or
+// KeyVariant_SIKE.
+func isValidVariant(v KeyVariant) bool {
+	return v == KeyVariant_SIDH_A || v == KeyVariant_SIDH_B || v == KeyVariant_SIKE
+}
+
+// CreatePrivateKey works as NewPrivateKey, but returns error instead of
+// panicking in case `id` is not registered or `v` is not a valid key variant.
+// Use it when `id` comes from untrusted source.
+func CreatePrivateKey(id uint8, v KeyVariant) (*PrivateKey, error) {
+	if !isValidVariant(v) {
+		return nil, errors.New("sidh: invalid key variant")
+	}
+	if _, err := LookupParams(id); err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(id, v), nil
+}
+
+// CreatePublicKey works as NewPublicKey, but returns error instead of
+// panicking in case `id` is not registered or `v` is not a valid key variant.
+// Use it when `id` comes from untrusted source.
+func CreatePublicKey(id uint8, v KeyVariant) (*PublicKey, error) {
+	if !isValidVariant(v) {
+		return nil, errors.New("sidh: invalid key variant")
+	}
+	if _, err := LookupParams(id); err != nil {
+		return nil, err
+	}
+	return NewPublicKey(id, v), nil
+}
+
 // NewPublicKey initializes public key.
 // Usage of this function guarantees that the object is correctly initialized.
+// Function panics in case `id` wasn't registered, see CreatePublicKey.
 func NewPublicKey(id uint8, v KeyVariant) *PublicKey {
 	return &PublicKey{key: key{params: Params(id), keyVariant: v}}
 }
 
 // Import clears content of the public key currently stored in the structure
 // and imports key stored in the byte string. Returns error in case byte string
-// size is wrong. Doesn't perform any validation.
+// size is wrong. Doesn't perform any validation, use Validate for that.
+// Coordinates which are not reduced modulo p are accepted, use ImportStrict
+// to reject them.
 func (pub *PublicKey) Import(input []byte) error {
 	if len(input) != pub.Size() {
 		return errors.New("sidh: input to short")
@@ -99,6 +195,36 @@ func (pub *PublicKey) Import(input []byte) error {
 	op.Fp2FromBytes(&pub.affine_xP, input[0:ssSz])
 	op.Fp2FromBytes(&pub.affine_xQ, input[ssSz:2*ssSz])
 	op.Fp2FromBytes(&pub.affine_xQmP, input[2*ssSz:3*ssSz])
+	// Export always produces coordinates reduced modulo p
+	pub.nonCanonical = !bytes.Equal(pub.Export(), input)
+	return nil
+}
+
+// ImportStrict works as Import, but accepts only canonical encoding of the
+// key: input must have exactly the size of the key and each coordinate must
+// be reduced modulo p. In the latter case *CoordinateError is returned. The
+// key is not modified in case of error.
+func (pub *PublicKey) ImportStrict(input []byte) error {
+	if len(input) < pub.Size() {
+		return errors.New("sidh: input to short")
+	}
+	if len(input) > pub.Size() {
+		return errors.New("sidh: trailing data after public key")
+	}
+	var xs [3]Fp2Element
+	op := CurveOperations{Params: pub.params}
+	ssSz := pub.params.SharedSecretSize
+	enc := make([]byte, ssSz)
+	for i := range xs {
+		in := input[i*ssSz : (i+1)*ssSz]
+		op.Fp2FromBytes(&xs[i], in)
+		op.Fp2ToBytes(enc, &xs[i])
+		if !bytes.Equal(enc, in) {
+			return &CoordinateError{Coordinate: coordinateNames[i]}
+		}
+	}
+	pub.affine_xP, pub.affine_xQ, pub.affine_xQmP = xs[0], xs[1], xs[2]
+	pub.nonCanonical = false
 	return nil
 }
 
@@ -119,6 +245,45 @@ func (pub *PublicKey) Size() int {
 	return pub.params.PublicKeySize
 }
 
+// Validate checks if the public key is well formed, which is needed when
+// the key is static or comes from an untrusted source. It checks that
+// coordinates are encoded canonically, that the curve recovered from the key
+// is nonsingular and supersingular and that points P and Q have order l^e and
+// generate E[l^e] (l^e is 3^e3 for KeyVariant_SIDH_A and 2^e2 otherwise).
+// Returns nil on success or one of ValidationError constants.
+//
+// Not constant time.
+func (pub *PublicKey) Validate() error {
+	var curve ProjectiveCurveParameters
+	var op = CurveOperations{Params: pub.params}
+
+	if pub.nonCanonical {
+		return ErrNonCanonical
+	}
+
+	op.RecoverCoordinateA(&curve, &pub.affine_xP, &pub.affine_xQ, &pub.affine_xQmP)
+	if op.IsSingular(&curve.A) {
+		return ErrSingularCurve
+	}
+	if !op.IsSupersingular(&curve.A) {
+		return ErrNotSupersingular
+	}
+
+	ell, e := pub.torsion()
+	TP, okP := op.HasTorsionOrder(&curve.A, &pub.affine_xP, ell, e)
+	TQ, okQ := op.HasTorsionOrder(&curve.A, &pub.affine_xQ, ell, e)
+	_, okQmP := op.HasTorsionOrder(&curve.A, &pub.affine_xQmP, ell, e)
+	if !okP || !okQ || !okQmP {
+		return ErrWrongOrder
+	}
+	// P and Q generate E[l^e] iff [l^(e-1)]P and [l^(e-1)]Q generate
+	// different subgroups of order l
+	if op.IsEqualX(&TP, &TQ) {
+		return ErrDependentPoints
+	}
+	return nil
+}
+
 // Exports currently stored key. In case structure hasn't been filled with key data
 // returned byte string is filled with zeros.
 func (prv *PrivateKey) Export() []byte {
@@ -150,6 +315,21 @@ func (prv *PrivateKey) Import(input []byte) error {
 	return nil
 }
 
+// Zeroize overwrites the private key with zeros. The key remains usable,
+// e.g. with Import or Generate.
+func (prv *PrivateKey) Zeroize() {
+	Zeroize(prv.Scalar)
+	Zeroize(prv.S)
+}
+
+// Destroy zeroizes the private key and releases its buffers. The key must not
+// be used afterwards.
+func (prv *PrivateKey) Destroy() {
+	prv.Zeroize()
+	prv.Scalar = nil
+	prv.S = nil
+}
+
 // Generates random private key for SIDH or SIKE. Generated value is
 // formed as little-endian integer from key-space <2^(e2-1)..2^e2 - 1>
 // for KeyVariant_A or <2^(s-1)..2^s - 1>, where s = floor(log_2(3^e3)),
@@ -180,15 +360,47 @@ func (prv *PrivateKey) Generate(rand io.Reader) error {
 	if err != nil {
 		return err
 	}
-	prv.Scalar[len(prv.Scalar)-1] &= (1 << (dp.SecretBitLen % 8)) - 1
-	// Make sure scalar is SecretBitLen long. SIKE spec says that key
+	if dp.SecretBitLen%8 != 0 {
+		prv.Scalar[len(prv.Scalar)-1] &= (1 << (dp.SecretBitLen % 8)) - 1
+	}
+	// Make sure SIDH scalar is SecretBitLen long. SIKE spec says that key
 	// space starts from 0, but I'm not confortable with having low
 	// value scalars used for private keys. It is still secrure as per
-	// table 5.1 in [SIKE].
-	prv.Scalar[len(prv.Scalar)-1] |= 1 << ((dp.SecretBitLen % 8) - 1)
+	// table 5.1 in [SIKE]. SIKE keys are generated exactly as specified,
+	// so that output is the same as of reference implementation for the
+	// same random input (see known answer tests).
+	if prv.keyVariant != KeyVariant_SIKE {
+		prv.Scalar[len(prv.Scalar)-1] |= 1 << ((dp.SecretBitLen - 1) % 8)
+	}
 	return err
 }
 
+// DeriveKeyPair deterministically derives key pair of variant v for parameter
+// set id from a seed of DeriveSeedSize bytes, so that only the seed needs to
+// be stored. Seed is expanded with
+//
+//	cSHAKE256(seed, N = "", S = params.Name || " " || variant || " DeriveKeyPair")
+//
+// where variant is "SIDH-A", "SIDH-B" or "SIKE" (e.g. "SIKEp503 SIKE
+// DeriveKeyPair") and the output is consumed by Generate: in case of SIKE
+// first MsgLen bytes form S, following SecretByteLen bytes form the scalar,
+// with bits above SecretBitLen cleared (and the top bit set for SIDH).
+func DeriveKeyPair(id uint8, v KeyVariant, seed []byte) (*PrivateKey, *PublicKey, error) {
+	if len(seed) != DeriveSeedSize {
+		return nil, nil, errors.New("sidh: seed has wrong size")
+	}
+	prv, err := CreatePrivateKey(id, v)
+	if err != nil {
+		return nil, nil, err
+	}
+	h := cshake.NewCShake256(nil, []byte(prv.params.Name+" "+variantNames[v]+" DeriveKeyPair"))
+	h.Write(seed)
+	if err = prv.Generate(h); err != nil {
+		return nil, nil, err
+	}
+	return prv, prv.GeneratePublicKey(), nil
+}
+
 // Generates public key.
 //
 // Constant time.
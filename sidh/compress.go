@@ -0,0 +1,230 @@
+package sidh
+
+import (
+	"errors"
+	"math/big"
+
+	. "github.com/cloudflare/sidh/internal/isogeny"
+)
+
+// -----------------------------------------------------------------------------
+// Public key compression
+//
+// Public key of type A consists of x-coordinates of points phi_A(PB),
+// phi_A(QB) and phi_A(PB-QB), which generate E_A[3^e3] (similarily public
+// key of type B consists of points generating E_B[2^e2]). Compression uses
+// the fact, that the curve E_A can be recovered from those points and that
+// the points can be expressed in terms of deterministically generated basis
+// {R1, R2} of E_A[l^e]:
+//
+//   phi_A(PB) = [a0]R1 + [b0]R2
+//   phi_A(QB) = [a1]R1 + [b1]R2
+//
+// Scalars are computed with Pohlig-Hellman algorithm applied to the Weil
+// pairings of the points. Other party uses the public key only to compute
+// kernel <phi_A(PB) + [k]phi_A(QB)>, which doesn't change when both points
+// are multiplied by the same invertible scalar. Hence, either a0 or b0
+// (whichever is invertible) is normalized to 1 and only 3 scalars are stored.
+//
+// Compressed public key is encoded as:
+//
+//   A || s0 || s1 || s2 || bit
+//
+// where A is the coefficient of the curve, s0, s1, s2 are scalars modulo l^e
+// encoded in little-endian format and bit is a single byte indicating if
+// a0 (bit=0) or b0 (bit=1) has been normalized to 1. In the first case
+// (s0, s1, s2) = (b0/a0, a1/a0, b1/a0), otherwise (s0, s1, s2) = (a0/b0,
+// a1/b0, b1/b0).
+//
+// This encoding is specific to this package. It isn't compatible with
+// SIKE-compressed from the SIKE submission, which uses different bases and
+// encoding of the scalars.
+//
+// Compression and decompression work only with public data and they are not
+// constant time.
+
+// Returns l and e such that points of the public key generate E[l^e].
+func (pub *PublicKey) torsion() (ell, e uint) {
+	var op = CurveOperations{Params: pub.params}
+	e2, e3 := op.TorsionExponents()
+	if (pub.keyVariant & KeyVariant_SIDH_A) == KeyVariant_SIDH_A {
+		return 3, e3
+	}
+	return 2, e2
+}
+
+// Returns size of a scalar modulo l^e, in bytes
+func torsionScalarSize(ell, e uint) int {
+	n := new(big.Int).Exp(big.NewInt(int64(ell)), big.NewInt(int64(e)), nil)
+	return (n.BitLen() + 7) / 8
+}
+
+// Encodes x as little-endian integer on len(out) bytes.
+func scalarToBytes(out []byte, x *big.Int) {
+	b := x.Bytes()
+	for i := range out {
+		out[i] = 0
+	}
+	for i := range b {
+		out[i] = b[len(b)-1-i]
+	}
+}
+
+// Decodes little-endian integer
+func scalarFromBytes(in []byte) *big.Int {
+	b := make([]byte, len(in))
+	for i := range in {
+		b[i] = in[len(in)-1-i]
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// CompressedSize returns size of the compressed public key in bytes
+func (pub *PublicKey) CompressedSize() int {
+	return 2*pub.params.Bytelen + 3*torsionScalarSize(pub.torsion()) + 1
+}
+
+// ExportCompressed returns compressed encoding of the public key. Function
+// returns error in case public key is malformed and can't be compressed.
+//
+// Not constant time.
+func (pub *PublicKey) ExportCompressed() ([]byte, error) {
+	var curve ProjectiveCurveParameters
+	var op = CurveOperations{Params: pub.params}
+	var A = &curve.A
+
+	ell, e := pub.torsion()
+	n := new(big.Int).Exp(big.NewInt(int64(ell)), big.NewInt(int64(e)), nil)
+	bigEll := big.NewInt(int64(ell))
+
+	// Recover curve and full points P, Q such that x(P-Q) = xQmP
+	op.RecoverCoordinateA(&curve, &pub.affine_xP, &pub.affine_xQ, &pub.affine_xQmP)
+	P, okP := op.LiftPoint(A, &pub.affine_xP)
+	Q, okQ := op.LiftPoint(A, &pub.affine_xQ)
+	if !okP || !okQ {
+		return nil, errors.New("sidh: invalid public key")
+	}
+	negQ := op.Neg(&Q)
+	PmQ := op.Add(A, &P, &negQ)
+	if op.IsInfinity(&PmQ) {
+		return nil, errors.New("sidh: invalid public key")
+	}
+	op.Normalize(&PmQ)
+	if !op.Fp2IsEqual(&PmQ.X, &pub.affine_xQmP) {
+		Q = negQ
+	}
+
+	// Express P and Q in terms of the basis {R1, R2}
+	R1, R2, w, ok := op.TorsionBasis(A, ell, e)
+	if !ok {
+		return nil, errors.New("sidh: invalid public key")
+	}
+	// e(P, R2) = w^a0 and e(P, R1) = w^(-b0), similarly for Q
+	var s [4]*big.Int
+	pairings := op.WeilPairings(A, []CurvePoint{P, Q}, []CurvePoint{R1, R2}, n)
+	for i := range pairings {
+		a, okA := op.Dlog(&pairings[i][1], &w, ell, e)
+		b, okB := op.Dlog(&pairings[i][0], &w, ell, e)
+		if !okA || !okB {
+			return nil, errors.New("sidh: invalid public key")
+		}
+		s[2*i] = a
+		s[2*i+1] = b.Mod(b.Neg(b), n)
+	}
+
+	// Normalize a0 or b0 to 1
+	var bit byte
+	var inv = new(big.Int)
+	if new(big.Int).Mod(s[0], bigEll).Sign() != 0 {
+		inv.ModInverse(s[0], n)
+		s[0] = s[1]
+	} else {
+		inv.ModInverse(s[1], n)
+		bit = 1
+	}
+	if inv.Sign() == 0 {
+		return nil, errors.New("sidh: invalid public key")
+	}
+
+	output := make([]byte, pub.CompressedSize())
+	scalarLen := torsionScalarSize(ell, e)
+	fp2Len := 2 * pub.params.Bytelen
+	op.Fp2ToBytes(output[:fp2Len], A)
+	for i, x := range []*big.Int{s[0], s[2], s[3]} {
+		x.Mul(x, inv)
+		x.Mod(x, n)
+		scalarToBytes(output[fp2Len+i*scalarLen:fp2Len+(i+1)*scalarLen], x)
+	}
+	output[len(output)-1] = bit
+	return output, nil
+}
+
+// ImportCompressed clears content of the public key currently stored in the
+// structure and imports compressed key stored in the byte string. Returns
+// error in case byte string size is wrong or it doesn't encode a valid
+// compressed key.
+//
+// Not constant time.
+func (pub *PublicKey) ImportCompressed(input []byte) error {
+	var A Fp2Element
+	var op = CurveOperations{Params: pub.params}
+
+	if len(input) != pub.CompressedSize() {
+		return errors.New("sidh: input to short")
+	}
+
+	ell, e := pub.torsion()
+	n := new(big.Int).Exp(big.NewInt(int64(ell)), big.NewInt(int64(e)), nil)
+	scalarLen := torsionScalarSize(ell, e)
+	fp2Len := 2 * pub.params.Bytelen
+
+	op.Fp2FromBytes(&A, input[:fp2Len])
+	var s [3]*big.Int
+	for i := range s {
+		s[i] = scalarFromBytes(input[fp2Len+i*scalarLen : fp2Len+(i+1)*scalarLen])
+		if s[i].Cmp(n) >= 0 {
+			return errors.New("sidh: invalid compressed public key")
+		}
+	}
+	bit := input[len(input)-1]
+	if bit > 1 {
+		return errors.New("sidh: invalid compressed public key")
+	}
+
+	if op.IsSingular(&A) {
+		return errors.New("sidh: invalid compressed public key")
+	}
+
+	R1, R2, _, ok := op.TorsionBasis(&A, ell, e)
+	if !ok {
+		return errors.New("sidh: invalid compressed public key")
+	}
+
+	// P = R1 + [s0]R2 or P = [s0]R1 + R2
+	var P CurvePoint
+	if bit == 0 {
+		P = op.ScalarMul(&A, &R2, s[0])
+		P = op.Add(&A, &P, &R1)
+	} else {
+		P = op.ScalarMul(&A, &R1, s[0])
+		P = op.Add(&A, &P, &R2)
+	}
+	// Q = [s1]R1 + [s2]R2
+	Q1 := op.ScalarMul(&A, &R1, s[1])
+	Q2 := op.ScalarMul(&A, &R2, s[2])
+	Q := op.Add(&A, &Q1, &Q2)
+	negQ := op.Neg(&Q)
+	PmQ := op.Add(&A, &P, &negQ)
+	if op.IsInfinity(&P) || op.IsInfinity(&Q) || op.IsInfinity(&PmQ) {
+		return errors.New("sidh: invalid compressed public key")
+	}
+
+	op.Normalize(&P)
+	op.Normalize(&Q)
+	op.Normalize(&PmQ)
+	pub.affine_xP = P.X
+	pub.affine_xQ = Q.X
+	pub.affine_xQmP = PmQ.X
+	pub.nonCanonical = false
+	return nil
+}
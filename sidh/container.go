@@ -0,0 +1,132 @@
+package sidh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// Keys implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler.
+// They are encoded in a container, which identifies parameter set and
+// variant of the key (integers are big-endian):
+//
+//	magic     4 bytes  "SIDH"
+//	version   1 byte   0x01
+//	type      1 byte   0x01 for public key, 0x02 for private key
+//	flags     1 byte   bit 0 is set if checksum is present
+//	params    1 byte   SidhParams.Id
+//	variant   1 byte   KeyVariant
+//	length    2 bytes  length of the payload
+//	payload            key encoded by Export
+//	checksum  4 bytes  CRC-32 (IEEE) of all preceding bytes, optional
+//
+// MarshalBinary always adds the checksum. Checksum only detects accidental
+// corruption, it doesn't provide integrity against an adversary.
+const (
+	containerVersion    = 0x01
+	containerPublicKey  = 0x01
+	containerPrivateKey = 0x02
+	containerChecksum   = 0x01
+	containerHeaderSize = 11
+)
+
+var containerMagic = []byte("SIDH")
+
+// Returns container with given payload
+func marshalContainer(typ byte, k *key, payload []byte) []byte {
+	out := make([]byte, containerHeaderSize, containerHeaderSize+len(payload)+crc32.Size)
+	copy(out, containerMagic)
+	out[4] = containerVersion
+	out[5] = typ
+	out[6] = containerChecksum
+	out[7] = k.params.Id
+	out[8] = byte(k.keyVariant)
+	binary.BigEndian.PutUint16(out[9:], uint16(len(payload)))
+	out = append(out, payload...)
+	var sum [crc32.Size]byte
+	binary.BigEndian.PutUint32(sum[:], crc32.ChecksumIEEE(out))
+	return append(out, sum[:]...)
+}
+
+// Parses container of given type. Returns parameter set, variant and payload.
+func unmarshalContainer(typ byte, data []byte) (uint8, KeyVariant, []byte, error) {
+	if len(data) < containerHeaderSize || !bytes.Equal(data[:4], containerMagic) {
+		return 0, 0, nil, errors.New("sidh: not a key container")
+	}
+	if data[4] != containerVersion {
+		return 0, 0, nil, errors.New("sidh: unsupported version of key container")
+	}
+	if data[5] != typ {
+		return 0, 0, nil, errors.New("sidh: unexpected type of key in container")
+	}
+	flags := data[6]
+	if flags&^containerChecksum != 0 {
+		return 0, 0, nil, errors.New("sidh: unknown flags in key container")
+	}
+
+	n := containerHeaderSize + int(binary.BigEndian.Uint16(data[9:]))
+	if flags&containerChecksum != 0 {
+		if len(data) != n+crc32.Size {
+			return 0, 0, nil, errors.New("sidh: wrong length of key container")
+		}
+		if crc32.ChecksumIEEE(data[:n]) != binary.BigEndian.Uint32(data[n:]) {
+			return 0, 0, nil, errors.New("sidh: wrong checksum of key container")
+		}
+	} else if len(data) != n {
+		return 0, 0, nil, errors.New("sidh: wrong length of key container")
+	}
+	return data[7], KeyVariant(data[8]), data[containerHeaderSize:n], nil
+}
+
+// MarshalBinary returns the key in a container, see above
+func (pub *PublicKey) MarshalBinary() ([]byte, error) {
+	return marshalContainer(containerPublicKey, &pub.key, pub.Export()), nil
+}
+
+// UnmarshalBinary decodes key produced by MarshalBinary. Parameter set and
+// variant of pub are set to these from the container. Returns error in case
+// container is malformed or the key isn't encoded canonically (see
+// ImportStrict), the key is not modified then.
+func (pub *PublicKey) UnmarshalBinary(data []byte) error {
+	id, v, payload, err := unmarshalContainer(containerPublicKey, data)
+	if err != nil {
+		return err
+	}
+	key, err := CreatePublicKey(id, v)
+	if err != nil {
+		return err
+	}
+	if err = key.ImportStrict(payload); err != nil {
+		return err
+	}
+	*pub = *key
+	return nil
+}
+
+// MarshalBinary returns the key in a container, see above
+func (prv *PrivateKey) MarshalBinary() ([]byte, error) {
+	return marshalContainer(containerPrivateKey, &prv.key, prv.Export()), nil
+}
+
+// UnmarshalBinary decodes key produced by MarshalBinary. Parameter set and
+// variant of prv are set to these from the container, previous content of the
+// key is zeroized. Returns error in case container is malformed, the key is
+// not modified then.
+func (prv *PrivateKey) UnmarshalBinary(data []byte) error {
+	id, v, payload, err := unmarshalContainer(containerPrivateKey, data)
+	if err != nil {
+		return err
+	}
+	key, err := CreatePrivateKey(id, v)
+	if err != nil {
+		return err
+	}
+	if err = key.Import(payload); err != nil {
+		return err
+	}
+	// Previous key is not referenced anymore
+	prv.Zeroize()
+	*prv = *key
+	return nil
+}
@@ -0,0 +1,178 @@
+package sidh
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	. "github.com/cloudflare/sidh/internal/isogeny"
+)
+
+// Keys implement json.Marshaler and json.Unmarshaler. They are represented
+// as JSON objects similar to JWK (RFC 7517) with following members:
+//
+//	kty   key variant: "SIDH-A", "SIDH-B" or "SIKE"
+//	alg   name of the parameter set, e.g. "SIKEp503"
+//	xP    x-coordinate of P, public key only
+//	xQ    x-coordinate of Q, public key only
+//	xQmP  x-coordinate of Q-P, public key only
+//	d     secret scalar, private key only
+//	s     random value S, private SIKE key only
+//
+// Binary members are base64url encoded without padding, coordinates are
+// encoded as by PublicKey.Export. Decoding is strict: unknown members,
+// members of the other kind of key, values of wrong size and coordinates not
+// reduced modulo p are rejected.
+type jsonPublicKey struct {
+	Kty  string `json:"kty"`
+	Alg  string `json:"alg"`
+	XP   string `json:"xP"`
+	XQ   string `json:"xQ"`
+	XQmP string `json:"xQmP"`
+}
+
+type jsonPrivateKey struct {
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	D   string `json:"d"`
+	S   string `json:"s,omitempty"`
+}
+
+var b64 = base64.RawURLEncoding.Strict()
+
+// Decodes JSON object in data into v, rejecting unknown members and
+// trailing data
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("sidh: trailing data after JSON key")
+	}
+	return nil
+}
+
+// Returns parameter set and key variant named by kty and alg members
+func lookupJSONKey(kty, alg string) (uint8, KeyVariant, error) {
+	var v KeyVariant
+	for variant, name := range variantNames {
+		if name == kty {
+			v = variant
+		}
+	}
+	if v == 0 {
+		return 0, 0, errors.New("sidh: unknown JSON key type " + kty)
+	}
+	for _, p := range SupportedParams() {
+		if p.Name == alg {
+			return p.Id, v, nil
+		}
+	}
+	return 0, 0, errors.New("sidh: unknown JSON key algorithm " + alg)
+}
+
+// Decodes base64url encoded member of given name, which must be size bytes long
+func decodeJSONMember(name, value string, size int) ([]byte, error) {
+	b, err := b64.DecodeString(value)
+	if err != nil || len(b) != size {
+		return nil, errors.New("sidh: invalid JSON key member " + name)
+	}
+	return b, nil
+}
+
+// MarshalJSON returns JSON representation of the key, see above
+func (pub *PublicKey) MarshalJSON() ([]byte, error) {
+	enc := pub.Export()
+	ssSz := pub.params.SharedSecretSize
+	return json.Marshal(jsonPublicKey{
+		Kty:  variantNames[pub.keyVariant],
+		Alg:  pub.params.Name,
+		XP:   b64.EncodeToString(enc[0:ssSz]),
+		XQ:   b64.EncodeToString(enc[ssSz : 2*ssSz]),
+		XQmP: b64.EncodeToString(enc[2*ssSz : 3*ssSz]),
+	})
+}
+
+// UnmarshalJSON decodes key produced by MarshalJSON. Parameter set and
+// variant of pub are set to these named by the key. Returns error in case
+// JSON is malformed, the key is not modified then.
+func (pub *PublicKey) UnmarshalJSON(data []byte) error {
+	var jwk jsonPublicKey
+	if err := decodeJSON(data, &jwk); err != nil {
+		return err
+	}
+	id, v, err := lookupJSONKey(jwk.Kty, jwk.Alg)
+	if err != nil {
+		return err
+	}
+	key := NewPublicKey(id, v)
+	var enc []byte
+	for _, m := range []struct{ name, value string }{
+		{"xP", jwk.XP}, {"xQ", jwk.XQ}, {"xQmP", jwk.XQmP},
+	} {
+		b, err := decodeJSONMember(m.name, m.value, key.params.SharedSecretSize)
+		if err != nil {
+			return err
+		}
+		enc = append(enc, b...)
+	}
+	if err = key.ImportStrict(enc); err != nil {
+		return err
+	}
+	*pub = *key
+	return nil
+}
+
+// MarshalJSON returns JSON representation of the key, see above
+func (prv *PrivateKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPrivateKey{
+		Kty: variantNames[prv.keyVariant],
+		Alg: prv.params.Name,
+		D:   b64.EncodeToString(prv.Scalar),
+		S:   b64.EncodeToString(prv.S),
+	})
+}
+
+// UnmarshalJSON decodes key produced by MarshalJSON. Parameter set and
+// variant of prv are set to these named by the key, previous content of the
+// key is zeroized. Returns error in case JSON is malformed or d is out of the
+// key space of Generate (it has bits set above SecretBitLen), the key is not
+// modified then.
+func (prv *PrivateKey) UnmarshalJSON(data []byte) error {
+	var jwk jsonPrivateKey
+	if err := decodeJSON(data, &jwk); err != nil {
+		return err
+	}
+	id, v, err := lookupJSONKey(jwk.Kty, jwk.Alg)
+	if err != nil {
+		return err
+	}
+	key := NewPrivateKey(id, v)
+	d, err := decodeJSONMember("d", jwk.D, len(key.Scalar))
+	if err != nil {
+		return err
+	}
+	dp := &key.params.B
+	if (v & KeyVariant_SIDH_A) == KeyVariant_SIDH_A {
+		dp = &key.params.A
+	}
+	if bits := dp.SecretBitLen % 8; bits != 0 && d[len(d)-1]>>bits != 0 {
+		Zeroize(d)
+		return errors.New("sidh: JSON key member d out of range")
+	}
+	s, err := decodeJSONMember("s", jwk.S, len(key.S))
+	if err != nil {
+		return err
+	}
+	copy(key.Scalar, d)
+	copy(key.S, s)
+	Zeroize(d)
+	Zeroize(s)
+	// Previous key is not referenced anymore
+	prv.Zeroize()
+	*prv = *key
+	return nil
+}
@@ -1,26 +1,250 @@
 package sidh
 
 import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
 	. "github.com/cloudflare/sidh/internal/isogeny"
+	p434 "github.com/cloudflare/sidh/p434"
 	p503 "github.com/cloudflare/sidh/p503"
+	p610 "github.com/cloudflare/sidh/p610"
 	p751 "github.com/cloudflare/sidh/p751"
+	p964 "github.com/cloudflare/sidh/p964"
 )
 
 // Keeps mapping: SIDH prime field ID to domain parameters
 var sidhParams = make(map[uint8]SidhParams)
 
+// Guards sidhParams, which can be modified by RegisterParams
+var sidhParamsMu sync.RWMutex
+
+// Returned by LookupParams in case parameter set ID is not registered
+var ErrUnsupportedParams = errors.New("sidh: SIDH Params ID unregistered")
+
+// Summary of a registered parameter set, as returned by SupportedParams.
+type ParamsInfo struct {
+	// ID of the parameter set
+	Id uint8
+	// Name of the parameter set, e.g. "SIKEp434"
+	Name string
+	// NIST security category
+	SecurityLevel int
+	// Size of SIDH/SIKE public key
+	PublicKeySize int
+	// Size of SIDH shared secret
+	SharedSecretSize int
+	// Size of SIKE private key in NIST format: random value S, secret
+	// scalar and public key (see sike.SIKEPrivateKey)
+	SikePrivateKeySize int
+	// Size of SIKE ciphertext
+	SikeCiphertextSize int
+	// Size of SIKE shared secret
+	SikeSharedSecretSize int
+}
+
+// LookupParams returns domain parameters corresponding to finite field and
+// identified by `id` provided by the caller. Returns ErrUnsupportedParams in
+// case `id` wasn't registered earlier.
+func LookupParams(id uint8) (*SidhParams, error) {
+	sidhParamsMu.RLock()
+	defer sidhParamsMu.RUnlock()
+	if val, ok := sidhParams[id]; ok {
+		return &val, nil
+	}
+	return nil, ErrUnsupportedParams
+}
+
 // Params returns domain parameters corresponding to finite field and identified by
 // `id` provieded by the caller. Function panics in case `id` wasn't registered earlier.
+// Use LookupParams in case `id` comes from untrusted source.
 func Params(id uint8) *SidhParams {
-	if val, ok := sidhParams[id]; ok {
-		return &val
+	params, err := LookupParams(id)
+	if err != nil {
+		panic(err.Error())
+	}
+	return params
+}
+
+// SupportedParams returns summary of all registered parameter sets, ordered
+// by size of the public key.
+func SupportedParams() []ParamsInfo {
+	var ret []ParamsInfo
+	sidhParamsMu.RLock()
+	for _, p := range sidhParams {
+		ret = append(ret, ParamsInfo{
+			Id:                   p.Id,
+			Name:                 p.Name,
+			SecurityLevel:        p.SecurityLevel,
+			PublicKeySize:        p.PublicKeySize,
+			SharedSecretSize:     p.SharedSecretSize,
+			SikePrivateKeySize:   p.MsgLen + int(p.B.SecretByteLen) + p.PublicKeySize,
+			SikeCiphertextSize:   p.PublicKeySize + p.MsgLen,
+			SikeSharedSecretSize: p.KemSize,
+		})
+	}
+	sidhParamsMu.RUnlock()
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].PublicKeySize != ret[j].PublicKeySize {
+			return ret[i].PublicKeySize < ret[j].PublicKeySize
+		}
+		return ret[i].Id < ret[j].Id
+	})
+	return ret
+}
+
+// RegisterParams makes a custom parameter set available to sidh and sike
+// packages under the ID params.Id. Function checks consistency of the
+// parameters: sizes must correspond to Bytelen, p must have a form
+// 2^e2*3^e3 - 1, lengths of isogeny strategies and secret keys must correspond
+// to e2 and e3, and base points must generate torsion subgroups of the
+// starting curve. Returns error in case a check fails or ID is already taken.
+func RegisterParams(params SidhParams) error {
+	if err := checkParams(&params); err != nil {
+		return err
+	}
+
+	sidhParamsMu.Lock()
+	defer sidhParamsMu.Unlock()
+	if _, ok := sidhParams[params.Id]; ok {
+		return errors.New("sidh: params ID already registered")
+	}
+	sidhParams[params.Id] = params
+	return nil
+}
+
+// Checks consistency of the domain parameters
+func checkParams(params *SidhParams) error {
+	var curve ProjectiveCurveParameters
+	var A, t Fp2Element
+
+	if params.Op == nil {
+		return errors.New("sidh: field operations not provided")
+	}
+	if params.Bytelen <= 0 || params.Bytelen > 8*FP_MAX_WORDS {
+		return errors.New("sidh: invalid Bytelen")
+	}
+	if params.SharedSecretSize != 2*params.Bytelen || params.PublicKeySize != 6*params.Bytelen {
+		return errors.New("sidh: sizes don't match Bytelen")
+	}
+	if params.MsgLen != 16 && params.MsgLen != 24 && params.MsgLen != 32 && params.MsgLen != 40 {
+		return errors.New("sidh: invalid MsgLen")
+	}
+	if params.KemSize <= 0 || params.KemSize > 64 {
+		return errors.New("sidh: invalid KemSize")
+	}
+	if params.SikeRound != SIKE_ROUND1 && params.SikeRound != SIKE_ROUND3 {
+		return errors.New("sidh: invalid SikeRound")
+	}
+
+	op := CurveOperations{Params: params}
+	params.Op.Mul(&t, &params.OneFp2, &params.OneFp2)
+	if !op.Fp2IsEqual(&t, &params.OneFp2) || op.Fp2IsEqual(&t, &A) {
+		return errors.New("sidh: invalid OneFp2")
+	}
+	params.Op.Add(&t, &params.HalfFp2, &params.HalfFp2)
+	if !op.Fp2IsEqual(&t, &params.OneFp2) {
+		return errors.New("sidh: invalid HalfFp2")
+	}
+
+	// p = 2^e2*3^e3 - 1
+	p := op.FieldPrime()
+	if (p.BitLen()+7)/8 != params.Bytelen || !p.ProbablyPrime(20) {
+		return errors.New("sidh: Bytelen doesn't match field prime")
+	}
+	e2, e3 := op.TorsionExponents()
+	n := new(big.Int).Exp(big.NewInt(3), big.NewInt(int64(e3)), nil)
+	n.Lsh(n, e2)
+	n.Sub(n, big.NewInt(1))
+	if e2 < 2 || e3 < 2 || n.Cmp(p) != 0 {
+		return errors.New("sidh: field prime is not of the form 2^e2*3^e3 - 1")
+	}
+
+	// Lengths of strategies and secret keys
+	bitLenB := new(big.Int).Exp(big.NewInt(3), big.NewInt(int64(e3)), nil).BitLen() - 1
+	if params.A.SecretBitLen != e2 || params.B.SecretBitLen != uint(bitLenB) {
+		return errors.New("sidh: SecretBitLen doesn't match e2 or e3")
+	}
+	if params.A.SecretByteLen != (params.A.SecretBitLen+7)/8 ||
+		params.B.SecretByteLen != (params.B.SecretBitLen+7)/8 {
+		return errors.New("sidh: SecretByteLen doesn't match SecretBitLen")
 	}
-	panic("sidh: SIDH Params ID unregistered")
+	if len(params.A.IsogenyStrategy) != int(e2/2-1) || len(params.B.IsogenyStrategy) != int(e3-1) {
+		return errors.New("sidh: strategy length doesn't match e2 or e3")
+	}
+
+	// Starting curve and base points
+	params.Op.Inv(&A, &params.InitCurve.C)
+	params.Op.Mul(&A, &A, &params.InitCurve.A)
+	if op.IsSingular(&A) || !op.IsSupersingular(&A) {
+		return errors.New("sidh: invalid starting curve")
+	}
+	for _, dp := range []struct {
+		d      *DomainParams
+		ell, e uint
+	}{{&params.A, 2, e2}, {&params.B, 3, e3}} {
+		op.RecoverCoordinateA(&curve, &dp.d.Affine_P, &dp.d.Affine_Q, &dp.d.Affine_R)
+		if !op.Fp2IsEqual(&curve.A, &A) {
+			return errors.New("sidh: base points don't lie on the starting curve")
+		}
+		for _, x := range []*Fp2Element{&dp.d.Affine_P, &dp.d.Affine_Q, &dp.d.Affine_R} {
+			if _, ok := op.LiftPoint(&A, x); !ok {
+				return errors.New("sidh: base points don't lie on the starting curve")
+			}
+		}
+		TP, okP := op.HasTorsionOrder(&A, &dp.d.Affine_P, dp.ell, dp.e)
+		TQ, okQ := op.HasTorsionOrder(&A, &dp.d.Affine_Q, dp.ell, dp.e)
+		_, okR := op.HasTorsionOrder(&A, &dp.d.Affine_R, dp.ell, dp.e)
+		if !okP || !okQ || !okR || op.IsEqualX(&TP, &TQ) {
+			return errors.New("sidh: base points don't generate torsion subgroup")
+		}
+	}
+	return nil
 }
 
 func init() {
+	p434 := SidhParams{
+		Id:               FP_434,
+		Name:             "SIKEp434",
+		SecurityLevel:    1,
+		PublicKeySize:    p434.P434_PublicKeySize,
+		SharedSecretSize: p434.P434_SharedSecretSize,
+		A: DomainParams{
+			Affine_P:        p434.P434_affine_PA,
+			Affine_Q:        p434.P434_affine_QA,
+			Affine_R:        p434.P434_affine_RA,
+			SecretBitLen:    p434.P434_SecretBitLenA,
+			SecretByteLen:   uint((p434.P434_SecretBitLenA + 7) / 8),
+			IsogenyStrategy: p434.P434_AliceIsogenyStrategy[:],
+		},
+		B: DomainParams{
+			Affine_P:        p434.P434_affine_PB,
+			Affine_Q:        p434.P434_affine_QB,
+			Affine_R:        p434.P434_affine_RB,
+			SecretBitLen:    p434.P434_SecretBitLenB,
+			SecretByteLen:   uint((p434.P434_SecretBitLenB + 7) / 8),
+			IsogenyStrategy: p434.P434_BobIsogenyStrategy[:],
+		},
+		// Starting curve E_6: y^2 = x^3 + 6x^2 + x
+		InitCurve: ProjectiveCurveParameters{
+			A: p434.P434_SixFp2,
+			C: p434.P434_OneFp2,
+		},
+		OneFp2:  p434.P434_OneFp2,
+		HalfFp2: p434.P434_HalfFp2,
+		MsgLen:  16,
+		// SIKEp434 provides 128 bit of classical security ([SIKE], 5.1)
+		KemSize:   16,
+		SikeRound: SIKE_ROUND3,
+		Bytelen:   p434.P434_Bytelen,
+		Op:        p434.FieldOperations(),
+	}
+
 	p503 := SidhParams{
 		Id:               FP_503,
+		Name:             "SIKEp503",
+		SecurityLevel:    1,
 		PublicKeySize:    p503.P503_PublicKeySize,
 		SharedSecretSize: p503.P503_SharedSecretSize,
 		A: DomainParams{
@@ -39,17 +263,61 @@ func init() {
 			SecretByteLen:   uint((p503.P503_SecretBitLenB + 7) / 8),
 			IsogenyStrategy: p503.P503_BobIsogenyStrategy[:],
 		},
+		// Starting curve E_0: y^2 = x^3 + x
+		InitCurve: ProjectiveCurveParameters{
+			C: p503.P503_OneFp2,
+		},
 		OneFp2:  p503.P503_OneFp2,
 		HalfFp2: p503.P503_HalfFp2,
 		MsgLen:  24,
 		// SIKEp751 provides 128 bit of classical security ([SIKE], 5.1)
-		KemSize: 16,
-		Bytelen: p503.P503_Bytelen,
-		Op:      p503.FieldOperations(),
+		KemSize:   16,
+		SikeRound: SIKE_ROUND1,
+		Bytelen:   p503.P503_Bytelen,
+		Op:        p503.FieldOperations(),
+	}
+
+	p610 := SidhParams{
+		Id:               FP_610,
+		Name:             "SIKEp610",
+		SecurityLevel:    3,
+		PublicKeySize:    p610.P610_PublicKeySize,
+		SharedSecretSize: p610.P610_SharedSecretSize,
+		A: DomainParams{
+			Affine_P:        p610.P610_affine_PA,
+			Affine_Q:        p610.P610_affine_QA,
+			Affine_R:        p610.P610_affine_RA,
+			SecretBitLen:    p610.P610_SecretBitLenA,
+			SecretByteLen:   uint((p610.P610_SecretBitLenA + 7) / 8),
+			IsogenyStrategy: p610.P610_AliceIsogenyStrategy[:],
+		},
+		B: DomainParams{
+			Affine_P:        p610.P610_affine_PB,
+			Affine_Q:        p610.P610_affine_QB,
+			Affine_R:        p610.P610_affine_RB,
+			SecretBitLen:    p610.P610_SecretBitLenB,
+			SecretByteLen:   uint((p610.P610_SecretBitLenB + 7) / 8),
+			IsogenyStrategy: p610.P610_BobIsogenyStrategy[:],
+		},
+		// Starting curve E_6: y^2 = x^3 + 6x^2 + x
+		InitCurve: ProjectiveCurveParameters{
+			A: p610.P610_SixFp2,
+			C: p610.P610_OneFp2,
+		},
+		OneFp2:  p610.P610_OneFp2,
+		HalfFp2: p610.P610_HalfFp2,
+		MsgLen:  24,
+		// SIKEp610 provides 192 bit of classical security ([SIKE], 5.1)
+		KemSize:   24,
+		SikeRound: SIKE_ROUND3,
+		Bytelen:   p610.P610_Bytelen,
+		Op:        p610.FieldOperations(),
 	}
 
 	p751 := SidhParams{
 		Id:               FP_751,
+		Name:             "SIKEp751",
+		SecurityLevel:    3,
 		PublicKeySize:    p751.P751_PublicKeySize,
 		SharedSecretSize: p751.P751_SharedSecretSize,
 		A: DomainParams{
@@ -68,15 +336,102 @@ func init() {
 			SecretBitLen:    p751.P751_SecretBitLenB,
 			SecretByteLen:   uint((p751.P751_SecretBitLenB + 7) / 8),
 		},
+		// Starting curve E_0: y^2 = x^3 + x
+		InitCurve: ProjectiveCurveParameters{
+			C: p751.P751_OneFp2,
+		},
 		OneFp2:  p751.P751_OneFp2,
 		HalfFp2: p751.P751_HalfFp2,
 		MsgLen:  32,
 		// SIKEp751 provides 192 bit of classical security ([SIKE], 5.1)
-		KemSize: 24,
-		Bytelen: p751.P751_Bytelen,
-		Op:      p751.FieldOperations(),
+		KemSize:   24,
+		SikeRound: SIKE_ROUND1,
+		Bytelen:   p751.P751_Bytelen,
+		Op:        p751.FieldOperations(),
+	}
+
+	p964 := SidhParams{
+		Id:               FP_964,
+		Name:             "SIKEp964",
+		SecurityLevel:    5,
+		PublicKeySize:    p964.P964_PublicKeySize,
+		SharedSecretSize: p964.P964_SharedSecretSize,
+		A: DomainParams{
+			Affine_P:        p964.P964_affine_PA,
+			Affine_Q:        p964.P964_affine_QA,
+			Affine_R:        p964.P964_affine_RA,
+			SecretBitLen:    p964.P964_SecretBitLenA,
+			SecretByteLen:   uint((p964.P964_SecretBitLenA + 7) / 8),
+			IsogenyStrategy: p964.P964_AliceIsogenyStrategy[:],
+		},
+		B: DomainParams{
+			Affine_P:        p964.P964_affine_PB,
+			Affine_Q:        p964.P964_affine_QB,
+			Affine_R:        p964.P964_affine_RB,
+			SecretBitLen:    p964.P964_SecretBitLenB,
+			SecretByteLen:   uint((p964.P964_SecretBitLenB + 7) / 8),
+			IsogenyStrategy: p964.P964_BobIsogenyStrategy[:],
+		},
+		// Starting curve E_6: y^2 = x^3 + 6x^2 + x
+		InitCurve: ProjectiveCurveParameters{
+			A: p964.P964_SixFp2,
+			C: p964.P964_OneFp2,
+		},
+		OneFp2:  p964.P964_OneFp2,
+		HalfFp2: p964.P964_HalfFp2,
+		MsgLen:  32,
+		// SIKEp964 provides 256 bit of classical security
+		KemSize:   32,
+		SikeRound: SIKE_ROUND3,
+		Bytelen:   p964.P964_Bytelen,
+		Op:        p964.FieldOperations(),
 	}
 
+	sidhParams[FP_434] = p434
 	sidhParams[FP_503] = p503
+	sidhParams[FP_610] = p610
 	sidhParams[FP_751] = p751
+	sidhParams[FP_964] = p964
+}
+
+// SIKEp503 and SIKEp751 as updated by round 3 of [SIKE]. Primes and isogeny
+// strategies are unchanged, starting curve is E_6 and base points differ.
+// KEM uses SHAKE256 and produces longer shared secret.
+func init() {
+	p503r3 := sidhParams[FP_503]
+	p503r3.Id = FP_503_R3
+	p503r3.Name = "SIKEp503r3"
+	p503r3.SecurityLevel = 2
+	p503r3.A.Affine_P = p503.P503_R3_affine_PA
+	p503r3.A.Affine_Q = p503.P503_R3_affine_QA
+	p503r3.A.Affine_R = p503.P503_R3_affine_RA
+	p503r3.B.Affine_P = p503.P503_R3_affine_PB
+	p503r3.B.Affine_Q = p503.P503_R3_affine_QB
+	p503r3.B.Affine_R = p503.P503_R3_affine_RB
+	p503r3.InitCurve = ProjectiveCurveParameters{
+		A: p503.P503_SixFp2,
+		C: p503.P503_OneFp2,
+	}
+	p503r3.KemSize = 24
+	p503r3.SikeRound = SIKE_ROUND3
+
+	p751r3 := sidhParams[FP_751]
+	p751r3.Id = FP_751_R3
+	p751r3.Name = "SIKEp751r3"
+	p751r3.SecurityLevel = 5
+	p751r3.A.Affine_P = p751.P751_R3_affine_PA
+	p751r3.A.Affine_Q = p751.P751_R3_affine_QA
+	p751r3.A.Affine_R = p751.P751_R3_affine_RA
+	p751r3.B.Affine_P = p751.P751_R3_affine_PB
+	p751r3.B.Affine_Q = p751.P751_R3_affine_QB
+	p751r3.B.Affine_R = p751.P751_R3_affine_RB
+	p751r3.InitCurve = ProjectiveCurveParameters{
+		A: p751.P751_SixFp2,
+		C: p751.P751_OneFp2,
+	}
+	p751r3.KemSize = 32
+	p751r3.SikeRound = SIKE_ROUND3
+
+	sidhParams[FP_503_R3] = p503r3
+	sidhParams[FP_751_R3] = p751r3
 }
@@ -0,0 +1,238 @@
+package sidh
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+)
+
+// Experimental OIDs of keys are allocated under 1.3.6.1.4.1.44363.46, in the
+// private enterprise arc of Cloudflare. No OIDs are standardized for SIDH nor
+// SIKE. Arcs under 46 are allocated as follows:
+//
+//	46.<variant>.<prime>    keys of the original submission
+//	46.4.<variant>.<prime>  keys of round 3 versions of SIKEp503 and SIKEp751
+//
+// where variant is 1 for KeyVariant_SIDH_A, 2 for KeyVariant_SIDH_B and 3 for
+// KeyVariant_SIKE, and prime is the bit length of the prime. Arc 46.4 is a
+// sibling of the variant arcs, hence no OID is a prefix of another one.
+var oidSIDH = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44363, 46}
+
+// Arc of round 3 versions of parameter sets
+const arcRound3 = 4
+
+// Arcs identifying built-in parameter sets: arcs preceding the variant and
+// the arc following it. Lookup of OIDs follows the order of the table.
+var paramsArcs = []struct {
+	id     uint8
+	prefix []int
+	prime  int
+}{
+	{FP_434, nil, 434},
+	{FP_503, nil, 503},
+	{FP_610, nil, 610},
+	{FP_751, nil, 751},
+	{FP_964, nil, 964},
+	{FP_503_R3, []int{arcRound3}, 503},
+	{FP_751_R3, []int{arcRound3}, 751},
+}
+
+// Arcs identifying key variants
+var variantArcs = []struct {
+	v   KeyVariant
+	arc int
+}{
+	{KeyVariant_SIDH_A, 1},
+	{KeyVariant_SIDH_B, 2},
+	{KeyVariant_SIKE, 3},
+}
+
+// PEM block types used by EncodePEM* and DecodePEM* functions
+const (
+	PEMPublicKey  = "PUBLIC KEY"
+	PEMPrivateKey = "PRIVATE KEY"
+)
+
+// Returned in case OID of an encoded key is not known
+var ErrUnknownOID = errors.New("sidh: unknown key algorithm")
+
+// SubjectPublicKeyInfo, RFC 5280
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// PrivateKeyInfo, RFC 5208
+type privateKeyInfo struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// OID returns object identifier of keys of variant v with parameter set id.
+// Returns error for parameter sets registered with RegisterParams, they have
+// no OIDs.
+func OID(id uint8, v KeyVariant) (asn1.ObjectIdentifier, error) {
+	for _, p := range paramsArcs {
+		if p.id != id {
+			continue
+		}
+		for _, variant := range variantArcs {
+			if variant.v == v {
+				oid := append(asn1.ObjectIdentifier(nil), oidSIDH...)
+				oid = append(oid, p.prefix...)
+				return append(oid, variant.arc, p.prime), nil
+			}
+		}
+	}
+	return nil, errors.New("sidh: no OID for parameter set or key variant")
+}
+
+// Returns parameter set and key variant identified by algorithm identifier.
+// Parameters of the algorithm must be absent.
+func lookupAlgorithm(alg *pkix.AlgorithmIdentifier) (uint8, KeyVariant, error) {
+	if len(alg.Parameters.FullBytes) != 0 {
+		return 0, 0, errors.New("sidh: unexpected algorithm parameters")
+	}
+	for _, p := range paramsArcs {
+		for _, variant := range variantArcs {
+			if oid, _ := OID(p.id, variant.v); oid.Equal(alg.Algorithm) {
+				return p.id, variant.v, nil
+			}
+		}
+	}
+	return 0, 0, ErrUnknownOID
+}
+
+// MarshalPKIXPublicKey returns DER encoding of SubjectPublicKeyInfo carrying
+// pub. Public key is encoded as by PublicKey.Export.
+func MarshalPKIXPublicKey(pub *PublicKey) ([]byte, error) {
+	oid, err := OID(pub.params.Id, pub.keyVariant)
+	if err != nil {
+		return nil, err
+	}
+	data := pub.Export()
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+		PublicKey: asn1.BitString{Bytes: data, BitLength: 8 * len(data)},
+	})
+}
+
+// ParsePKIXPublicKey decodes public key produced by MarshalPKIXPublicKey.
+// Parameter set and variant of the key are determined by its OID. Only
+// canonical encoding of the key is accepted (see PublicKey.ImportStrict).
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sidh: trailing data after public key")
+	}
+	id, v, err := lookupAlgorithm(&spki.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if spki.PublicKey.BitLength != 8*len(spki.PublicKey.Bytes) {
+		return nil, errors.New("sidh: public key is not a whole number of bytes")
+	}
+	pub := NewPublicKey(id, v)
+	if err = pub.ImportStrict(spki.PublicKey.Bytes); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// MarshalPKCS8PrivateKey returns DER encoding of PKCS#8 PrivateKeyInfo
+// carrying prv. Private key is encoded as by PrivateKey.Export, for SIKE it
+// includes the random value S.
+func MarshalPKCS8PrivateKey(prv *PrivateKey) ([]byte, error) {
+	oid, err := OID(prv.params.Id, prv.keyVariant)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(privateKeyInfo{
+		Algorithm:  pkix.AlgorithmIdentifier{Algorithm: oid},
+		PrivateKey: prv.Export(),
+	})
+}
+
+// ParsePKCS8PrivateKey decodes private key produced by
+// MarshalPKCS8PrivateKey. Parameter set and variant of the key are
+// determined by its OID.
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var info privateKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sidh: trailing data after private key")
+	}
+	if info.Version != 0 {
+		return nil, errors.New("sidh: unsupported version of PKCS#8 private key")
+	}
+	id, v, err := lookupAlgorithm(&info.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	prv := NewPrivateKey(id, v)
+	if err = prv.Import(info.PrivateKey); err != nil {
+		return nil, err
+	}
+	return prv, nil
+}
+
+// Returns content of the first PEM block in data, which must be of given type
+func decodePEM(data []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("sidh: no PEM block found")
+	}
+	if block.Type != blockType {
+		return nil, errors.New("sidh: unexpected PEM block type " + block.Type)
+	}
+	return block.Bytes, nil
+}
+
+// EncodePEMPublicKey returns SubjectPublicKeyInfo of pub in a PEM block of
+// type PEMPublicKey
+func EncodePEMPublicKey(pub *PublicKey) ([]byte, error) {
+	der, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEMPublicKey, Bytes: der}), nil
+}
+
+// DecodePEMPublicKey decodes public key from the first PEM block in data,
+// produced by EncodePEMPublicKey
+func DecodePEMPublicKey(data []byte) (*PublicKey, error) {
+	der, err := decodePEM(data, PEMPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePKIXPublicKey(der)
+}
+
+// EncodePEMPrivateKey returns PKCS#8 encoding of prv in a PEM block of type
+// PEMPrivateKey
+func EncodePEMPrivateKey(prv *PrivateKey) ([]byte, error) {
+	der, err := MarshalPKCS8PrivateKey(prv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEMPrivateKey, Bytes: der}), nil
+}
+
+// DecodePEMPrivateKey decodes private key from the first PEM block in data,
+// produced by EncodePEMPrivateKey
+func DecodePEMPrivateKey(data []byte) (*PrivateKey, error) {
+	der, err := decodePEM(data, PEMPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePKCS8PrivateKey(der)
+}
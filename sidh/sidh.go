@@ -8,6 +8,26 @@ import (
 // Functions for traversing isogeny trees acoording to strategy. Key type 'A' is
 //
 
+// In case 2^e2 is an odd power of 2, the 2^e2-isogeny is computed as a
+// single 2-isogeny followed by (e2-1)/2 4-isogenies (see [SIKE], 1.3.5).
+// Function computes 2-isogeny with kernel [2^(e2-1)]xR, updates curve
+// coefficients and evaluates xR and all provided points on the isogeny.
+func isogeny2StepA(curve *ProjectiveCurveParameters, xR *ProjectivePoint, params *SidhParams, points ...*ProjectivePoint) {
+	var op = CurveOperations{Params: params}
+	var phi = Newisogeny2(op.Params.Op)
+	var xS = *xR
+
+	cparam := op.CalcCurveParamsEquiv4(curve)
+	op.Pow2k(&xS, &cparam, uint32(params.A.SecretBitLen-1))
+	cparam = phi.GenerateCurve(&xS)
+	op.RecoverCurveCoefficients4(curve, &cparam)
+
+	*xR = phi.EvaluatePoint(xR)
+	for _, p := range points {
+		*p = phi.EvaluatePoint(p)
+	}
+}
+
 // Traverses isogeny tree in order to compute xR, xP, xQ and xQmP needed
 // for public key generation.
 func traverseTreePublicKeyA(curve *ProjectiveCurveParameters, xR, phiP, phiQ, phiR *ProjectivePoint, pub *PublicKey) {
@@ -178,12 +198,14 @@ func publicKeyGenA(prv *PrivateKey) (pub *PublicKey) {
 	xPB = ProjectivePoint{X: prv.params.B.Affine_P, Z: prv.params.OneFp2}
 
 	// Find isogeny kernel
-	tmp.C = pub.params.OneFp2
+	tmp = pub.params.InitCurve
 	xR = op.ScalarMul3Pt(&tmp, &xPA, &xQA, &xRA, prv.params.A.SecretBitLen, prv.Scalar)
 
 	// Reset params object and travers isogeny tree
-	tmp.C = pub.params.OneFp2
-	tmp.A.Zeroize()
+	tmp = pub.params.InitCurve
+	if pub.params.A.SecretBitLen%2 == 1 {
+		isogeny2StepA(&tmp, &xR, pub.params, &xPB, &xQB, &xRB)
+	}
 	traverseTreePublicKeyA(&tmp, &xR, &xPB, &xQB, &xRB, pub)
 
 	// Secret isogeny
@@ -196,6 +218,9 @@ func publicKeyGenA(prv *PrivateKey) (pub *PublicKey) {
 	op.Params.Op.Mul(&pub.affine_xP, &xPA.X, &invZP)
 	op.Params.Op.Mul(&pub.affine_xQ, &xQA.X, &invZQ)
 	op.Params.Op.Mul(&pub.affine_xQmP, &xRA.X, &invZR)
+
+	// Kernel of the secret isogeny
+	ZeroizeFp2(&xR.X, &xR.Z)
 	return
 }
 
@@ -220,11 +245,10 @@ func publicKeyGenB(prv *PrivateKey) (pub *PublicKey) {
 	xQA = ProjectivePoint{X: prv.params.A.Affine_Q, Z: prv.params.OneFp2}
 	xRA = ProjectivePoint{X: prv.params.A.Affine_R, Z: prv.params.OneFp2}
 
-	tmp.C = pub.params.OneFp2
+	tmp = pub.params.InitCurve
 	xR = op.ScalarMul3Pt(&tmp, &xPB, &xQB, &xRB, prv.params.B.SecretBitLen, prv.Scalar)
 
-	tmp.C = pub.params.OneFp2
-	tmp.A.Zeroize()
+	tmp = pub.params.InitCurve
 	traverseTreePublicKeyB(&tmp, &xR, &xPA, &xQA, &xRA, pub)
 
 	phi.GenerateCurve(&xR)
@@ -236,6 +260,9 @@ func publicKeyGenB(prv *PrivateKey) (pub *PublicKey) {
 	op.Params.Op.Mul(&pub.affine_xP, &xPB.X, &invZP)
 	op.Params.Op.Mul(&pub.affine_xQ, &xQB.X, &invZQ)
 	op.Params.Op.Mul(&pub.affine_xQmP, &xRB.X, &invZR)
+
+	// Kernel of the secret isogeny
+	ZeroizeFp2(&xR.X, &xR.Z)
 	return
 }
 
@@ -263,12 +290,18 @@ func deriveSecretA(prv *PrivateKey, pub *PublicKey) []byte {
 	xR = op.ScalarMul3Pt(&cparam, &xP, &xQ, &xQmP, pub.params.A.SecretBitLen, prv.Scalar)
 
 	// Traverse isogeny tree
+	if pub.params.A.SecretBitLen%2 == 1 {
+		isogeny2StepA(&cparam, &xR, pub.params)
+	}
 	traverseTreeSharedKeyA(&cparam, &xR, pub)
 
 	// Calculate j-invariant on isogeneus curve
 	c := phi.GenerateCurve(&xR)
 	op.RecoverCurveCoefficients4(&cparam, &c)
 	op.Jinvariant(&cparam, sharedSecret)
+
+	// Kernel of the secret isogeny and the shared curve
+	ZeroizeFp2(&xR.X, &xR.Z, &cparam.A, &cparam.C, &c.A, &c.C)
 	return sharedSecret
 }
 
@@ -298,5 +331,8 @@ func deriveSecretB(prv *PrivateKey, pub *PublicKey) []byte {
 	c := phi.GenerateCurve(&xR)
 	op.RecoverCurveCoefficients3(&cparam, &c)
 	op.Jinvariant(&cparam, sharedSecret)
+
+	// Kernel of the secret isogeny and the shared curve
+	ZeroizeFp2(&xR.X, &xR.Z, &cparam.A, &cparam.C, &c.A, &c.C)
 	return sharedSecret
 }
@@ -3,7 +3,11 @@ package sidh
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/asn1"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -21,6 +25,35 @@ var tdata = map[uint8]struct {
 	PkB  string
 	PrB  string
 }{
+	FP_434: {
+		name: "P-434",
+		PrA:  "3A727E04EA9B7E2A766A6F846489E7E7B915263BCEED308BB10FC9",
+		PrB:  "E37BFE55B43B32448F375903D8D226EC94ADBFEA1D2B3536EB987001",
+		PkA: "9E668D1E6750ED4B91EE052C32839CA9DD2E56D52BC24DECC950AAAD" +
+			"24CEED3F9049C77FE80F0B9B01E7F8DAD7833EEC2286544D6380009C" +
+			"379CDD3E7517CEF5E20EB01F8231D52FC30DC61D2F63FB357F85DC63" +
+			"96E8A95DB9740BD3A972C8DB7901B31F074CD3E45345CA78F9008171" +
+			"30E688A29A7CF0073B5C00FF2C65FBE776918EF9BD8E75B29EF7FAB7" +
+			"91969B60B0C5B37A8992EDEF95FA7BAC40A95DAFE02E237301FEE9A7" +
+			"A43FD0B73477E8035DD12B73FAFEF18D39904DDE3653A754F36BE188" +
+			"8F6607C6A7951349A414352CF31A29F2C40302DB406C48018C905EB9" +
+			"DC46AFBF42A9187A9BB9E51B587622A2862DC7D5CC598BF38ED6320F" +
+			"B51D8697AD3D7A72ABCC32A393F0133DA8DF5E253D9E00B760B2DF34" +
+			"2FCE974DCFE946CFE4727783531882800F9E5DD594D6D5A6275EEFEF" +
+			"9713ED838F4A06BB34D7B8D46E0B385AAEA1C7963601",
+		PkB: "C9F73E4497AAA3FDF9EB688135866A8A83934BA10E273B8CC3808CF0" +
+			"C1F5FAB3E9BB295885881B73DEBC875670C0F51C4BB40DF5FEDE01B8" +
+			"AF32D1BF10508B8C17B2734EB93B2B7F5D84A4A0F2F816E9E2C32AC2" +
+			"53C0B6025B124D05A87A9E2A8567930F44BAA14219B941B6B400B4AE" +
+			"D1D796DA12A5A9F0B8F3F5EE9DD43F64CB24A3B1719DF278ADF56B5F" +
+			"3395187829DA2319DEABF6BBD6EDA244DE2B62CC5AC250C1009DD1CD" +
+			"4712B0B37406612AD002B5E51A62B51AC9C0374D143ABBBD58275FAF" +
+			"C4A5E959C54838C2D6D9FB43B7B2609061267B6A2E6C6D01D295C422" +
+			"3E0D3D7A4CDCFB28A7818A737935279751A6DD8290FD498D1F6AD5F4" +
+			"FFF6BDFA536713F509DCE8047252F1E7D0DD9FCC414C0070B5DCCE36" +
+			"65A21A032D7FBE749181032183AFAD240B7E671E87FBBEC3A8CA4C11" +
+			"AA7A9A23AC69AE2ACF54B664DECD27753D63508F1B02",
+	},
 	FP_503: {
 		name: "P-503",
 		PrA:  "B0AD510708F4ABCF3E0D97DC2F2FF112D9D2AAE49D97FFD1E4267F21C6E71C03",
@@ -50,6 +83,45 @@ var tdata = map[uint8]struct {
 			"5EAE1132E582C88E47ED87B363D45F05BEA714D5E9933D7AF4071CBB5D49008F" +
 			"3E3DAD7DFF935EE509D5DE561842B678CCEB133D62E270E9AC3E",
 	},
+	FP_610: {
+		name: "P-610",
+		PrA:  "DC5F198B576B206D750DF73F375A6D9B3191F2828832AF1FFD113C78A5304F513681D5526B7501",
+		PrB:  "AC9A7ABE3EAD4BA27E36ECEF0AFBCCBAFBB49D76B217D5661DCAD864BD5631DA945C86B0179D",
+		PkA: "733EBD78B98CCC8FFB27608BE594C57EFFC11B85726702472E57C898" +
+			"02B5C500190BB160B55AD618130A2504776BAFA8D48FE0D4BD4A9B6B" +
+			"E65A5343E9C1ADD4A5CBA5771B2E25CA3F76D15A00F7F699B3C87EA9" +
+			"AC8194122C6ACB44A75917EDF03B27F5CD1288F423065E5C255A855F" +
+			"442F18526AFDA94F2B8EB24424714E47FB2FCCD980AA34EC7F010A77" +
+			"CE322201AB7D660767B60ADAE701BDD66B6093DFB7504262E52020DA" +
+			"BFADF8C09C261333554829EA3AB4C609AEF946FACCA10BF7B86A7972" +
+			"F81A73B6EF71CFCBFD1620B0048701ADEC43B185E28E67E8ACE2F3DB" +
+			"8FA2C8EF85CC00B849A61B1EC7BB44F1CED45D0082A527049F5573B8" +
+			"1BB3201748C53491604D3CDE0E6EF83655146E07A61DCA0001DEDC9B" +
+			"E5F619A361E7B8F481C76AAE944D727AE8E775E21C72D7BB5E852302" +
+			"CE59AA165CB6790B45272070D06B72CE44FD592AD427AC7AA1A1417E" +
+			"20787E81C5A4422D19E1F37D191E5E4AAF62A970EE06DE15A0291903" +
+			"58E720B4AD04EAA3FE2D8DA197A1C7E316F74E03023841C4DE927B1F" +
+			"20CDEADF657C9AAB53A433D9D3C8607050A22B26E8CD3EF41C2BF4DB" +
+			"BB8FD705352D8ED839974C2B6BEA53E740ADDFC9386E6B25827DD51F" +
+			"62BF50752A0A2A2BE5C30A230F01",
+		PkB: "153146FDC28A0174845CB8396D116C0D43471CBBE752011B857E246C" +
+			"57D329C82DD53727332BE26155E9EDAE232CD399545CB65FC552B3B3" +
+			"C95BBB4C45F3296C01F4D260936C61F8D35212D40158DE95915CD666" +
+			"513B4F538B11A976BFA23BC59112758C475D29E5DA895AE9463F22B2" +
+			"E77B2330CDA7A175A44EB9FD724C57E687511C744E78B9468C00D635" +
+			"39F4A503C0C4B54F0D9EAA9F3100B3D81EFB9BCD417F12A78675B86A" +
+			"8CF6EC59814EB27E3C120132B144A59FB30E988986616E77C724FEF3" +
+			"E4EA7580DC8D6C1D226B82E41CB379120BE9AEF1C21E5C0AA98A5740" +
+			"A0F1190EE02801312CCB7BD7AB6055C8B324AB1C600CD18692B3A391" +
+			"A19EBF5EA428A2A8FB9199A92C04CAAA6AC6F9C119C8A48E32AA4C42" +
+			"155F23CD220F672BE8BB6D45E1F906061D3DE9620FC0032CBA9D5102" +
+			"87D5359BAF4026924879892E2500FB705B7121D11E6B27F458AB30D3" +
+			"8C87EC1E798FE338017A1135983493F362754ACEE8FD85A1D0C4C17D" +
+			"B804705A75EB425C1A2EDB8176445FC558008F8B0187369CD7E979C4" +
+			"2A3E8A0EC57ADBDC264DE4BF3BB5A35D7EA841A1E28F60990CA43766" +
+			"BA53EADF196091B163C26E2F4CC2378448979569D59F056481BE7872" +
+			"545B5D3F7F9CF3255FD5DE003C02",
+	},
 	FP_751: {
 		name: "P-751",
 		// PrA - Alice's Private Key: 2*randint(0,2^371)
@@ -87,6 +159,135 @@ var tdata = map[uint8]struct {
 			"FB03D1A40F596613AF97F4ED7643A1C2D12692E959C6DEB8E72403ADC0E42204DBCE5056EEF0CC60B0C6E" +
 			"83B8B55AC01F6C85644EE49",
 	},
+	FP_503_R3: {
+		name: "P-503r3",
+		PrA:  "D5B94224FA1AD1701EC277FDA83462D09E87181C2E583C5F09FD446A43F25103",
+		PrB:  "FF0EF91753D71D83D912656856086007AD2CF3B2A979B2BD63E5313BFD276506",
+		PkA: "FFB9A589DBB3975A20373F1AD3B449880E1DA47916FCD7C751A019AAA8E95A03" +
+			"4ADA1AE8BFCF6FBF70F323713318E25315DE865B29C9124982594BF78CD61C09" +
+			"B98C22307DFD4FC0958C58EC0D144828006E510FA4072D721B48D1A3CFEA02F6" +
+			"062324FE1B68F457CF29BD4EB1FB68D0684EE69F53A3FAC327404341BB39365B" +
+			"5DE885034C9A6DD6798CB08126183C696D0302730D489584AC8D6BCADF3AB4DE" +
+			"FFC227D3B1701462DA15BF68EF2B07C44712DB5429B74063202F43DC0EA7919D" +
+			"B95025B2B03E5A3EBF57FF37E21838CE8F5531F491315E576A260DC2F515DDF2" +
+			"4DEDE54CA69737CE6442B4219CD472D9CCCF8AF12B5C5B23ACB9C22929924ABA" +
+			"52C820932C82435518B920AFC43F2041263AE98D29E58B73F33A5DD42AAC7533" +
+			"404B4AC2B83DD2C7BEA7676930B6007CC185C264672B75F5332C18429BBC7B0F" +
+			"2EA3F746995A298E7443FFB0C1B27DCA7D19635064AF3B87938960587F56B724" +
+			"C1EF1FF012B056D7301F8713295B7734563542D4259B8BEA260C",
+		PkB: "AA3303F79FCE4855DE125B239D10BEC3B7082E55B08C769A9765F1BF41A31210" +
+			"66382D6BF2857D85138CC17D69593B8FC7703D22C553FB077DFB3AC577CF1E55" +
+			"2AED6DC0368123E01DDD70059A47E31E06423D2F697A3DA5D621EB5FF2A52EE7" +
+			"CA7D3BD01B427AC14CB6099E03C6639A3BAC9B939297CEB10F276F93CF16A1EE" +
+			"1B8085DE3DA037C911491B145B034A47B42A996A61C2313FEF166FD3665B0AE8" +
+			"C201A268DA01AB52E4759ACCC1DD09685A17CDA5D44AF847931ABB221D62E241" +
+			"2329394445ADFD662C77472A9268F40AB540177C0E6F1D59026E595C46FBED2F" +
+			"703838C5F3D7A811B500237596C5E960AA1F2989C5F17DD60D8A752569DBF250" +
+			"E01C63C9790D014CE05A9D8683814A320E30185E478AF9ABD761DADDA8DE7E3E" +
+			"A53C224AC8313C176D14BD0F04AB09A9D0D0302ACBF768EB4DB03681EAE3BC10" +
+			"21CAA429A1490AE1D6E0CA9D6BC4BCD14B1CFE694226D03E8731E9E0B3760877" +
+			"7D56630B31298CC05B6FF6C1A08935312D8E95B8056AD7831A22",
+	},
+	FP_751_R3: {
+		name: "P-751r3",
+		// PrA - Alice's Private Key: 2*randint(0,2^371)
+		PrA: "B1937F2B009FD9F785C5AD899F5ED2FD064218C07A76798D1433336E093DF184CA0CAF6E7C92B320E89632A7765F0B",
+		// PrB - Bob's Private Key: 3*randint(0,3^238)
+		PrB: "54DB4380D4134E187F5793FA82A4F18B39CA2F8F1C145FFB040FC917E2F41542037FF227F3A4AAFB17A6983AA88B4403",
+		PkA: "2B53BB7C002A9D18B077D068C38353ADFD57A3CA0E431D92CCB8060D41A83A09" +
+			"C1500BEE63FB07DA1DBEDB4AF145F22C2D8152B07B7E124F06643C7AB5B1C48D" +
+			"581FC4C6FB7FC165F6E02F40B47D81400B84B6288DEAB38DE795B51486430974" +
+			"788377770D46B0ABC1F3F7C3647A0E38ACED475EA8EE990F3C5A7668452478C1" +
+			"975993E126B405E476AD6A10E9DD4E135ADA910BE41A8F93331268791F7B958A" +
+			"0888052D1CF7B9128058A258002F22AC48E2C296E3935033139D336FDEB33E9B" +
+			"8713663B1705B566AC4F4455FD0E710A5236C18011EA971DDD18633F9022C432" +
+			"B225EC401FCD4C233B8BF91BE5944D1A657516CE4B844D0BC6049478046B60B6" +
+			"BE163E41CCE743054806B13FAC0A1FA393C866D74A5A93A938209287324FAB46" +
+			"295F5B271C9D8CFEE17868599202D7AEF1A67EBE2FA2A27AD9E0991CEDEE270D" +
+			"1E7639425D94A0F0A2EFDE90B86D5D7F03F3CF97D30415CAA661F865ADB788E6" +
+			"3F79EE2FE8637D9DCAF3BCD5C42AE499688048BBB49F216B4714675FD87F7CCC" +
+			"37A0C2EA56BB53675584F7648BA052F963C35387ECC4DDED4F8076934FF32D5D" +
+			"77B5D3C57553757A4CB9588C4575D4CC4CA8280370041A6FF03007C31D0712E8" +
+			"327CC5BC9885F896904FAB4EE167745260D331C3E85E8A32FCCD23093F2C55A9" +
+			"EA8D7BECEFC3564D3C3D4CE585B62785773B2228742185C97D6F51CD3E3CF885" +
+			"5CF37F305734E97A86479580FDB9983DDCAE9D0F1B125523DB2FE58339240A58" +
+			"131D0EFD14A560A6F7EDE774F6C3C54F46DD0830",
+		PkB: "E93E4E2B0E7348C088FD91F8E3B17CC52B55C5E8933B6D00B3757E5347C5A0AE" +
+			"5F1A6051C32FB20A41013A244484C8D0501B6A3516A634581E08C907F29D651C" +
+			"BCC5B6CA6BAC4DCC980CD36155B60A2108134D3F78ED19ADA2B8B20184302FD7" +
+			"47E096B01ABB33CB76B115D673B00F76913281CF7A0B44FF5CF1A256B8138FE6" +
+			"77AB8D22FEBBCB10EB46E442D0AD96A126DD24E941280C7AB3F9FAC2832A149C" +
+			"AD93D221EDCB406303F5A1B38F43F310244D766239A7F4D89EED4C6FD272BC4F" +
+			"F7C4E34F3E5E77BFF59D6FE5C0D3937F574ABE81C2FC1FEDD98F43323F8F2D7C" +
+			"154DCEBF410FCF698A4E6506D6FD6E4A861395F75698C810A527E00ADC118C90" +
+			"9CDE7729B24C6E7DE9C92CFB592CB9A4BAC54DB468AA52724452CC7A7B40509B" +
+			"B7894ACCF2C7933537FD52DB312D7FBC4FDE01AC2350CD25ABDD5E6F3AEF87BA" +
+			"1BE23691544EB6A4F8FA9CF2BE70B9665EA479B44DC3C61D4651CDDB53656B6F" +
+			"3A2D139EC6BE8550B8A623CCA30EB5374F70C67A932712002006F3D73190384F" +
+			"6D3BEC9DB9F46E9B7AE2891E615752E74DC112579CF43283E8B6EBEF4C802BD6" +
+			"700A97B9739E8694B48D8CB9C8ABBC915A834FF1DBF2C823B3E156D0A415C558" +
+			"71B719BD9DD215F57C8D19A6B4F8CD93283D6EBD135347DAAF4F20C54D24017F" +
+			"5E6AF400AB6DC98AD2D5810E59360DF64503599FAF6FBAB720CC557DEEF0F528" +
+			"249DEB530C8F310465E0BE2B4041C24DAF783BC4EE71D27C760FA7CFD4A9A691" +
+			"70BCBA465CA242961671DF0C53A8112CCD708D58",
+	},
+	FP_964: {
+		name: "P-964",
+		PrA:  "239B0E9DD07A20D4A60044C66663ED25B240A4A5B68144C2813ECF8A43CF25E7F2B68A5E02B45A9180F8D883F1DED19FF0085A8F923E3E2136C71BDD2C",
+		PrB:  "79D5E6F3A355F728A2661ECB5502AA961BC87A55E0E8724404DDE538E6AEEAAA8F3CA9BA8FAD4E79B950899E20EAE069BDCA078C11A97FE3D606D519",
+		PkA: "8FCFF0A8A8BA70820C822567308028605C7B5E9248E97614F8EA186B" +
+			"D1E61BF0F23B07966A727695AA2F2F766FD3DCD2F8877D0A4BA1DA8C" +
+			"F3A81DFEE8197CA1BBFD4E02EF35A0DA72EA3107CC13CC199525888D" +
+			"058415BB1D44AE1633918E859CBA0E03C8A9E1C91891D7540D11E655" +
+			"2BFB8D6850A16E6F0048ED46810AE07B2E5C3D69FFC3EF8132E4A920" +
+			"220E92B99BF7DC4BE04F91AFDD2FE8B258C03D714637B12FEF877364" +
+			"38C9C3BE6BEE680AE44CDE7928C2FD1BCB9737033B76C5C2E5DBED7E" +
+			"21F1158FCC79E3C4118E2FADDEABE331A476880948E53F2EAA81268A" +
+			"E4A80910187E47A0C7F6F5115CA70BBF6F074781855FCC232675A477" +
+			"CFD141D93FFC3BF0FB402BF5D4B4C114F7DD820FEA9B55B8583DBA4C" +
+			"F86D9ECC294FB0B3B52920DF73BD0B64DD56A74625096D9C1DAADF59" +
+			"4C64A4F5878E0C3797B49D10FC6948C51343E25B3FCA390A69666872" +
+			"C2DBA3DB4F736AAF6B87BC1EB386E273BE7ED6FB650ABC668EB906E1" +
+			"977655479378D2B319D4A4F38B4CAE224E206FB96B916E38E56AB4E6" +
+			"03730828698B125E7E3D7B270850B2324347C3A032466E1336188119" +
+			"9837E7CA4B6FF1CBFAEF31B7072C98A48CBAF35675566FC6968EDDB4" +
+			"16317C85A06EAAF07EA2CABBBD3BDF1D8439A59D7F7B2242EB3158B3" +
+			"CA32A9E7D8E16A0116C116F9FA8F6623FF0B5481270A044FD296B13E" +
+			"72A70A91714C23D6D2A092FC426FD33EE86BBA083D81FDC5865C740B" +
+			"AF543C1F0A2346539D298EC18A7465F6E46CF9D6628CA25AAEFCE8F3" +
+			"D656865A436CE6D648116FAF6B6D84F8ED8948A95F83896A4C77C8FF" +
+			"4E0D7AB6DF117852A4EBFE61F8BC5E0A005139C81EAE39A7ABFE03BB" +
+			"E3631072680EB31BA1836A1E6B5AF34A0978B3FFA43811DC361837ED" +
+			"156CFB35FCC5AF0BAABDA824967448844C9DE9B4766A1723A9606A69" +
+			"E5F28DC04CC34FD95680A29A9DEE5D082D69DA16AF3819408BAEE312" +
+			"DA58A85556A2F7E3AFEF554E1A09230031E61B11F5A269D78307",
+		PkB: "168D08E079BB4F70C3EA8B6DD7FB2E44440BADB3F0C4AA5008E16E8E" +
+			"AFF1A3459E03086E656FECEA1AF99FF2962184C6214F63419D9056AE" +
+			"4EF554E37236EFA3C1DBC1F9A503C308C02DF741A5D391A79E3457F8" +
+			"F3FFDA46DDECE67F46F64F2CC5CA0CE2CADC0A8427A0DDD2C9536AD7" +
+			"A3CFD51E6F483A7A05FC4BE85E2661192E85BBE89E69EC4E1C701D1A" +
+			"7083922DBD81B307D9011C9037B34D822D8A4BB2547C7443DCA21DC1" +
+			"7795CA53A4A6F51DBE9BDE604EC96750509339057A43D97EB70F71E8" +
+			"80B7B404EC864D7C914E2372FF485B17587294E0E01E7D31EDD68BC3" +
+			"6EA52CA1EA49D75CAA16E786B6B919CF2F08B5042C7271083B0ED0BA" +
+			"C1D95BF15DD902EE24B91D775214A867826D5C4712722B787E8112A5" +
+			"4E2318CECE4EAECA5DFA3A71307D3D4EC2C3EB7A234303EF0F892DB6" +
+			"1A73131F1ECA304A006A14C2865C7F17996675C818CB8A486CF7CB13" +
+			"8957DBE2F80A407E558F9A5360BA6D2D61C1E1FD1DA4C35821CA0667" +
+			"FBCC6F80D8B5FF86A9F7A4AC8BD092FC44077F7FD2FC9977ECAE6BF0" +
+			"478A4932E06EA9986D052B3F198EA7C10D3835F355FEC41A746BFD8A" +
+			"B6009FA90C33F391A677F47BA3353967317E5007299E12E3A4EFCF4D" +
+			"DAE702644FE70776624FAC27A550A8B069A1A8A90A830DD348FE33ED" +
+			"FAFCD72B6AA6D102EF5624F87556016A7678A04568ADF6C34CEA3E55" +
+			"93B95B734C84349BA4C4563AFE0689693050FCE6217CC932052D286E" +
+			"00B2F5EB8076C234FEDB8A39A950F5F2280FBDE1244A1803DF034BED" +
+			"79965B0D0A3C5DA0BC03AE0F10F2EC323F39C23C4BE15EBD74383260" +
+			"FC4038C774D733824E62AD2A3A75B97E0370EA23A2D8F1E4F77900AB" +
+			"CC64BA152BED431782A0FDDECE83548F85B15402624C86D427AB34EF" +
+			"C48AA2F3749469576408671703031D85DDD1BE9547B62319BAEDE1D3" +
+			"F6028682E921654F9F5831E32AD9A616F2E73243AAEF717FF974EF4A" +
+			"88E9DFB77AA67E2949E905B3D3CE4E5C35A40A29C2BE83CF7C02",
+	},
 }
 
 /* -------------------------------------------------------------------------
@@ -253,6 +454,119 @@ func testImportExport(t testing.TB, id uint8) {
 	}
 }
 
+func testCompression(t testing.TB, id uint8) {
+	alicePrivate := convToPrv(tdata[id].PrA, KeyVariant_SIDH_A, id)
+	bobPrivate := convToPrv(tdata[id].PrB, KeyVariant_SIDH_B, id)
+	alicePublic := convToPub(tdata[id].PkA, KeyVariant_SIDH_A, id)
+	bobPublic := convToPub(tdata[id].PkB, KeyVariant_SIDH_B, id)
+
+	expSecret, err := DeriveSecret(alicePrivate, bobPublic)
+	checkErr(t, err, "derivation of shared secret failed")
+
+	for _, pub := range []*PublicKey{alicePublic, bobPublic} {
+		cmp, err := pub.ExportCompressed()
+		checkErr(t, err, "compression failed")
+		if len(cmp) != pub.CompressedSize() {
+			t.Fatalf("wrong size of compressed key: %d", len(cmp))
+		}
+
+		dec := NewPublicKey(id, pub.Variant())
+		checkErr(t, dec.ImportCompressed(cmp), "decompression failed")
+
+		// Compressed encoding is canonical
+		cmp2, err := dec.ExportCompressed()
+		checkErr(t, err, "compression failed")
+		if !bytes.Equal(cmp, cmp2) {
+			t.Fatalf("compressed encoding not canonical")
+		}
+
+		// Decompressed key yields the same shared secret
+		var s []byte
+		if pub.Variant() == KeyVariant_SIDH_A {
+			s, err = DeriveSecret(bobPrivate, dec)
+		} else {
+			s, err = DeriveSecret(alicePrivate, dec)
+		}
+		checkErr(t, err, "derivation of shared secret failed")
+		if !bytes.Equal(s, expSecret) {
+			t.Fatalf("shared secrets don't match: \n%X, \n%X", s, expSecret)
+		}
+
+		// Negative cases
+		if dec.ImportCompressed(cmp[1:]) == nil {
+			t.Error("decompression accepts input of wrong size")
+		}
+		bad := append([]byte{}, cmp...)
+		bad[len(bad)-1] = 2
+		if dec.ImportCompressed(bad) == nil {
+			t.Error("decompression accepts wrong normalization byte")
+		}
+		bad = append([]byte{}, cmp...)
+		for i := 2 * pub.Params().Bytelen; i < len(bad)-1; i++ {
+			bad[i] = 0xFF
+		}
+		if dec.ImportCompressed(bad) == nil {
+			t.Error("decompression accepts non-reduced scalars")
+		}
+	}
+}
+
+func testValidate(t testing.TB, id uint8) {
+	for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B} {
+		prv := NewPrivateKey(id, v)
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		checkErr(t, prv.GeneratePublicKey().Validate(), "valid generated key rejected")
+	}
+
+	pkA, err := hex.DecodeString(tdata[id].PkA)
+	checkErr(t, err, "invalid hex-number provided")
+	pkB, err := hex.DecodeString(tdata[id].PkB)
+	checkErr(t, err, "invalid hex-number provided")
+
+	for _, tc := range []struct {
+		v   KeyVariant
+		key []byte
+	}{{KeyVariant_SIDH_A, pkA}, {KeyVariant_SIDH_B, pkB}, {KeyVariant_SIKE, pkB}} {
+		pub := NewPublicKey(id, tc.v)
+		checkErr(t, pub.Import(tc.key), "import failed")
+		checkErr(t, pub.Validate(), "valid key rejected")
+
+		// Coordinate not reduced modulo p
+		bad := append([]byte{}, tc.key...)
+		for i := 0; i < pub.Params().Bytelen; i++ {
+			bad[i] = 0xFF
+		}
+		checkErr(t, pub.Import(bad), "import failed")
+		if err = pub.Validate(); err != ErrNonCanonical {
+			t.Errorf("expected ErrNonCanonical, got: %v", err)
+		}
+
+		// Modified coordinate of a point
+		bad = append([]byte{}, tc.key...)
+		bad[0] ^= 1
+		checkErr(t, pub.Import(bad), "import failed")
+		if _, ok := pub.Validate().(ValidationError); !ok {
+			t.Error("modified key accepted")
+		}
+	}
+
+	// Points of a key A don't have order 2^e2
+	pub := NewPublicKey(id, KeyVariant_SIDH_B)
+	checkErr(t, pub.Import(pkA), "import failed")
+	if err = pub.Validate(); err != ErrWrongOrder {
+		t.Errorf("expected ErrWrongOrder, got: %v", err)
+	}
+
+	// x(P) = x(Q) = 1 gives singular curve with A = -2
+	sz := pub.Params().SharedSecretSize
+	sing := make([]byte, pub.Size())
+	sing[0], sing[sz], sing[2*sz] = 1, 1, 3
+	checkErr(t, pub.Import(sing), "import failed")
+	if err = pub.Validate(); err != ErrSingularCurve {
+		t.Errorf("expected ErrSingularCurve, got: %v", err)
+	}
+}
+
 func testPrivateKeyBelowMax(t testing.TB, id uint8) {
 	params := Params(id)
 	for variant, keySz := range map[KeyVariant]*DomainParams{
@@ -285,11 +599,427 @@ func testPrivateKeyBelowMax(t testing.TB, id uint8) {
 				if prvBig.Cmp(maxSecertVal) == 1 {
 					t.Error("Generated private key is wrong")
 				}
+				// Generate() always sets most significant bit of the scalar
+				if prvBig.BitLen() != int(dp.SecretBitLen) {
+					t.Error("Generated private key has wrong bit length")
+				}
 			}
 		}(variant, keySz)
 	}
 }
 
+func testDeriveKeyPair(t testing.TB, id uint8) {
+	seed := make([]byte, DeriveSeedSize)
+	rand.Read(seed)
+	scalars := make(map[string]bool)
+	for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B, KeyVariant_SIKE} {
+		prv1, pub1, err := DeriveKeyPair(id, v, seed)
+		checkErr(t, err, "key derivation failed")
+		prv2, pub2, err := DeriveKeyPair(id, v, seed)
+		checkErr(t, err, "key derivation failed")
+		if !bytes.Equal(prv1.Export(), prv2.Export()) || !bytes.Equal(pub1.Export(), pub2.Export()) {
+			t.Error("key derivation is not deterministic")
+		}
+		if !bytes.Equal(prv1.GeneratePublicKey().Export(), pub1.Export()) || prv1.Variant() != v {
+			t.Error("derived public key doesn't match private key")
+		}
+		scalars[hex.EncodeToString(prv1.Scalar)] = true
+	}
+	if len(scalars) != 3 {
+		t.Error("same scalar derived for different variants")
+	}
+
+	if _, _, err := DeriveKeyPair(id, KeyVariant_SIKE, seed[1:]); err == nil {
+		t.Error("seed of wrong size accepted")
+	}
+	if _, _, err := DeriveKeyPair(id, KeyVariant(0), seed); err == nil {
+		t.Error("invalid key variant accepted")
+	}
+}
+
+func testZeroize(t testing.TB, id uint8) {
+	isZero := func(b []byte) bool {
+		return bytes.Equal(b, make([]byte, len(b)))
+	}
+	for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B, KeyVariant_SIKE} {
+		prv := NewPrivateKey(id, v)
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		scalar, s := prv.Scalar, prv.S
+
+		prv.Zeroize()
+		if !isZero(scalar) || !isZero(s) || len(prv.Scalar) != len(scalar) {
+			t.Error("private key not zeroized")
+		}
+		// Key can be reused
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		if isZero(prv.Scalar) {
+			t.Error("zeroized key can't be reused")
+		}
+
+		prv.Destroy()
+		if !isZero(scalar) || !isZero(s) || prv.Scalar != nil || prv.S != nil {
+			t.Error("private key not destroyed")
+		}
+	}
+}
+
+func testPKIX(t testing.TB, id uint8) {
+	for v, pk := range map[KeyVariant]string{
+		KeyVariant_SIDH_A: tdata[id].PkA,
+		KeyVariant_SIDH_B: tdata[id].PkB,
+		KeyVariant_SIKE:   tdata[id].PkB,
+	} {
+		pub := convToPub(pk, v, id)
+		enc, err := EncodePEMPublicKey(pub)
+		checkErr(t, err, "PEM encoding of public key failed")
+		pub2, err := DecodePEMPublicKey(enc)
+		if err != nil || pub2.Params().Id != id || pub2.Variant() != v || !bytes.Equal(pub2.Export(), pub.Export()) {
+			t.Fatalf("public key of variant %d not decoded", v)
+		}
+
+		prv := NewPrivateKey(id, v)
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		enc, err = EncodePEMPrivateKey(prv)
+		checkErr(t, err, "PEM encoding of private key failed")
+		prv2, err := DecodePEMPrivateKey(enc)
+		if err != nil || prv2.Params().Id != id || prv2.Variant() != v || !bytes.Equal(prv2.Export(), prv.Export()) {
+			t.Fatalf("private key of variant %d not decoded", v)
+		}
+		if _, err = DecodePEMPublicKey(enc); err == nil {
+			t.Error("private key decoded as public key")
+		}
+	}
+}
+
+func TestPKIXErrors(t *testing.T) {
+	oid, err := OID(FP_503, KeyVariant_SIKE)
+	if err != nil || !oid.Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44363, 46, 3, 503}) {
+		t.Errorf("unexpected OID of SIKEp503 key: %v", oid)
+	}
+	if _, err = OID(FP_503, KeyVariant_SIKE|KeyVariant_SIDH_A); err == nil {
+		t.Error("OID of invalid key variant")
+	}
+	// Round 3 versions are in a sibling arc, OIDs aren't prefixes of each other
+	oid, err = OID(FP_503_R3, KeyVariant_SIKE)
+	if err != nil || !oid.Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44363, 46, 4, 3, 503}) {
+		t.Errorf("unexpected OID of SIKEp503r3 key: %v", oid)
+	}
+	var oids []asn1.ObjectIdentifier
+	for _, p := range SupportedParams() {
+		for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B, KeyVariant_SIKE} {
+			if oid, err = OID(p.Id, v); err == nil {
+				oids = append(oids, oid)
+			}
+		}
+	}
+	for i, a := range oids {
+		for j, b := range oids {
+			if i != j && len(a) <= len(b) && a.Equal(b[:len(a)]) {
+				t.Errorf("OID %v is a prefix of %v", a, b)
+			}
+		}
+	}
+
+	pub := convToPub(tdata[FP_434].PkB, KeyVariant_SIDH_B, FP_434)
+	der, err := MarshalPKIXPublicKey(pub)
+	checkErr(t, err, "encoding of public key failed")
+	if _, err = ParsePKIXPublicKey(append(der, 0)); err == nil {
+		t.Error("trailing data accepted")
+	}
+
+	var spki subjectPublicKeyInfo
+	if _, err = asn1.Unmarshal(der, &spki); err != nil {
+		t.Fatal(err)
+	}
+	spki.Algorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+	bad, _ := asn1.Marshal(spki)
+	if _, err = ParsePKIXPublicKey(bad); err != ErrUnknownOID {
+		t.Error("unknown OID accepted")
+	}
+	// P-434 key labelled as P-503 one
+	spki.Algorithm.Algorithm, _ = OID(FP_503, KeyVariant_SIDH_B)
+	bad, _ = asn1.Marshal(spki)
+	if _, err = ParsePKIXPublicKey(bad); err == nil {
+		t.Error("public key of wrong size accepted")
+	}
+	spki.Algorithm.Algorithm, _ = OID(FP_434, KeyVariant_SIDH_B)
+	// Non-canonical encoding of xP
+	key := spki.PublicKey.Bytes
+	spki.PublicKey.Bytes = append([]byte{}, key...)
+	for i := 0; i < pub.Params().Bytelen; i++ {
+		spki.PublicKey.Bytes[i] = 0xFF
+	}
+	bad, _ = asn1.Marshal(spki)
+	if _, err = ParsePKIXPublicKey(bad); !errors.Is(err, ErrNonCanonical) {
+		t.Errorf("non-canonical public key accepted: %v", err)
+	}
+	spki.PublicKey.Bytes = key
+	spki.Algorithm.Parameters = asn1.NullRawValue
+	bad, _ = asn1.Marshal(spki)
+	if _, err = ParsePKIXPublicKey(bad); err == nil {
+		t.Error("algorithm parameters accepted")
+	}
+
+	prv := convToPrv(tdata[FP_434].PrB, KeyVariant_SIDH_B, FP_434)
+	der, err = MarshalPKCS8PrivateKey(prv)
+	checkErr(t, err, "encoding of private key failed")
+	var info privateKeyInfo
+	if _, err = asn1.Unmarshal(der, &info); err != nil {
+		t.Fatal(err)
+	}
+	info.Version = 1
+	bad, _ = asn1.Marshal(info)
+	if _, err = ParsePKCS8PrivateKey(bad); err == nil {
+		t.Error("unsupported version accepted")
+	}
+	if _, err = DecodePEMPrivateKey(der); err == nil {
+		t.Error("DER accepted as PEM")
+	}
+}
+
+func testContainer(t testing.TB, id uint8) {
+	for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B, KeyVariant_SIKE} {
+		prv := NewPrivateKey(id, v)
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		data, err := prv.MarshalBinary()
+		checkErr(t, err, "marshaling of private key failed")
+		var prv2 PrivateKey
+		if err = prv2.UnmarshalBinary(data); err != nil || prv2.Params().Id != id || prv2.Variant() != v ||
+			!bytes.Equal(prv2.Export(), prv.Export()) {
+			t.Fatalf("private key of variant %d not unmarshaled", v)
+		}
+
+		pub := NewPublicKey(id, v)
+		pk, _ := hex.DecodeString(tdata[id].PkB)
+		if v == KeyVariant_SIDH_A {
+			pk, _ = hex.DecodeString(tdata[id].PkA)
+		}
+		checkErr(t, pub.Import(pk), "import failed")
+		data, err = pub.MarshalBinary()
+		checkErr(t, err, "marshaling of public key failed")
+		var pub2 PublicKey
+		if err = pub2.UnmarshalBinary(data); err != nil || pub2.Params().Id != id || pub2.Variant() != v ||
+			!bytes.Equal(pub2.Export(), pk) {
+			t.Fatalf("public key of variant %d not unmarshaled", v)
+		}
+	}
+}
+
+func TestContainerErrors(t *testing.T) {
+	pub := convToPub(tdata[FP_434].PkA, KeyVariant_SIDH_A, FP_434)
+	data, _ := pub.MarshalBinary()
+	if !bytes.HasPrefix(data, []byte{'S', 'I', 'D', 'H', 1, 1, 1, FP_434, byte(KeyVariant_SIDH_A)}) {
+		t.Fatalf("unexpected header of container: %X", data[:containerHeaderSize])
+	}
+
+	// Container without checksum is accepted
+	noChecksum := append([]byte(nil), data[:len(data)-4]...)
+	noChecksum[6] = 0
+	var pub2 PublicKey
+	if err := pub2.UnmarshalBinary(noChecksum); err != nil || !bytes.Equal(pub2.Export(), pub.Export()) {
+		t.Error("container without checksum rejected")
+	}
+	// Non-canonical encoding of xP
+	nonCanonical := append([]byte(nil), noChecksum...)
+	for i := 0; i < pub.Params().Bytelen; i++ {
+		nonCanonical[containerHeaderSize+i] = 0xFF
+	}
+	if err := pub2.UnmarshalBinary(nonCanonical); !errors.Is(err, ErrNonCanonical) {
+		t.Errorf("non-canonical public key accepted: %v", err)
+	}
+
+	modify := func(f func([]byte) []byte) []byte {
+		return f(append([]byte(nil), data...))
+	}
+	for i, bad := range [][]byte{
+		nil,
+		data[:containerHeaderSize],
+		data[:len(data)-1],
+		append(append([]byte(nil), data...), 0),
+		noChecksum[:len(noChecksum)-1],
+		modify(func(b []byte) []byte { b[0] = 'X'; return b }),
+		// version
+		modify(func(b []byte) []byte { b[4] = 2; return b }),
+		// private key container
+		modify(func(b []byte) []byte { b[5] = containerPrivateKey; return b }),
+		// unknown flag
+		modify(func(b []byte) []byte { b[6] |= 2; return b }),
+		// unregistered parameter set
+		modify(func(b []byte) []byte { b[7] = maxPrimeFieldId; return b }),
+		// invalid variant
+		modify(func(b []byte) []byte { b[8] = 5; return b }),
+		// payload
+		modify(func(b []byte) []byte { b[containerHeaderSize] ^= 1; return b }),
+		// checksum
+		modify(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }),
+	} {
+		pub2 = *pub
+		if pub2.UnmarshalBinary(bad) == nil {
+			t.Errorf("malformed container %d accepted", i)
+		}
+		if !bytes.Equal(pub2.Export(), pub.Export()) {
+			t.Errorf("key modified by failed unmarshaling of container %d", i)
+		}
+	}
+
+	var prv PrivateKey
+	if prv.UnmarshalBinary(data) == nil {
+		t.Error("public key unmarshaled as private key")
+	}
+}
+
+func testJSON(t testing.TB, id uint8) {
+	for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B, KeyVariant_SIKE} {
+		prv := NewPrivateKey(id, v)
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		data, err := json.Marshal(prv)
+		checkErr(t, err, "JSON encoding of private key failed")
+		var prv2 PrivateKey
+		if err = json.Unmarshal(data, &prv2); err != nil || prv2.Params().Id != id || prv2.Variant() != v ||
+			!bytes.Equal(prv2.Export(), prv.Export()) {
+			t.Fatalf("private key of variant %d not decoded from JSON", v)
+		}
+
+		pk := tdata[id].PkB
+		if v == KeyVariant_SIDH_A {
+			pk = tdata[id].PkA
+		}
+		pub := convToPub(pk, v, id)
+		data, err = json.Marshal(pub)
+		checkErr(t, err, "JSON encoding of public key failed")
+		var pub2 PublicKey
+		if err = json.Unmarshal(data, &pub2); err != nil || pub2.Params().Id != id || pub2.Variant() != v ||
+			!bytes.Equal(pub2.Export(), pub.Export()) {
+			t.Fatalf("public key of variant %d not decoded from JSON", v)
+		}
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	pub := convToPub(tdata[FP_503].PkB, KeyVariant_SIKE, FP_503)
+	data, _ := json.Marshal(pub)
+	var members map[string]string
+	if err := json.Unmarshal(data, &members); err != nil {
+		t.Fatal(err)
+	}
+	if members["kty"] != "SIKE" || members["alg"] != "SIKEp503" || len(members) != 5 ||
+		members["xP"] != base64.RawURLEncoding.EncodeToString(pub.Export()[:pub.Params().SharedSecretSize]) {
+		t.Errorf("unexpected JSON encoding of public key: %s", data)
+	}
+
+	modify := func(name, value string) []byte {
+		m := make(map[string]string)
+		for k, v := range members {
+			m[k] = v
+		}
+		if value == "" {
+			delete(m, name)
+		} else {
+			m[name] = value
+		}
+		b, _ := json.Marshal(m)
+		return b
+	}
+	// Coordinate not reduced modulo p
+	nonCanonical := append([]byte(nil), pub.Export()[:pub.Params().SharedSecretSize]...)
+	for i := 0; i < pub.Params().Bytelen; i++ {
+		nonCanonical[i] = 0xFF
+	}
+	for i, bad := range [][]byte{
+		[]byte(`null`),
+		[]byte(`[]`),
+		append(append([]byte(nil), data...), []byte(" {}")...),
+		modify("kty", "RSA"),
+		modify("kty", ""),
+		modify("alg", "sikep503"),
+		modify("alg", "SIKEp1"),
+		modify("xP", ""),
+		modify("xQ", members["xQ"]+"AA"),
+		modify("xQmP", members["xQmP"]+"="),
+		modify("xP", base64.StdEncoding.EncodeToString(pub.Export()[:pub.Params().SharedSecretSize])),
+		modify("xP", base64.RawURLEncoding.EncodeToString(nonCanonical)),
+		modify("d", "AAAA"),
+	} {
+		pub2 := *pub
+		if pub2.UnmarshalJSON(bad) == nil {
+			t.Errorf("malformed JSON %d accepted: %s", i, bad)
+		}
+		if !bytes.Equal(pub2.Export(), pub.Export()) {
+			t.Errorf("key modified by failed decoding of JSON %d", i)
+		}
+	}
+
+	// Private key must carry S only if it's SIKE key
+	prv := convToPrv(tdata[FP_503].PrA, KeyVariant_SIDH_A, FP_503)
+	data, _ = json.Marshal(prv)
+	if bytes.Contains(data, []byte(`"s"`)) {
+		t.Errorf("SIDH private key with S: %s", data)
+	}
+	var prv2 PrivateKey
+	for i, bad := range [][]byte{
+		bytes.Replace(data, []byte(`"SIDH-A"`), []byte(`"SIKE"`), 1),
+		bytes.Replace(data, []byte(`"SIDH-A"`), []byte(`"sidh-a"`), 1),
+		bytes.Replace(data, []byte(`}`), []byte(`,"s":"AAAA"}`), 1),
+		bytes.Replace(data, []byte(`}`), []byte(`,"xP":"AAAA"}`), 1),
+	} {
+		if prv2.UnmarshalJSON(bad) == nil {
+			t.Errorf("malformed JSON %d accepted: %s", i, bad)
+		}
+	}
+
+	// Scalar with bits set above SecretBitLen, for both torsion subgroups
+	for _, v := range []KeyVariant{KeyVariant_SIDH_A, KeyVariant_SIDH_B, KeyVariant_SIKE} {
+		prv = NewPrivateKey(FP_503, v)
+		checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+		prv.Scalar[len(prv.Scalar)-1] |= 0x80
+		data, _ = json.Marshal(prv)
+		if err := prv2.UnmarshalJSON(data); err == nil {
+			t.Errorf("scalar out of range accepted for variant %d", v)
+		}
+	}
+}
+
+func testImportStrict(t testing.TB, id uint8) {
+	pk, err := hex.DecodeString(tdata[id].PkA)
+	checkErr(t, err, "invalid hex-number provided")
+	pub := NewPublicKey(id, KeyVariant_SIDH_A)
+	checkErr(t, pub.ImportStrict(pk), "import of canonical key failed")
+	if !bytes.Equal(pub.Export(), pk) || pub.Validate() != nil {
+		t.Fatal("wrong key imported")
+	}
+
+	ssSz := pub.Params().SharedSecretSize
+	for i, name := range []string{"xP", "xQ", "xQmP"} {
+		// Real and imaginary part of the coordinate
+		for _, off := range []int{0, pub.Params().Bytelen} {
+			bad := append([]byte{}, pk...)
+			for j := 0; j < pub.Params().Bytelen; j++ {
+				bad[i*ssSz+off+j] = 0xFF
+			}
+			err = pub.ImportStrict(bad)
+			if cerr, ok := err.(*CoordinateError); !ok || cerr.Coordinate != name {
+				t.Errorf("non-canonical %s accepted: %v", name, err)
+			}
+			if !errors.Is(err, ErrNonCanonical) {
+				t.Error("CoordinateError doesn't match ErrNonCanonical")
+			}
+			if !bytes.Equal(pub.Export(), pk) {
+				t.Error("key modified by failed import")
+			}
+			// Import accepts it
+			checkErr(t, pub.Import(bad), "import failed")
+			checkErr(t, pub.ImportStrict(pk), "import of canonical key failed")
+		}
+	}
+
+	if pub.ImportStrict(append(pk, 0)) == nil {
+		t.Error("trailing data accepted")
+	}
+	if pub.ImportStrict(pk[:len(pk)-1]) == nil {
+		t.Error("truncated key accepted")
+	}
+}
+
 func TestKeyAgreement(t *testing.T) {
 	for id, val := range tdata {
 		fmt.Printf("\tTesting: %s\n", val.name)
@@ -307,9 +1037,144 @@ func TestKeyAgreementP751_AliceEvenNumber(t *testing.T) {
 /* -------------------------------------------------------------------------
    Wrappers for 'testing' module
    -------------------------------------------------------------------------*/
-func TestKeygen(t *testing.T)       { Do(testKeygen, t) }
-func TestRoundtrip(t *testing.T)    { Do(testRoundtrip, t) }
-func TestImportExport(t *testing.T) { Do(testImportExport, t) }
+func TestLookupParams(t *testing.T) {
+	for id := range tdata {
+		p, err := LookupParams(id)
+		if err != nil || p.Id != id {
+			t.Errorf("lookup of registered parameters %d failed", id)
+		}
+	}
+	if _, err := LookupParams(maxPrimeFieldId); err != ErrUnsupportedParams {
+		t.Error("lookup of unregistered parameters didn't fail")
+	}
+	if _, err := CreatePrivateKey(maxPrimeFieldId, KeyVariant_SIKE); err == nil {
+		t.Error("private key with unregistered parameters created")
+	}
+	if _, err := CreatePublicKey(maxPrimeFieldId, KeyVariant_SIKE); err == nil {
+		t.Error("public key with unregistered parameters created")
+	}
+	if _, err := CreatePublicKey(FP_503, KeyVariant_SIKE|KeyVariant_SIDH_A); err == nil {
+		t.Error("public key with invalid variant created")
+	}
+	prv, err := CreatePrivateKey(FP_503, KeyVariant_SIKE)
+	if err != nil || len(prv.S) != prv.Params().MsgLen {
+		t.Error("private key creation failed")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Params doesn't panic for unregistered parameters")
+		}
+	}()
+	Params(maxPrimeFieldId)
+}
+
+// Derivation procedure is fixed, as seeds may be stored instead of keys
+func TestDeriveKeyPairVectors(t *testing.T) {
+	seed := make([]byte, DeriveSeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	for v, exp := range map[KeyVariant]string{
+		KeyVariant_SIDH_A: "CCEA744B4B5C3FF3EB4D52F7996B71C7E0A04FED2D9FF525431D45619AFB0302",
+		KeyVariant_SIDH_B: "F29DDA63C0439067E188DA9F046CAF78AF6B3793E3E1B913EF0B4BC965E7AA0D",
+		KeyVariant_SIKE:   "8DD954F68F49F6D956082556056C06ACA396FA0A7D3857DF97D3A2CDABFAC338B78383258A1D9ED2D790F253FB27576D061FE5C7186E350B",
+	} {
+		prv, _, err := DeriveKeyPair(FP_503, v, seed)
+		checkErr(t, err, "key derivation failed")
+		if got := fmt.Sprintf("%X", prv.Export()); got != exp {
+			t.Errorf("unexpected private key of variant %d: %s", v, got)
+		}
+	}
+}
+
+func TestSupportedParams(t *testing.T) {
+	var found int
+	info := SupportedParams()
+	for i, v := range info {
+		if _, ok := tdata[v.Id]; ok {
+			found++
+		}
+		p := Params(v.Id)
+		if v.Name != p.Name || v.SecurityLevel != p.SecurityLevel || v.PublicKeySize != p.PublicKeySize {
+			t.Errorf("wrong description of %s", v.Name)
+		}
+		sk := NewPrivateKey(v.Id, KeyVariant_SIKE)
+		if v.SikePrivateKeySize != sk.Size()+p.PublicKeySize {
+			t.Errorf("wrong size of SIKE private key for %s", v.Name)
+		}
+		if i > 0 && info[i-1].PublicKeySize > v.PublicKeySize {
+			t.Error("parameter sets not ordered")
+		}
+	}
+	if found != len(tdata) {
+		t.Errorf("expected %d built-in parameter sets, got %d", len(tdata), found)
+	}
+}
+
+func TestRegisterParams(t *testing.T) {
+	for id := range tdata {
+		if err := checkParams(Params(id)); err != nil {
+			t.Errorf("built-in parameters %d rejected: %s", id, err)
+		}
+	}
+
+	if RegisterParams(*Params(FP_434)) == nil {
+		t.Error("parameters registered twice under the same ID")
+	}
+
+	// Parameters registered under custom ID can be used for key agreement
+	const customId = 0xF0
+	custom := *Params(FP_434)
+	custom.Id = customId
+	custom.Name = "custom"
+	if _, err := LookupParams(customId); err != nil {
+		checkErr(t, RegisterParams(custom), "registration failed")
+	}
+	testRoundtrip(t, customId)
+	prvA := convToPrv(tdata[FP_434].PrA, KeyVariant_SIDH_A, customId)
+	pubB := convToPub(tdata[FP_434].PkB, KeyVariant_SIDH_B, customId)
+	s1, err := DeriveSecret(prvA, pubB)
+	checkErr(t, err, "derivation of shared secret failed")
+	s2, err := DeriveSecret(convToPrv(tdata[FP_434].PrA, KeyVariant_SIDH_A, FP_434),
+		convToPub(tdata[FP_434].PkB, KeyVariant_SIDH_B, FP_434))
+	checkErr(t, err, "derivation of shared secret failed")
+	if !bytes.Equal(s1, s2) {
+		t.Error("custom parameters produce different shared secret")
+	}
+
+	// Inconsistent parameters
+	for i, modify := range []func(p *SidhParams){
+		func(p *SidhParams) { p.PublicKeySize++ },
+		func(p *SidhParams) { p.Bytelen++ },
+		func(p *SidhParams) { p.MsgLen = 20 },
+		func(p *SidhParams) { p.A.SecretBitLen-- },
+		func(p *SidhParams) { p.B.IsogenyStrategy = p.B.IsogenyStrategy[1:] },
+		func(p *SidhParams) { p.A.Affine_P = p.B.Affine_P },
+		func(p *SidhParams) { p.B.Affine_Q = p.B.Affine_R },
+		func(p *SidhParams) { p.InitCurve = Params(FP_503).InitCurve },
+		func(p *SidhParams) { p.Op = Params(FP_503).Op },
+	} {
+		p := *Params(FP_434)
+		p.Id = customId + 1
+		modify(&p)
+		if RegisterParams(p) == nil {
+			t.Errorf("inconsistent parameters %d registered", i)
+		}
+	}
+}
+
+func TestKeygen(t *testing.T)             { Do(testKeygen, t) }
+func TestRoundtrip(t *testing.T)          { Do(testRoundtrip, t) }
+func TestImportExport(t *testing.T)       { Do(testImportExport, t) }
+func TestPrivateKeyBelowMax(t *testing.T) { Do(testPrivateKeyBelowMax, t) }
+func TestCompression(t *testing.T)        { Do(testCompression, t) }
+func TestValidate(t *testing.T)           { Do(testValidate, t) }
+func TestDeriveKeyPair(t *testing.T)      { Do(testDeriveKeyPair, t) }
+func TestZeroize(t *testing.T)            { Do(testZeroize, t) }
+func TestPKIX(t *testing.T)               { Do(testPKIX, t) }
+func TestContainer(t *testing.T)          { Do(testContainer, t) }
+func TestJSON(t *testing.T)               { Do(testJSON, t) }
+func TestImportStrict(t *testing.T)       { Do(testImportStrict, t) }
 
 /* -------------------------------------------------------------------------
    Benchmarking
@@ -342,6 +1207,48 @@ func BenchmarkSidhKeyAgreementP503(b *testing.B) {
 	}
 }
 
+func BenchmarkSidhKeyAgreementP434(b *testing.B) {
+	// KeyPairs
+	alicePublic := convToPub(tdata[FP_434].PkA, KeyVariant_SIDH_A, FP_434)
+	bobPublic := convToPub(tdata[FP_434].PkB, KeyVariant_SIDH_B, FP_434)
+	alicePrivate := convToPrv(tdata[FP_434].PrA, KeyVariant_SIDH_A, FP_434)
+	bobPrivate := convToPrv(tdata[FP_434].PrB, KeyVariant_SIDH_B, FP_434)
+
+	for i := 0; i < b.N; i++ {
+		// Derive shared secret
+		DeriveSecret(bobPrivate, alicePublic)
+		DeriveSecret(alicePrivate, bobPublic)
+	}
+}
+
+func BenchmarkSidhKeyAgreementP610(b *testing.B) {
+	// KeyPairs
+	alicePublic := convToPub(tdata[FP_610].PkA, KeyVariant_SIDH_A, FP_610)
+	bobPublic := convToPub(tdata[FP_610].PkB, KeyVariant_SIDH_B, FP_610)
+	alicePrivate := convToPrv(tdata[FP_610].PrA, KeyVariant_SIDH_A, FP_610)
+	bobPrivate := convToPrv(tdata[FP_610].PrB, KeyVariant_SIDH_B, FP_610)
+
+	for i := 0; i < b.N; i++ {
+		// Derive shared secret
+		DeriveSecret(bobPrivate, alicePublic)
+		DeriveSecret(alicePrivate, bobPublic)
+	}
+}
+
+func BenchmarkSidhKeyAgreementP964(b *testing.B) {
+	// KeyPairs
+	alicePublic := convToPub(tdata[FP_964].PkA, KeyVariant_SIDH_A, FP_964)
+	bobPublic := convToPub(tdata[FP_964].PkB, KeyVariant_SIDH_B, FP_964)
+	alicePrivate := convToPrv(tdata[FP_964].PrA, KeyVariant_SIDH_A, FP_964)
+	bobPrivate := convToPrv(tdata[FP_964].PrB, KeyVariant_SIDH_B, FP_964)
+
+	for i := 0; i < b.N; i++ {
+		// Derive shared secret
+		DeriveSecret(bobPrivate, alicePublic)
+		DeriveSecret(alicePrivate, bobPublic)
+	}
+}
+
 func BenchmarkAliceKeyGenPrvP751(b *testing.B) {
 	prv := NewPrivateKey(FP_751, KeyVariant_SIDH_A)
 	for n := 0; n < b.N; n++ {
@@ -356,6 +1263,27 @@ func BenchmarkAliceKeyGenPrvP503(b *testing.B) {
 	}
 }
 
+func BenchmarkAliceKeyGenPrvP434(b *testing.B) {
+	prv := NewPrivateKey(FP_434, KeyVariant_SIDH_A)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
+func BenchmarkAliceKeyGenPrvP610(b *testing.B) {
+	prv := NewPrivateKey(FP_610, KeyVariant_SIDH_A)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
+func BenchmarkAliceKeyGenPrvP964(b *testing.B) {
+	prv := NewPrivateKey(FP_964, KeyVariant_SIDH_A)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
 func BenchmarkBobKeyGenPrvP751(b *testing.B) {
 	prv := NewPrivateKey(FP_751, KeyVariant_SIDH_B)
 	for n := 0; n < b.N; n++ {
@@ -370,6 +1298,27 @@ func BenchmarkBobKeyGenPrvP503(b *testing.B) {
 	}
 }
 
+func BenchmarkBobKeyGenPrvP434(b *testing.B) {
+	prv := NewPrivateKey(FP_434, KeyVariant_SIDH_B)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
+func BenchmarkBobKeyGenPrvP610(b *testing.B) {
+	prv := NewPrivateKey(FP_610, KeyVariant_SIDH_B)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
+func BenchmarkBobKeyGenPrvP964(b *testing.B) {
+	prv := NewPrivateKey(FP_964, KeyVariant_SIDH_B)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
 func BenchmarkAliceKeyGenPubP751(b *testing.B) {
 	prv := NewPrivateKey(FP_751, KeyVariant_SIDH_A)
 	prv.Generate(rand.Reader)
@@ -386,6 +1335,30 @@ func BenchmarkAliceKeyGenPubP503(b *testing.B) {
 	}
 }
 
+func BenchmarkAliceKeyGenPubP434(b *testing.B) {
+	prv := NewPrivateKey(FP_434, KeyVariant_SIDH_A)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey()
+	}
+}
+
+func BenchmarkAliceKeyGenPubP610(b *testing.B) {
+	prv := NewPrivateKey(FP_610, KeyVariant_SIDH_A)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey()
+	}
+}
+
+func BenchmarkAliceKeyGenPubP964(b *testing.B) {
+	prv := NewPrivateKey(FP_964, KeyVariant_SIDH_A)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey()
+	}
+}
+
 func BenchmarkBobKeyGenPubP751(b *testing.B) {
 	prv := NewPrivateKey(FP_751, KeyVariant_SIDH_B)
 	prv.Generate(rand.Reader)
@@ -402,6 +1375,30 @@ func BenchmarkBobKeyGenPubP503(b *testing.B) {
 	}
 }
 
+func BenchmarkBobKeyGenPubP434(b *testing.B) {
+	prv := NewPrivateKey(FP_434, KeyVariant_SIDH_B)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey()
+	}
+}
+
+func BenchmarkBobKeyGenPubP610(b *testing.B) {
+	prv := NewPrivateKey(FP_610, KeyVariant_SIDH_B)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey()
+	}
+}
+
+func BenchmarkBobKeyGenPubP964(b *testing.B) {
+	prv := NewPrivateKey(FP_964, KeyVariant_SIDH_B)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey()
+	}
+}
+
 func BenchmarkSharedSecretAliceP751(b *testing.B) {
 	aPr := convToPrv(tdata[FP_751].PrA, KeyVariant_SIDH_A, FP_751)
 	bPk := convToPub(tdata[FP_751].PkB, KeyVariant_SIDH_B, FP_751)
@@ -418,6 +1415,30 @@ func BenchmarkSharedSecretAliceP503(b *testing.B) {
 	}
 }
 
+func BenchmarkSharedSecretAliceP434(b *testing.B) {
+	aPr := convToPrv(tdata[FP_434].PrA, KeyVariant_SIDH_A, FP_434)
+	bPk := convToPub(tdata[FP_434].PkB, KeyVariant_SIDH_B, FP_434)
+	for n := 0; n < b.N; n++ {
+		DeriveSecret(aPr, bPk)
+	}
+}
+
+func BenchmarkSharedSecretAliceP610(b *testing.B) {
+	aPr := convToPrv(tdata[FP_610].PrA, KeyVariant_SIDH_A, FP_610)
+	bPk := convToPub(tdata[FP_610].PkB, KeyVariant_SIDH_B, FP_610)
+	for n := 0; n < b.N; n++ {
+		DeriveSecret(aPr, bPk)
+	}
+}
+
+func BenchmarkSharedSecretAliceP964(b *testing.B) {
+	aPr := convToPrv(tdata[FP_964].PrA, KeyVariant_SIDH_A, FP_964)
+	bPk := convToPub(tdata[FP_964].PkB, KeyVariant_SIDH_B, FP_964)
+	for n := 0; n < b.N; n++ {
+		DeriveSecret(aPr, bPk)
+	}
+}
+
 func BenchmarkSharedSecretBobP751(b *testing.B) {
 	// m_B = 3*randint(0,3^238)
 	aPk := convToPub(tdata[FP_751].PkA, KeyVariant_SIDH_A, FP_751)
@@ -435,3 +1456,30 @@ func BenchmarkSharedSecretBobP503(b *testing.B) {
 		DeriveSecret(bPr, aPk)
 	}
 }
+
+func BenchmarkSharedSecretBobP434(b *testing.B) {
+	// m_B = 3*randint(0,3^238)
+	aPk := convToPub(tdata[FP_434].PkA, KeyVariant_SIDH_A, FP_434)
+	bPr := convToPrv(tdata[FP_434].PrB, KeyVariant_SIDH_B, FP_434)
+	for n := 0; n < b.N; n++ {
+		DeriveSecret(bPr, aPk)
+	}
+}
+
+func BenchmarkSharedSecretBobP610(b *testing.B) {
+	// m_B = 3*randint(0,3^238)
+	aPk := convToPub(tdata[FP_610].PkA, KeyVariant_SIDH_A, FP_610)
+	bPr := convToPrv(tdata[FP_610].PrB, KeyVariant_SIDH_B, FP_610)
+	for n := 0; n < b.N; n++ {
+		DeriveSecret(bPr, aPk)
+	}
+}
+
+func BenchmarkSharedSecretBobP964(b *testing.B) {
+	// m_B = 3*randint(0,3^238)
+	aPk := convToPub(tdata[FP_964].PkA, KeyVariant_SIDH_A, FP_964)
+	bPr := convToPrv(tdata[FP_964].PrB, KeyVariant_SIDH_B, FP_964)
+	for n := 0; n < b.N; n++ {
+		DeriveSecret(bPr, aPk)
+	}
+}
@@ -0,0 +1,99 @@
+package sike
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	. "github.com/cloudflare/sidh/sidh"
+)
+
+// SIKEPrivateKey is a SIKE private key together with the corresponding
+// public key. It is encoded in the format of NIST reference implementation:
+// sk = s || sk || pk, so that a single blob is enough for decapsulation. The
+// layout is the same in round 1 and round 3 of SIKE.
+type SIKEPrivateKey struct {
+	prv *PrivateKey
+	pub *PublicKey
+}
+
+// NewSIKEPrivateKey allocates private key for parameter set id. Function
+// panics in case `id` wasn't registered.
+func NewSIKEPrivateKey(id uint8) *SIKEPrivateKey {
+	return &SIKEPrivateKey{
+		prv: NewPrivateKey(id, KeyVariant_SIKE),
+		pub: NewPublicKey(id, KeyVariant_SIKE),
+	}
+}
+
+// Generate generates private key and computes corresponding public key
+func (key *SIKEPrivateKey) Generate(rng io.Reader) error {
+	if err := key.prv.Generate(rng); err != nil {
+		return err
+	}
+	key.pub = key.prv.GeneratePublicKey()
+	return nil
+}
+
+// PrivateKey returns private part of the key
+func (key *SIKEPrivateKey) PrivateKey() *PrivateKey {
+	return key.prv
+}
+
+// PublicKey returns public key corresponding to the private key
+func (key *SIKEPrivateKey) PublicKey() *PublicKey {
+	return key.pub
+}
+
+// Size returns size of the encoded key
+func (key *SIKEPrivateKey) Size() int {
+	return key.prv.Size() + key.pub.Size()
+}
+
+// Export returns s || sk || pk
+func (key *SIKEPrivateKey) Export() []byte {
+	return append(key.prv.Export(), key.pub.Export()...)
+}
+
+// Import decodes key in NIST format s || sk || pk. Returns error in case
+// input has wrong size or pk doesn't correspond to sk, the key is not
+// modified then.
+func (key *SIKEPrivateKey) Import(input []byte) error {
+	if len(input) != key.Size() {
+		return errors.New("wrong size of private key")
+	}
+	id := key.prv.Params().Id
+	prv := NewPrivateKey(id, KeyVariant_SIKE)
+	pub := NewPublicKey(id, KeyVariant_SIKE)
+	if err := prv.Import(input[:prv.Size()]); err != nil {
+		return err
+	}
+	if err := pub.Import(input[prv.Size():]); err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(prv.GeneratePublicKey().Export(), input[prv.Size():]) != 1 {
+		prv.Zeroize()
+		return errors.New("public key doesn't match private key")
+	}
+	key.prv, key.pub = prv, pub
+	return nil
+}
+
+// Zeroize overwrites the private part of the key with zeros, see
+// PrivateKey.Zeroize.
+func (key *SIKEPrivateKey) Zeroize() {
+	key.prv.Zeroize()
+}
+
+// Destroy zeroizes the key and releases its buffers. The key must not be
+// used afterwards.
+func (key *SIKEPrivateKey) Destroy() {
+	key.prv.Destroy()
+	key.prv, key.pub = nil, nil
+}
+
+// Decapsulate works as Decapsulate function, using public key stored in the
+// key.
+func (key *SIKEPrivateKey) Decapsulate(ctext []byte) ([]byte, error) {
+	return decapsulate(key.prv, key.pub, ctext, false)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	// TODO: Use implementation from xcrypto, once PR below merged
 	// https://go-review.googlesource.com/c/crypto/+/111281/
+	. "github.com/cloudflare/sidh/internal/isogeny"
 	. "github.com/cloudflare/sidh/sidh"
 	cshake "github.com/henrydcase/nobs/hash/sha3"
 )
@@ -19,14 +20,77 @@ var G = []byte{0x00, 0x00}
 var H = []byte{0x01, 0x00}
 var F = []byte{0x02, 0x00}
 
-// Generates cShake-256 sum
-func cshakeSum(out, in, S []byte) {
-	h := cshake.NewCShake256(nil, S)
+// Returns hash function used by SIKE. Parameter sets following round 1 of
+// [SIKE] use cSHAKE256 with customization string S. Later revisions use
+// SHAKE256 and S is ignored.
+func newHash(params *SidhParams, S []byte) cshake.ShakeHash {
+	if params.SikeRound == SIKE_ROUND1 {
+		return cshake.NewCShake256(nil, S)
+	}
+	return cshake.NewShake256()
+}
+
+// Generates cShake-256 (or SHAKE-256) sum
+func cshakeSum(params *SidhParams, out, in, S []byte) {
+	h := newHash(params, S)
 	h.Write(in)
 	h.Read(out)
 }
 
-func encrypt(skA *PrivateKey, pkA, pkB *PublicKey, ptext []byte) ([]byte, error) {
+// Version identifies revision of [SIKE] followed by a parameter set. Revision
+// determines hash function, starting curve, base points and size of shared
+// secret. Encoding of keys and ciphertexts is the same in all revisions, in
+// particular secret key in NIST format is s || sk || pk (see SIKEPrivateKey).
+type Version uint8
+
+const (
+	// Round1 is the original 2017 submission: cSHAKE256 with customization
+	// strings G, H and F, starting curve E_0.
+	Round1 = Version(SIKE_ROUND1)
+	// Round3 is the round 3 submission, implemented by liboqs: SHAKE256,
+	// starting curve E_6.
+	Round3 = Version(SIKE_ROUND3)
+)
+
+// Parameter sets by revision of [SIKE] and bit length of the prime
+var versions = map[Version]map[int]uint8{
+	Round1: {503: FP_503, 751: FP_751},
+	Round3: {434: FP_434, 503: FP_503_R3, 610: FP_610, 751: FP_751_R3, 964: FP_964},
+}
+
+// SpecVersion returns revision of [SIKE] followed by parameter set id.
+// Function panics in case `id` wasn't registered.
+func SpecVersion(id uint8) Version {
+	return Version(Params(id).SikeRound)
+}
+
+// ParamsForVersion returns ID of the parameter set with prime of given bit
+// length, as specified by revision v of [SIKE]. For example
+// ParamsForVersion(503, Round3) returns FP_503_R3.
+func ParamsForVersion(bits int, v Version) (uint8, error) {
+	if id, ok := versions[v][bits]; ok {
+		return id, nil
+	}
+	return 0, errors.New("no such parameter set in this version of SIKE")
+}
+
+// Returns encoding of the public key, compressed or not.
+func exportKey(pub *PublicKey, compressed bool) ([]byte, error) {
+	if compressed {
+		return pub.ExportCompressed()
+	}
+	return pub.Export(), nil
+}
+
+// Returns size of the encoded ephemeral public key (c0) for given parameters.
+func ephemeralKeySize(params *SidhParams, compressed bool) int {
+	if compressed {
+		return NewPublicKey(params.Id, KeyVariant_SIDH_A).CompressedSize()
+	}
+	return params.PublicKeySize
+}
+
+func encrypt(skA *PrivateKey, pkA, pkB *PublicKey, ptext []byte, compressed bool) ([]byte, error) {
 	var n [40]byte // n can is max 320-bit (see 1.4 of [SIKE])
 	var ptextLen = len(ptext)
 
@@ -39,14 +103,19 @@ func encrypt(skA *PrivateKey, pkA, pkB *PublicKey, ptext []byte) ([]byte, error)
 		return nil, err
 	}
 
-	cshakeSum(n[:ptextLen], j, F)
+	cshakeSum(pkB.Params(), n[:ptextLen], j, F)
+	Zeroize(j)
 	for i, _ := range ptext {
 		n[i] ^= ptext[i]
 	}
 
-	ret := make([]byte, pkA.Size()+ptextLen)
-	copy(ret, pkA.Export())
-	copy(ret[pkA.Size():], n[:ptextLen])
+	c0, err := exportKey(pkA, compressed)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]byte, len(c0)+ptextLen)
+	copy(ret, c0)
+	copy(ret[len(c0):], n[:ptextLen])
 	return ret, nil
 }
 
@@ -54,49 +123,55 @@ func encrypt(skA *PrivateKey, pkA, pkB *PublicKey, ptext []byte) ([]byte, error)
 // PKE interface
 //
 
-// Uses SIKE public key to encrypt plaintext. Requires cryptographically secure PRNG
-// Returns ciphertext in case encryption succeeds. Returns error in case PRNG fails
-// or wrongly formated input was provided.
-func Encrypt(rng io.Reader, pub *PublicKey, ptext []byte) ([]byte, error) {
+func encryptWith(rng io.Reader, pub *PublicKey, ptext []byte, compressed bool) ([]byte, error) {
 	var params = pub.Params()
 	var ptextLen = len(ptext)
-	// c1 must be security level + 64 bits (see [SIKE] 1.4 and 4.3.3)
-	if ptextLen != (params.KemSize + 8) {
+	// c1 must be of the same length as SIKE secret message (see [SIKE] 1.4 and 4.3.3)
+	if ptextLen != params.MsgLen {
 		return nil, errors.New("Unsupported message length")
 	}
 
 	skA := NewPrivateKey(params.Id, KeyVariant_SIDH_A)
+	defer skA.Zeroize()
 	err := skA.Generate(rng)
 	if err != nil {
 		return nil, err
 	}
 
 	pkA := skA.GeneratePublicKey()
-	return encrypt(skA, pkA, pub, ptext)
+	return encrypt(skA, pkA, pub, ptext, compressed)
 }
 
-// Uses SIKE private key to decrypt ciphertext. Returns plaintext in case
-// decryption succeeds or error in case unexptected input was provided.
-// Constant time
-func Decrypt(prv *PrivateKey, ctext []byte) ([]byte, error) {
+// Decrypts ctext. If strict is set, uncompressed c0 must be encoded
+// canonically (see PublicKey.ImportStrict). Decapsulation doesn't need it, as
+// c0 is compared with the re-encrypted one.
+func decryptWith(prv *PrivateKey, ctext []byte, compressed, strict bool) ([]byte, error) {
 	var params = prv.Params()
 	var n [40]byte // n can is max 320-bit (see 1.4 of [SIKE])
 	var c1_len int
-	var pk_len = params.PublicKeySize
+	var pk_len = ephemeralKeySize(params, compressed)
+	var err error
 
 	if prv.Variant() != KeyVariant_SIKE {
 		return nil, errors.New("wrong key type")
 	}
 
 	// ctext is a concatenation of (pubkey_A || c1=ciphertext)
-	// it must be security level + 64 bits (see [SIKE] 1.4 and 4.3.3)
+	// c1 must be of the same length as SIKE secret message (see [SIKE] 1.4 and 4.3.3)
 	c1_len = len(ctext) - pk_len
-	if c1_len != (int(params.KemSize) + 8) {
+	if c1_len != params.MsgLen {
 		return nil, errors.New("wrong size of cipher text")
 	}
 
 	c0 := NewPublicKey(params.Id, KeyVariant_SIDH_A)
-	err := c0.Import(ctext[:pk_len])
+	switch {
+	case compressed:
+		err = c0.ImportCompressed(ctext[:pk_len])
+	case strict:
+		err = c0.ImportStrict(ctext[:pk_len])
+	default:
+		err = c0.Import(ctext[:pk_len])
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +180,8 @@ func Decrypt(prv *PrivateKey, ctext []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	cshakeSum(n[:c1_len], j, F)
+	cshakeSum(params, n[:c1_len], j, F)
+	Zeroize(j)
 	for i, _ := range n[:c1_len] {
 		n[i] ^= ctext[pk_len+i]
 	}
@@ -113,52 +189,96 @@ func Decrypt(prv *PrivateKey, ctext []byte) ([]byte, error) {
 	return n[:c1_len], nil
 }
 
+// Uses SIKE public key to encrypt plaintext. Requires cryptographically secure PRNG
+// Returns ciphertext in case encryption succeeds. Returns error in case PRNG fails
+// or wrongly formated input was provided.
+func Encrypt(rng io.Reader, pub *PublicKey, ptext []byte) ([]byte, error) {
+	return encryptWith(rng, pub, ptext, false)
+}
+
+// Uses SIKE private key to decrypt ciphertext. Returns plaintext in case
+// decryption succeeds or error in case unexptected input was provided.
+// Ciphertext with non-canonical encoding of c0 is rejected, the error is
+// *CoordinateError then. Constant time
+func Decrypt(prv *PrivateKey, ctext []byte) ([]byte, error) {
+	return decryptWith(prv, ctext, false, true)
+}
+
+// Same as Encrypt, but c0 part of the ciphertext is a compressed public
+// key (see PublicKey.ExportCompressed).
+//
+// Not constant time.
+func EncryptCompressed(rng io.Reader, pub *PublicKey, ptext []byte) ([]byte, error) {
+	return encryptWith(rng, pub, ptext, true)
+}
+
+// Decrypts ciphertext produced by EncryptCompressed.
+//
+// Not constant time.
+func DecryptCompressed(prv *PrivateKey, ctext []byte) ([]byte, error) {
+	return decryptWith(prv, ctext, true, false)
+}
+
 // -----------------------------------------------------------------------------
 // KEM interface
 //
 
-// Encapsulation receives the public key and generates SIKE ciphertext and shared secret.
-// The generated ciphertext is used for authentication.
-// The rng must be cryptographically secure PRNG.
-// Error is returned in case PRNG fails or wrongly formated input was provided.
-func Encapsulate(rng io.Reader, pub *PublicKey) (ctext []byte, secret []byte, err error) {
-	var params = pub.Params()
+func encapsulate(rng io.Reader, pub *PublicKey, compressed bool) (ctext []byte, secret []byte, err error) {
 	// Buffer for random, secret message
-	var ptext = make([]byte, params.MsgLen)
+	var ptext = make([]byte, pub.Params().MsgLen)
+
+	// Generate ephemeral value
+	_, err = io.ReadFull(rng, ptext)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer Zeroize(ptext)
+	return encapsulateWith(pub, ptext, compressed)
+}
+
+func encapsulateWith(pub *PublicKey, ptext []byte, compressed bool) (ctext []byte, secret []byte, err error) {
+	var params = pub.Params()
 	// r = G(ptext||pub)
 	var r = make([]byte, params.A.SecretByteLen)
 	// Resulting shared secret
 	secret = make([]byte, params.KemSize)
 
-	// Generate ephemeral value
-	_, err = io.ReadFull(rng, ptext)
+	if len(ptext) != params.MsgLen {
+		return nil, nil, errors.New("wrong size of message")
+	}
+
+	pkB, err := exportKey(pub, compressed)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	h := cshake.NewCShake256(nil, G)
+	h := newHash(params, G)
 	h.Write(ptext)
-	h.Write(pub.Export())
+	h.Write(pkB)
 	h.Read(r)
 
-	// cSHAKE256 implementation is byte oriented. Ensure bitlength is not bigger then to 2^e2-1
-	r[len(r)-1] &= (1 << (params.A.SecretBitLen % 8)) - 1
+	// Hash implementation is byte oriented. Ensure bitlength is not bigger then to 2^e2-1
+	if params.A.SecretBitLen%8 != 0 {
+		r[len(r)-1] &= (1 << (params.A.SecretBitLen % 8)) - 1
+	}
 
 	// (c0 || c1) = Enc(pkA, ptext; r)
 	skA := NewPrivateKey(params.Id, KeyVariant_SIDH_A)
+	defer skA.Zeroize()
 	err = skA.Import(r)
+	Zeroize(r)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	pkA := skA.GeneratePublicKey()
-	ctext, err = encrypt(skA, pkA, pub, ptext)
+	ctext, err = encrypt(skA, pkA, pub, ptext, compressed)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// K = H(ptext||(c0||c1))
-	h = cshake.NewCShake256(nil, H)
+	h = newHash(params, H)
 	h.Write(ptext)
 	h.Write(ctext)
 	h.Read(secret)
@@ -166,39 +286,48 @@ func Encapsulate(rng io.Reader, pub *PublicKey) (ctext []byte, secret []byte, er
 	return ctext, secret, nil
 }
 
-// Decapsulate given the keypair and ciphertext as inputs, Decapsulate outputs a shared
-// secret if plaintext verifies correctly, otherwise function outputs random value.
-// Decapsulation may fail in case input is wrongly formated.
-// Constant time for properly initialized input.
-func Decapsulate(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error) {
+func decapsulate(prv *PrivateKey, pub *PublicKey, ctext []byte, compressed bool) ([]byte, error) {
 	var params = pub.Params()
 	var r = make([]byte, params.A.SecretByteLen)
 	// Resulting shared secret
 	var secret = make([]byte, params.KemSize)
 	var skA = NewPrivateKey(params.Id, KeyVariant_SIDH_A)
+	defer skA.Zeroize()
+
+	m, err := decryptWith(prv, ctext, compressed, false)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(m)
 
-	m, err := Decrypt(prv, ctext)
+	pkB, err := exportKey(pub, compressed)
 	if err != nil {
 		return nil, err
 	}
 
 	// r' = G(m'||pub)
-	h := cshake.NewCShake256(nil, G)
+	h := newHash(params, G)
 	h.Write(m)
-	h.Write(pub.Export())
+	h.Write(pkB)
 	h.Read(r)
 
-	// cSHAKE256 implementation is byte oriented: Ensure bitlength is not bigger than 2^e2-1
-	r[len(r)-1] &= (1 << (params.A.SecretBitLen % 8)) - 1
+	// Hash implementation is byte oriented: Ensure bitlength is not bigger than 2^e2-1
+	if params.A.SecretBitLen%8 != 0 {
+		r[len(r)-1] &= (1 << (params.A.SecretBitLen % 8)) - 1
+	}
 
 	// Never fails
 	skA.Import(r)
+	Zeroize(r)
 
 	// Never fails
 	pkA := skA.GeneratePublicKey()
-	c0 := pkA.Export()
+	c0, err := exportKey(pkA, compressed)
+	if err != nil {
+		return nil, err
+	}
 
-	h = cshake.NewCShake256(nil, H)
+	h = newHash(params, H)
 	if subtle.ConstantTimeCompare(c0, ctext[:len(c0)]) == 1 {
 		h.Write(m)
 	} else {
@@ -215,3 +344,45 @@ func Decapsulate(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error)
 	h.Read(secret)
 	return secret, nil
 }
+
+// Encapsulation receives the public key and generates SIKE ciphertext and shared secret.
+// The generated ciphertext is used for authentication.
+// The rng must be cryptographically secure PRNG.
+// Error is returned in case PRNG fails or wrongly formated input was provided.
+func Encapsulate(rng io.Reader, pub *PublicKey) (ctext []byte, secret []byte, err error) {
+	return encapsulate(rng, pub, false)
+}
+
+// EncapsulateDeterministically works as Encapsulate, but uses m as the
+// secret message instead of reading it from rng. m must be exactly MsgLen
+// bytes long. Intended for test harnesses and comparison with other
+// implementations: security of the KEM requires m to be random and secret.
+func EncapsulateDeterministically(pub *PublicKey, m []byte) (ctext []byte, secret []byte, err error) {
+	return encapsulateWith(pub, m, false)
+}
+
+// Decapsulate given the keypair and ciphertext as inputs, Decapsulate outputs a shared
+// secret if plaintext verifies correctly, otherwise function outputs random value.
+// Decapsulation may fail in case input is wrongly formated.
+// Constant time for properly initialized input.
+func Decapsulate(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error) {
+	return decapsulate(prv, pub, ctext, false)
+}
+
+// EncapsulateCompressed works as Encapsulate, but uses compressed encodings
+// of the public key and ephemeral public key c0 (see
+// PublicKey.ExportCompressed). Ciphertext is a concatenation of compressed c0
+// and c1. It doesn't interoperate with SIKE-compressed.
+//
+// Not constant time.
+func EncapsulateCompressed(rng io.Reader, pub *PublicKey) (ctext []byte, secret []byte, err error) {
+	return encapsulate(rng, pub, true)
+}
+
+// DecapsulateCompressed works as Decapsulate, but expects ciphertext produced
+// by EncapsulateCompressed.
+//
+// Not constant time.
+func DecapsulateCompressed(prv *PrivateKey, pub *PublicKey, ctext []byte) ([]byte, error) {
+	return decapsulate(prv, pub, ctext, true)
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 
 	rand "crypto/rand"
+	"github.com/cloudflare/sidh/internal/drbg"
 	. "github.com/cloudflare/sidh/sidh"
 )
 
@@ -31,16 +32,56 @@ var tdata = map[uint8]struct {
 	PkB     string
 	PrB     string
 }{
+	FP_434: {
+		"P-434",
+		"../etc/PQCkemKAT_374.rsp",
+		"4484D7AADB44B40CC180DC568B2C142A60E6E2863F5988614A6215254B2F5F6F79B48F329AD1A2DED20B7ABAB10F7DBF59C3E20B59A700093060D2A44ACDC0083A53CF0808E0B3A827C45176BEE0DC6EC7CC16461E38461C12451BB95191407C1E942BB50D4C7B25A49C644B630159E6C403653838E689FBF4A7ADEA693ED0657BA4A724786AF7953F7BA6E15F9BBF9F5007FB711569E72ACAB05D3463A458536CAB647F00C205D27D5311B2A5113D4B26548000DB237515931A040804E769361F94FF0167C78353D2630A1E6F595A1F80E87F6A5BCD679D7A64C5006F6191D4ADEFA1EA67F6388B7017D453F4FE2DFE80CCC709000B52175BFC3ADE52ECCB0CEBE1654F89D39131C357EACB61E5F13C80AB0165B7714D6BE6DF65F8DE73FF47B7F3304639F0903653ECCFA252F6E2104C4ABAD3C33AF24FD0E56F58DB92CC66859766035419AB2DF600",
+		"7C9935A0B07694AA0C6D10E4DB6B1ADD91282214654CB55E7C2CACD53919604D5BAC7B23EEF4B315FEEF5E01"},
 	FP_503: {
 		"P-503",
 		"../etc/PQCkemKAT_434.rsp",
 		"68460C22466E95864CFEA7B5D9077E768FF4F9ED69AE56D7CF3F236FB06B31020EEE34B5B572CEA5DDF20B531966AA8F5F3ACC0C6D1CE04EEDC30FD1F1233E2D96FE60C6D638FC646EAF2E2246F1AEC96859CE874A1F029A78F9C978CD6B22114A0D5AB20101191FD923E80C76908B1498B9D0200065CCA09159A0C65A1E346CC6470314FE78388DAA89DD08EC67DBE63C1F606674ACC49EBF9FDBB2B898B3CE733113AA6F942DB401A76D629CE6EE6C0FDAF4CFB1A5E366DB66C17B3923A1B7FB26A3FF25B9018869C674D3DEF4AF269901D686FE4647F9D2CDB2CEB3AFA305B27C885F037ED167F595066C21E7DD467D8332B934A5102DA5F13332DFA356B82156A0BB2E7E91C6B85B7D1E381BC9E3F0FC4DB9C36016D9ECEC415D7E977E9AC29910D934BA2FE4EE49D3B387607A4E1AFABF495FB86A77194626589E802FF5167C7A25C542C1EAD25A6E0AA931D94F2F9AFD3DBDF222E651F729A90E77B20974905F1E65E041CE6C95AAB3E1F22D332E0A5DE9C5DB3D9C7A38",
 		"80FC55DA74DEFE3113487B80841E678AF9ED4E0599CF07353A4AB93971C090A0A9402C9DC98AC6DC8F5FDE5E970AE22BA48A400EFC72851C"},
+	// Official KAT of SIKEp610 (round 3 submission) isn't available in etc/
+	// yet, TestSIKE_KAT fails for P-610 until it is added. PkB and PrB are
+	// taken from testdata/SIKEp610_regression.rsp.
+	FP_610: {
+		"P-610",
+		"../etc/PQCkemKAT_524.rsp",
+		"2A823B5B57D1E4C2229E5EE0B0ABA035E1F774AEAA369C05BE793F133176F62E8DA3C49C5D2950FED8AE619C1A0F3B9B3DE3D9FA8FA145DBD49D1F1BFA13BE712326FE38DBA1EE6DC9B087650127526A3CBF82C51E19C5519DF559D1F9C93F9BB6D2E6EA9A5DC99AC5910DC42B5C5FB6773A84CD3342D4DCFCB828364C8C48037D327B815BA8A5F10F098BEDACD793434AEF2DAA9A458FE1D8000FEEEC86DE47AF7EC96A7A1DB8AF991238968F4CEA8DD4799C7FF2F888B419F6A2EF203285DEAF24612692E8FDAA072A76780989E9A6468AEEF11C622761DAE131578F6263CF60BA8B7E6C620181D86705962BBAA6AEA87752BD3A93BF24DBE6148CBF4A27C386C86653808BD6AA6614E8A83B90D0767796FB5AADC5028D717816D7588C6390B34148CA04ED12DC1CC1E62966F33CC7C605570135412A7F298F536A12B2165DD0B203EAF120B17A42CE6FED5831C8DE0DA7B81610EA7D18AEB003C4F31B5E27B79E0846A9384B4EC75E349B387CF9B606594BB93D8DA8011ACE902035D50A4100B76F1C33711058A9C26BC3508C6D37A9762AE6092F76DE4A1C21B8D25A5555BC76D1E404E956D375FFFED5B3FC3715D8C95737EA237E37BB048BC3B8ACD9CCF75374BA06AF9852125F26A84601",
+		"7C9935A0B07694AA0C6D10E4DB6B1ADD2FD81A25CCB148038626ED79D451140800E03B59B956F8210E556067407D13DC90FA9E8B872BFB8FAB0A72898521"},
 	FP_751: {
 		"P-751",
 		"../etc/PQCkemKAT_644.rsp",
 		"7C55E268665504B9A11A1B30B4363A4957960AD015A7B74DF39FB0141A95CC51A4BEBBB48452EF0C881220D68CB5FF904C0549F05F06BF49A520E684DD610A7E121B420C751B789BDCDB8B6EC136BA0CE74EB6904906057EA7343839EA35FAF2C3D7BE76C81DCA4DF0850CE5F111FF9FF97242EC5520310D7F90A004BACFD75408CBFE8948232A9CCF035136DE3691D9BEF110C3081AADF0D2328CE2CC94998D8AE94D6575083FAFA045F50201FCE841D01C214CC8BBEFCC701484215EA70518204C76A0DA89BEAF0B066F6FD9E78A2C908CF0AFF74E0B55477190F918397F0CF3A537B7911DA846196AD914114A15C2F3C1062D78B19D23348C3D3D4A9C2B2018B382CC44544DA2FA263EB6212D2D13F254216DE002D4AEA55C75C5349A681D7A809BCC29C4CAE1168AC790321FF7429FAAC2FC09465F93E10B9DD970901A1B1D045DDAC9D7B901E00F29AA9F2C87C8EF848E80B7B290ECF85D6BB4C7E975A939A7AFB63069F900A75C9B7B71C2E7472C21A87AB604B6372D4EBEC5974A711281A819636D8FA3E6608F2B81F35599BBB4A1EB5CBD8F743587550F8CE3A809F5C9C399DD52B2D15F217A36F3218C772FD4E67F67D526DEBE1D31FEC4634927A873A1A6CFE55FF1E35AB72EBBD22E3CDD9D2640813345015BB6BD25A6977D0391D4D78998DD178155FEBF247BED3A9F83EAF3346BA90098B908B2359B60491C94330626709D235D1CFB7C87DCA779CFBA23DA280DC06FAEA0FDB3773B0C6391F889D803B7C04AC6AB27375B440336789823176C57",
 		"00010203040506070809000102030405060708090001020304050607080901028626ED79D451140800E03B59B956F8210E556067407D13DC90FA9E8B872BFB8FAB0A7289852106E40538D3575C500201"},
+	FP_503_R3: {
+		"P-503r3",
+		"../etc/PQCkemKAT_434_r3.rsp",
+		"05279D27FF7E3A38ABB05DCFE23B5831C030D832D3EAE35FE06A6538597532D22A0F4012FB2263E160495F8291B58D9DF8A8947C7CF3E6735520BB2D094912408829851AC4B85AA922069F2AAA0A4827DFA4730E9CF05485CBEE411C3D5169DD4953746B6A2E6574957EF920596B1612BE62A883740B5A0C157117AE1C3A07E4CE8CCCE7E9E88FE7C20A507FF019AE0893F34303E173D291F6CB7ECB4C3901FF34A48DE40771F5BAD72DA2B4C1CFD0A61F33E39327A8DA60F5640D4C2E71EF9C7297A4E9BC50493E3BA65D3664610A6D61035CB6600378D017D1E1810ACD113252D60F5915749C2B5CFB4452C40C86F1F40C63297DCCA900686F2D2266F9444539D9BA13B1F52FB2FC3BD4F3EDAA6EB707AAFCA5261EA271ED961B2ED195D5E3B0299179251866CE0EAA31C5C90B7999A8D63BA2DE84A8AFA19F11F2DC0CACA39B982CE053F71D269931D9EE26BCE592A8EA818553BC8F8D244F62FB4F5E5386E3EFF5CD231401C9EC2BA57FF42DC3B3791357A53E1E31394008",
+		"7C9935A0B07694AA0C6D10E4DB6B1ADD2FD81A25CCB148038626ED79D451140800E03B59B956F8210E556067407D13DC90FA9E8B872BFB0F"},
+	FP_751_R3: {
+		"P-751r3",
+		"../etc/PQCkemKAT_644_r3.rsp",
+		"E1A758EC0D418BFE86D8077B5BB169133C06C1F2A067D8B202D9D058FFC51F63FD26155A6577C74BA7F1A27E7BA51982517B923615DEB00BE408920A07831DF5978CFDDD0BF690A264353A4A16B666F90586D7F89A193CE09375D389C1379A7A528581C3ACB002CD2DC4F0FD672568FF9050BA8365C7FEFC5E6ED089B921DE6804091A0744DE3EB14D426A3F7DA215C50312617C1C2697243980D06056F2CCE88AE7AE73C7343C0B7104C9F2870A94FED744CF6E94630514B6CEAB0E64733BB6FA67B931E5D8206010475CBE8BC587248D65D89D8CD9C8BBFA93E8B5F9EB9130773DED665D52ABBD91C4C8C255F73C0FC82501AE33330E9F308DE7177CBF83E4E26E334D7CB09019E638147FC58ED372AF660F14C194BC80E9666325C98E0F80877271D4A6BF514F603703D8A697874CD50A34D92F5AAEA84633CCF96801BD517BF425DEE4A32AAF06684052473EA14643C3D535440FB2240A988D09F297C5A388CB3DE60ED943F124034B90EFF611221F80F78EC124956338A105F6636B063D7E48BFBD5D614310FB97D86F122E4AE6F9DDF4977A93ED7D0CE2A94E346A1A03D3219CF21907B85A5BCDC713F93A4406A22E03B1655A66E1F6741A2F953E6FE0868B2614BABEF1943BBBCB1B66D3E7017E533EA84F291240B56AB33EF1DC3F3DE99DBF9E8BE51A0076E462BCDD825EA96D7F63C99177C305C257B31461F4C23D43115F0220409E8880BBB2468586D03461E807BE824B693874911B2B52AF06FDBDC47F5A0159729641A7C950AB9E03F2DC045135",
+		"7C9935A0B07694AA0C6D10E4DB6B1ADD2FD81A25CCB148032DCD739936737F2D8626ED79D451140800E03B59B956F8210E556067407D13DC90FA9E8B872BFB8FAB0A7289852106E40538D3575C500201"},
+	// No official KAT, PkB and PrB are taken from
+	// testdata/SIKEp964_regression.rsp.
+	FP_964: {
+		"P-964",
+		"",
+		"B569D60E8E628509B3110613F703B20626B93148A714E656B4B071C104B3BE14331857F44091CB3EEF34E692A2573AFC056A9587CDD9B07D376D026428D1726A7657907C7A4C48B38718F5913D613A8FFCC3A2D65B4747FE30346D64613E39A2A408E56AC8C39AD4FEE57DCDEE91A25F1CC0E5B08EB3FB3A07C7D4F5AEC1F70C4576F519EEC45C6EED74067F018B97577AE67AE37CE464BEBEFC7F52EB74073CC137DFE52F7B2A610FEB21EEF928A9ABCFDB58D4816D0BEBEC07F7FBA3BBEDF7CBF201F99404A7D614088A7F9CCFD0E4472024CD15642624A888489D48AFDD093F147D8E62DF384DAFDEC861E8694EE89D07DD62AAD11A4A21F0264D5B49516E7568D45D03CC1915C218399B9AEE278416CF5B42D8DEB949188C6120DF41DA75E4A9A4079797BABE94D5E989D638B470744867801C64E56FBEC6E8DC87E5DB83EC0559D9ACB5974BDA1BB18F2B75D008F4B7A903B26DE55D69D78F22605ADC03A6F18C58D8769C2E1B5C03DF3CA5FDD857BAFA1382F3E2DEC239B1F0DBD74D50CD24A82FD2C85F82F22B339A2018119FD8C66204ACAC2AEC2E2BB69256B633172242440A99A0560AAB25205349CD70E3040C73F7381E6893C9461B30A7C66FF180728BF8A5FFFE323A295B52C5830C79851439FE8962734E31ACC53AD37D311D2AC0790344F0B37427C80D137E15E96A63AAD635F5B07068ADCD36FA2309BBB8ED69559471037C2F14F3C9F0169552F28F60CB66BA5E28E3DC2795E839B6504F22F2020E12ECA5DA4CFC2E64E41D640A10E291074FBEB6A2922FB72672F1154A45CD5BC20D359CF3D1512FCFA461AB55A10EFB4D4A774AEC3C10447E079468CD387B2AA493FAF42E8F61434923C74CAF534A44D5693D81FFA2D4B32F97E4B7B721B28381D11884A4169E1A6ADD11C542F267A0086D193FFCE0065796B691823D19FF3C58835BCD7349A6492C623A3DF0E972B7DF7326F803317DF8EA934C68A1E4742C11DF549E3252D3F3693C4CAAC3A2A621E57306",
+		"7C9935A0B07694AA0C6D10E4DB6B1ADD2FD81A25CCB148032DCD739936737F2D8626ED79D451140800E03B59B956F8210E556067407D13DC90FA9E8B872BFB8FAB0A7289852106E40538D3575C50028DA0E37A216DD514EDD890120F"},
+}
+
+// Regression vectors for compressed keys, generated by this implementation
+// (there are no known answer tests for this encoding). Public key is
+// compressed, secret key is a concatenation of MSG + SECRET_BOB_KEY +
+// COMPRESSED_PUBLIC_BOB_KEY and ciphertext is a concatenation of compressed
+// c0 and c1.
+var tdataCompressed = map[uint8]string{
+	FP_434: "testdata/SIKEp434_compressed_keys_regression.rsp",
+	FP_503: "testdata/SIKEp503_compressed_keys_regression.rsp",
 }
 
 // Fail if err !=nil. Display msg as an error message
@@ -126,7 +167,7 @@ func testNegativePKE(t *testing.T, id uint8) {
 	pk := sk.GeneratePublicKey()
 
 	// bytelen(msg) - 1
-	ct, err := Encrypt(rand.Reader, pk, msg[:params.KemSize+8-1])
+	ct, err := Encrypt(rand.Reader, pk, msg[:params.MsgLen-1])
 	if err == nil {
 		t.Fatal("Error hasn't been returned")
 	}
@@ -134,14 +175,29 @@ func testNegativePKE(t *testing.T, id uint8) {
 		t.Fatal("Ciphertext must be nil")
 	}
 
-	// KemSize - 1
-	pt, err := Decrypt(sk, msg[:params.KemSize+8-1])
+	// MsgLen - 1
+	pt, err := Decrypt(sk, msg[:params.MsgLen-1])
 	if err == nil {
 		t.Fatal("Error hasn't been returned")
 	}
 	if pt != nil {
 		t.Fatal("Ciphertext must be nil")
 	}
+
+	// c0 with coordinate xQ not reduced modulo p
+	ct, err = Encrypt(rand.Reader, pk, msg[:params.MsgLen])
+	checkErr(t, err, "encryption failed")
+	for i := 0; i < params.Bytelen; i++ {
+		ct[params.SharedSecretSize+i] = 0xFF
+	}
+	pt, err = Decrypt(sk, ct)
+	if cerr, ok := err.(*CoordinateError); !ok || cerr.Coordinate != "xQ" || pt != nil {
+		t.Fatalf("non-canonical c0 accepted: %v", err)
+	}
+	// Decapsulation rejects it implicitly
+	if _, err = Decapsulate(sk, pk, ct); err != nil {
+		t.Fatalf("decapsulation failed: %v", err)
+	}
 }
 
 func testKEMRoundTrip(t *testing.T, pkB, skB []byte, id uint8) {
@@ -227,6 +283,169 @@ func testNegativeKEM(t *testing.T, id uint8) {
 
 // In case invalid ciphertext is provided, SIKE's decapsulation must
 // return same (but unpredictable) result for a given key.
+func testEncapsulateDeterministically(t *testing.T, id uint8) {
+	prv := NewPrivateKey(id, KeyVariant_SIKE)
+	checkErr(t, prv.Generate(rand.Reader), "key generation failed")
+	pub := prv.GeneratePublicKey()
+
+	m := make([]byte, pub.Params().MsgLen)
+	rand.Read(m)
+	mCopy := append([]byte{}, m...)
+	ct1, ss1, err := EncapsulateDeterministically(pub, m)
+	checkErr(t, err, "encapsulation failed")
+	ct2, ss2, err := Encapsulate(bytes.NewReader(m), pub)
+	checkErr(t, err, "encapsulation failed")
+	if !bytes.Equal(ct1, ct2) || !bytes.Equal(ss1, ss2) || !bytes.Equal(m, mCopy) {
+		t.Error("result differs from Encapsulate with the same message")
+	}
+
+	ss3, err := Decapsulate(prv, pub, ct1)
+	checkErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss1, ss3) {
+		t.Error("shared secrets differ")
+	}
+
+	for _, sz := range []int{0, len(m) - 1, len(m) + 1} {
+		if _, _, err = EncapsulateDeterministically(pub, make([]byte, sz)); err == nil {
+			t.Errorf("message of size %d accepted", sz)
+		}
+	}
+}
+
+func testSIKEPrivateKey(t *testing.T, id uint8) {
+	key := NewSIKEPrivateKey(id)
+	checkErr(t, key.Generate(rand.Reader), "key generation failed")
+	params := Params(id)
+	if key.Size() != params.MsgLen+int(params.B.SecretByteLen)+params.PublicKeySize {
+		t.Errorf("wrong size of private key: %d", key.Size())
+	}
+	for _, info := range SupportedParams() {
+		if info.Id == id && info.SikePrivateKeySize != key.Size() {
+			t.Errorf("SupportedParams: wrong size of private key: %d", info.SikePrivateKeySize)
+		}
+	}
+	ct, ss, err := Encapsulate(rand.Reader, key.PublicKey())
+	checkErr(t, err, "encapsulation failed")
+
+	// Only the encoded key is needed for decapsulation
+	sk := key.Export()
+	key2 := NewSIKEPrivateKey(id)
+	checkErr(t, key2.Import(sk), "import failed")
+	ss2, err := key2.Decapsulate(ct)
+	checkErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss, ss2) {
+		t.Error("shared secrets differ")
+	}
+
+	// Public key must match private key. Key is not modified on error.
+	other := NewSIKEPrivateKey(id)
+	checkErr(t, other.Generate(rand.Reader), "key generation failed")
+	bad := append(other.Export()[:key.PrivateKey().Size()], key.PublicKey().Export()...)
+	if key2.Import(bad) == nil {
+		t.Error("key with wrong public key accepted")
+	}
+	if key2.Import(sk[1:]) == nil {
+		t.Error("key of wrong size accepted")
+	}
+	if !bytes.Equal(key2.Export(), sk) {
+		t.Error("key modified by failed import")
+	}
+}
+
+// Reader which remembers buffers it has filled
+type recordingReader struct {
+	bufs [][]byte
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	r.bufs = append(r.bufs, p)
+	return rand.Read(p)
+}
+
+// Reader which fills all but the last byte of the first buffer and fails
+type failingReader struct {
+	recordingReader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	r.bufs = append(r.bufs, p)
+	if len(r.bufs) > 1 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	for i := range p {
+		p[i] = 0xFF
+	}
+	return len(p) - 1, nil
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Secret message of KEM and ephemeral key of PKE are wiped after use
+func testEphemeralZeroized(t *testing.T, id uint8) {
+	key := NewSIKEPrivateKey(id)
+	checkErr(t, key.Generate(rand.Reader), "key generation failed")
+	params := key.PublicKey().Params()
+
+	rng := &recordingReader{}
+	_, _, err := Encapsulate(rng, key.PublicKey())
+	checkErr(t, err, "encapsulation failed")
+	if len(rng.bufs) != 1 || len(rng.bufs[0]) != params.MsgLen || !isZero(rng.bufs[0]) {
+		t.Error("message not wiped by Encapsulate")
+	}
+
+	rng = &recordingReader{}
+	_, err = Encrypt(rng, key.PublicKey(), make([]byte, params.MsgLen))
+	checkErr(t, err, "encryption failed")
+	if len(rng.bufs) != 1 || len(rng.bufs[0]) != int(params.A.SecretByteLen) || !isZero(rng.bufs[0]) {
+		t.Error("ephemeral key not wiped by Encrypt")
+	}
+
+	// Also when generation of the ephemeral key fails
+	frng := &failingReader{}
+	if _, err = Encrypt(frng, key.PublicKey(), make([]byte, params.MsgLen)); err == nil {
+		t.Error("encryption with failing rng succeeded")
+	}
+	if len(frng.bufs) == 0 || !isZero(frng.bufs[0]) {
+		t.Error("partially generated ephemeral key not wiped by Encrypt")
+	}
+
+	// Caller's message is left untouched
+	m := []byte(strings.Repeat("m", params.MsgLen))
+	_, _, err = EncapsulateDeterministically(key.PublicKey(), m)
+	checkErr(t, err, "encapsulation failed")
+	if string(m) != strings.Repeat("m", params.MsgLen) {
+		t.Error("message modified by EncapsulateDeterministically")
+	}
+}
+
+func testSIKEPrivateKeyDestroy(t *testing.T, id uint8) {
+	key := NewSIKEPrivateKey(id)
+	checkErr(t, key.Generate(rand.Reader), "key generation failed")
+	scalar, s := key.PrivateKey().Scalar, key.PrivateKey().S
+
+	key.Zeroize()
+	if !isZero(scalar) || !isZero(s) || !isZero(key.PrivateKey().Export()) {
+		t.Error("key not zeroized")
+	}
+	checkErr(t, key.Generate(rand.Reader), "key generation failed")
+	scalar, s = key.PrivateKey().Scalar, key.PrivateKey().S
+	if isZero(scalar) {
+		t.Error("zeroized key can't be reused")
+	}
+
+	key.Destroy()
+	if !isZero(scalar) || !isZero(s) || key.PrivateKey() != nil || key.PublicKey() != nil {
+		t.Error("key not destroyed")
+	}
+}
+
 func testNegativeKEMSameWrongResult(t *testing.T, id uint8) {
 	sk := NewPrivateKey(id, KeyVariant_SIKE)
 	checkErr(t, sk.Generate(rand.Reader), "error: key generation")
@@ -282,7 +501,7 @@ func readAndCheckLine(r *bufio.Reader) []byte {
 	return ret
 }
 
-func testKeygen(pk, sk []byte, id uint8) bool {
+func testKeygen(pk, sk []byte, id uint8, compressed bool) bool {
 	// Import provided private key
 	var prvKey = NewPrivateKey(id, KeyVariant_SIKE)
 	if prvKey.Import(sk) != nil {
@@ -291,17 +510,66 @@ func testKeygen(pk, sk []byte, id uint8) bool {
 
 	// Generate public key
 	pubKey := prvKey.GeneratePublicKey()
+	if compressed {
+		exp, err := pubKey.ExportCompressed()
+		return err == nil && bytes.Equal(exp, pk)
+	}
 	return bytes.Equal(pubKey.Export(), pk)
 }
 
-func testDecapsulation(pk, sk, ct, ssExpected []byte, id uint8) bool {
+// Generates keypair and encapsulates, with NIST DRBG initialized with
+// seed as a source of randomness. Then checks results against test vector.
+// sk is a concatenation of MSG + SECRET_BOB_KEY + PUBLIC_BOB_KEY.
+func testGeneration(seed, pk, sk, ct, ss []byte, id uint8, compressed bool) (keygenOK, encapsOK bool) {
+	rng := drbg.New(seed, nil)
+	prvKey := NewPrivateKey(id, KeyVariant_SIKE)
+	if prvKey.Generate(rng) != nil {
+		panic("sike test: can't generate private key")
+	}
+	pubKey := prvKey.GeneratePublicKey()
+
+	var pkGot, ctGot, ssGot []byte
+	var err error
+	if compressed {
+		pkGot, err = pubKey.ExportCompressed()
+	} else {
+		pkGot = pubKey.Export()
+	}
+	skGot := append(prvKey.Export(), pkGot...)
+	if err != nil || !bytes.Equal(pkGot, pk) || !bytes.Equal(skGot, sk) {
+		return false, false
+	}
+
+	if compressed {
+		ctGot, ssGot, err = EncapsulateCompressed(rng, pubKey)
+	} else {
+		// Message is the next output of DRBG
+		m := make([]byte, pubKey.Params().MsgLen)
+		rng.Read(m)
+		ctGot, ssGot, err = EncapsulateDeterministically(pubKey, m)
+	}
+	return true, err == nil && bytes.Equal(ctGot, ct) && bytes.Equal(ssGot, ss)
+}
+
+func testDecapsulation(pk, sk, ct, ssExpected []byte, id uint8, compressed bool) bool {
 	var pubKey = NewPublicKey(id, KeyVariant_SIKE)
 	var prvKey = NewPrivateKey(id, KeyVariant_SIKE)
-	if pubKey.Import(pk) != nil || prvKey.Import(sk) != nil {
+	var err error
+	if compressed {
+		err = pubKey.ImportCompressed(pk)
+	} else {
+		err = pubKey.Import(pk)
+	}
+	if err != nil || prvKey.Import(sk) != nil {
 		panic("sike test: can't load KAT")
 	}
 
-	ssGot, err := Decapsulate(prvKey, pubKey, ct)
+	var ssGot []byte
+	if compressed {
+		ssGot, err = DecapsulateCompressed(prvKey, pubKey, ct)
+	} else {
+		ssGot, err = Decapsulate(prvKey, pubKey, ct)
+	}
 	if err != nil {
 		panic("sike test: can't perform decapsulation KAT")
 	}
@@ -312,9 +580,9 @@ func testDecapsulation(pk, sk, ct, ssExpected []byte, id uint8) bool {
 	return bytes.Equal(ssGot, ssExpected)
 }
 
-func testSIKE_KAT(t *testing.T, id uint8) {
+func testKATFile(t *testing.T, id uint8, katFile string, compressed bool) {
 	params := Params(id)
-	f, err := os.Open(tdata[id].KatFile)
+	f, err := os.Open(katFile)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -336,36 +604,189 @@ func testSIKE_KAT(t *testing.T, id uint8) {
 		// count
 		count := strings.Split(string(line), "=")[1]
 		// seed
-		_ = readAndCheckLine(r)
+		seed := readAndCheckLine(r)
 		// pk
 		pk := readAndCheckLine(r)
 		// sk (secret key in test vector is concatenation of
 		// MSG + SECRET_BOB_KEY + PUBLIC_BOB_KEY. We use only MSG+SECRET_BOB_KEY
-		sk := readAndCheckLine(r)
-		sk = sk[:params.MsgLen+int(params.B.SecretByteLen)]
+		skFull := readAndCheckLine(r)
+		sk := skFull[:params.MsgLen+int(params.B.SecretByteLen)]
 		// ct
 		ct := readAndCheckLine(r)
 		// ss
 		ss := readAndCheckLine(r)
 
-		if !testKeygen(pk, sk, id) {
-			t.Fatalf("KAT keygen form private failed at %s\n", count)
+		keygenOK, encapsOK := testGeneration(seed, pk, skFull, ct, ss, id, compressed)
+		if !keygenOK {
+			t.Fatalf("KAT keygen from seed failed at %s\n", count)
+		}
+		if !encapsOK {
+			t.Fatalf("KAT encapsulation failed at %s\n", count)
 		}
 
-		if !testDecapsulation(pk, sk, ct, ss, id) {
+		if !testDecapsulation(pk, sk, ct, ss, id, compressed) {
 			t.Fatalf("KAT decapsulation failed at %s\n", count)
 		}
 
-		// aditionally test roundtrip with a keypair
-		testKEMRoundTrip(t, pk, sk, id)
+		// aditionally test roundtrip with a keypair and decapsulation
+		// with the key in NIST format (only once, import is costly)
+		if !compressed {
+			testKEMRoundTrip(t, pk, sk, id)
+		}
+		if !compressed && strings.TrimSpace(count) == "0" {
+			key := NewSIKEPrivateKey(id)
+			checkErr(t, key.Import(skFull), "import of NIST secret key failed")
+			if ssGot, err := key.Decapsulate(ct); err != nil || !bytes.Equal(ssGot, ss) {
+				t.Fatalf("KAT decapsulation with SIKEPrivateKey failed at %s\n", count)
+			}
+		}
+	}
+}
+
+func testSIKE_KAT(t *testing.T, id uint8) {
+	if tdata[id].KatFile == "" {
+		// Covered by TestSIKE_Regression
+		return
+	}
+	if _, err := os.Stat(tdata[id].KatFile); err != nil {
+		t.Errorf("official KAT for %s not available: %v", tdata[id].name, err)
+		return
+	}
+	testKATFile(t, id, tdata[id].KatFile, false)
+}
+
+// Regression vectors generated by this implementation, for parameter sets
+// without official KAT. They don't check interoperability.
+var tdataRegression = map[uint8]string{
+	FP_610: "testdata/SIKEp610_regression.rsp",
+	FP_964: "testdata/SIKEp964_regression.rsp",
+}
+
+func TestSIKE_Regression(t *testing.T) {
+	for id, katFile := range tdataRegression {
+		fmt.Printf("\tTesting: %s\n", tdata[id].name)
+		testKATFile(t, id, katFile, false)
+	}
+}
+
+func TestCompressed_Regression(t *testing.T) {
+	for id, katFile := range tdataCompressed {
+		fmt.Printf("\tTesting: %s\n", tdata[id].name)
+		testKATFile(t, id, katFile, true)
+	}
+}
+
+// Compressed variant of PKE and KEM roundtrips. Compression is slow, hence
+// it is run only for parameter sets from tdataCompressed.
+func TestCompressedRoundTrip(t *testing.T) {
+	for id := range tdataCompressed {
+		params := Params(id)
+		msg := make([]byte, params.MsgLen)
+		for i := range msg {
+			msg[i] = byte(i)
+		}
+
+		sk := NewPrivateKey(id, KeyVariant_SIKE)
+		checkErr(t, sk.Generate(rand.Reader), "key generation")
+		pk := sk.GeneratePublicKey()
+
+		ct, err := EncryptCompressed(rand.Reader, pk, msg)
+		checkErr(t, err, "PKE encryption")
+		if len(ct) != NewPublicKey(id, KeyVariant_SIDH_A).CompressedSize()+len(msg) {
+			t.Errorf("wrong size of compressed ciphertext: %d", len(ct))
+		}
+		pt, err := DecryptCompressed(sk, ct)
+		checkErr(t, err, "PKE decryption")
+		if !bytes.Equal(pt, msg) {
+			t.Errorf("Decryption failed \n got : %X\n exp : %X", pt, msg)
+		}
+
+		ct, ss_e, err := EncapsulateCompressed(rand.Reader, pk)
+		checkErr(t, err, "encapsulation failed")
+		ss_d, err := DecapsulateCompressed(sk, pk, ct)
+		checkErr(t, err, "decapsulation failed")
+		if !bytes.Equal(ss_e, ss_d) {
+			t.Fatalf("KEM failed \n encapsulated: %X\n decapsulated: %X", ss_d, ss_e)
+		}
+
+		// Modified c1 must produce different shared secret
+		ct[len(ct)-1] ^= 1
+		ss_d, err = DecapsulateCompressed(sk, pk, ct)
+		checkErr(t, err, "decapsulation returns error when invalid ciphertext provided")
+		if bytes.Equal(ss_e, ss_d) {
+			t.Error("critical error")
+		}
+
+		// Uncompressed ciphertext must be rejected
+		ct, _, err = Encapsulate(rand.Reader, pk)
+		checkErr(t, err, "encapsulation failed")
+		if _, err = DecapsulateCompressed(sk, pk, ct); err == nil {
+			t.Error("decapsulation accepts uncompressed ciphertext")
+		}
+	}
+}
+
+// SIKE works with parameters registered by the user
+func TestRegisteredParams(t *testing.T) {
+	const customId = 0xF0
+	if _, err := LookupParams(customId); err != nil {
+		custom := *Params(FP_434)
+		custom.Id = customId
+		checkErr(t, RegisterParams(custom), "registration failed")
+	}
+	testPKEKeyGeneration(t, customId)
+	testKEMKeyGeneration(t, customId)
+
+	// KAT for P-434 holds for the copy
+	pk, _ := hex.DecodeString(tdata[FP_434].PkB)
+	sk, _ := hex.DecodeString(tdata[FP_434].PrB)
+	if !testKeygen(pk, sk, customId, false) {
+		t.Error("key generation with registered parameters failed")
+	}
+}
+
+func TestSpecVersion(t *testing.T) {
+	for _, v := range []struct {
+		bits int
+		ver  Version
+		id   uint8
+	}{
+		{503, Round1, FP_503}, {751, Round1, FP_751},
+		{434, Round3, FP_434}, {503, Round3, FP_503_R3}, {610, Round3, FP_610},
+		{751, Round3, FP_751_R3}, {964, Round3, FP_964},
+	} {
+		id, err := ParamsForVersion(v.bits, v.ver)
+		if err != nil || id != v.id {
+			t.Errorf("wrong parameter set for p%d round %d", v.bits, v.ver)
+		}
+		if SpecVersion(id) != v.ver {
+			t.Errorf("wrong version of %s", Params(id).Name)
+		}
+	}
+	if _, err := ParamsForVersion(434, Round1); err == nil {
+		t.Error("p434 is not defined in round 1")
+	}
+	if _, err := ParamsForVersion(503, Version(2)); err == nil {
+		t.Error("unknown version accepted")
+	}
+
+	// Round 1 and round 3 keys of the same size are not interchangeable
+	pk, _ := hex.DecodeString(tdata[FP_503_R3].PkB)
+	sk, _ := hex.DecodeString(tdata[FP_503_R3].PrB)
+	if testKeygen(pk, sk, FP_503, false) {
+		t.Error("round 3 key pair accepted by round 1 parameters")
 	}
 }
 
 // Interface to "testing"
-func TestPKEKeyGeneration(t *testing.T)           { Do(testPKEKeyGeneration, t) }
-func TestPKERoundTrip(t *testing.T)               { Do(testPKERoundTrip, t) }
-func TestNegativePKE(t *testing.T)                { Do(testNegativePKE, t) }
-func TestKEMKeyGeneration(t *testing.T)           { Do(testKEMKeyGeneration, t) }
-func TestNegativeKEM(t *testing.T)                { Do(testNegativeKEM, t) }
-func TestSIKE_KAT(t *testing.T)                   { Do(testSIKE_KAT, t) }
-func TestNegativeKEMSameWrongResult(t *testing.T) { Do(testNegativeKEMSameWrongResult, t) }
+func TestPKEKeyGeneration(t *testing.T)             { Do(testPKEKeyGeneration, t) }
+func TestPKERoundTrip(t *testing.T)                 { Do(testPKERoundTrip, t) }
+func TestNegativePKE(t *testing.T)                  { Do(testNegativePKE, t) }
+func TestKEMKeyGeneration(t *testing.T)             { Do(testKEMKeyGeneration, t) }
+func TestNegativeKEM(t *testing.T)                  { Do(testNegativeKEM, t) }
+func TestSIKE_KAT(t *testing.T)                     { Do(testSIKE_KAT, t) }
+func TestEncapsulateDeterministically(t *testing.T) { Do(testEncapsulateDeterministically, t) }
+func TestNegativeKEMSameWrongResult(t *testing.T)   { Do(testNegativeKEMSameWrongResult, t) }
+func TestSIKEPrivateKey(t *testing.T)               { Do(testSIKEPrivateKey, t) }
+func TestEphemeralZeroized(t *testing.T)            { Do(testEphemeralZeroized, t) }
+func TestSIKEPrivateKeyDestroy(t *testing.T)        { Do(testSIKEPrivateKeyDestroy, t) }
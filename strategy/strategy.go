@@ -0,0 +1,108 @@
+// Package strategy computes optimal strategies for traversing isogeny trees,
+// as described in section 4.2 of "Towards quantum-resistant cryptosystems
+// from supersingular elliptic curve isogenies" by L. De Feo, D. Jao and
+// J. Plut.
+//
+// A strategy for a tree with n leaves is encoded in the same way as the
+// IsogenyStrategy tables used by sidh package: it is a sequence of n-1
+// numbers listed in pre-order. The first number b says how many point
+// multiplications are performed before the tree is split into a subtree
+// with n-b leaves (which is traversed first) and a subtree with b leaves.
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Optimal returns strategy for a tree with n leaves, which minimizes the
+// total cost, given cost of a single point multiplication (mul) and cost of
+// a single isogeny evaluation (eval). In case of ties, the smallest split
+// is chosen, so the result is deterministic.
+func Optimal(n int, mul, eval float64) []uint32 {
+	if n < 1 {
+		return nil
+	}
+	// cost[i] is cost of optimal strategy for a tree with i leaves,
+	// split[i] is the first element of that strategy.
+	cost := make([]float64, n+1)
+	split := make([]int, n+1)
+	for i := 2; i <= n; i++ {
+		for b := 1; b < i; b++ {
+			c := cost[i-b] + cost[b] + float64(b)*mul + float64(i-b)*eval
+			if split[i] == 0 || c < cost[i] {
+				cost[i], split[i] = c, b
+			}
+		}
+	}
+
+	s := make([]uint32, 0, n-1)
+	var build func(int)
+	build = func(i int) {
+		if i == 1 {
+			return
+		}
+		b := split[i]
+		s = append(s, uint32(b))
+		build(i - b)
+		build(b)
+	}
+	build(n)
+	return s
+}
+
+// Cost returns total cost of traversing a tree with len(s)+1 leaves with
+// strategy s, given cost of a single point multiplication (mul) and cost of
+// a single isogeny evaluation (eval). Returns error if s is malformed.
+func Cost(s []uint32, mul, eval float64) (float64, error) {
+	var idx int
+	var rec func(int) (float64, error)
+	rec = func(n int) (float64, error) {
+		if n == 1 {
+			return 0, nil
+		}
+		if idx >= len(s) || s[idx] < 1 || int(s[idx]) >= n {
+			return 0, errors.New("strategy: malformed strategy")
+		}
+		b := int(s[idx])
+		idx++
+		c1, err := rec(n - b)
+		if err != nil {
+			return 0, err
+		}
+		c2, err := rec(b)
+		if err != nil {
+			return 0, err
+		}
+		return c1 + c2 + float64(b)*mul + float64(n-b)*eval, nil
+	}
+
+	c, err := rec(len(s) + 1)
+	if err == nil && idx != len(s) {
+		err = errors.New("strategy: malformed strategy")
+	}
+	return c, err
+}
+
+// Write emits Go source code defining strategy s as an array variable
+// called name, of size sizeConst, preceded by a comment. Output has the
+// same format as tables in p434, p503, ... packages.
+func Write(w io.Writer, comment, name, sizeConst string, s []uint32) error {
+	if _, err := fmt.Fprintf(w, "// %s\nvar %s = [%s]uint32{", comment, name, sizeConst); err != nil {
+		return err
+	}
+	for i, v := range s {
+		sep := ", "
+		if i == 0 {
+			sep = "\n\t"
+		} else if i%16 == 0 {
+			sep = ",\n\t"
+		}
+		if _, err := fmt.Fprintf(w, "%s0x%02X", sep, v); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "}\n")
+	return err
+}
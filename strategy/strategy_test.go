@@ -0,0 +1,101 @@
+package strategy
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+
+	p434 "github.com/cloudflare/sidh/p434"
+	p503 "github.com/cloudflare/sidh/p503"
+	p610 "github.com/cloudflare/sidh/p610"
+	p751 "github.com/cloudflare/sidh/p751"
+	p964 "github.com/cloudflare/sidh/p964"
+)
+
+// Returns minimal cost of a tree with n leaves by exhaustive search
+func bruteForce(n int, mul, eval float64) float64 {
+	if n == 1 {
+		return 0
+	}
+	best := math.Inf(1)
+	for b := 1; b < n; b++ {
+		c := bruteForce(n-b, mul, eval) + bruteForce(b, mul, eval) + float64(b)*mul + float64(n-b)*eval
+		best = math.Min(best, c)
+	}
+	return best
+}
+
+func TestOptimal(t *testing.T) {
+	for n := 1; n <= 12; n++ {
+		for _, w := range [][2]float64{{1, 1}, {3, 1}, {1, 3}, {112, 76}} {
+			s := Optimal(n, w[0], w[1])
+			if len(s) != n-1 {
+				t.Fatalf("wrong length of strategy: %d", len(s))
+			}
+			c, err := Cost(s, w[0], w[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exp := bruteForce(n, w[0], w[1]); c != exp {
+				t.Errorf("strategy for n=%d not optimal: %f, expected %f", n, c, exp)
+			}
+		}
+	}
+}
+
+func TestCostMalformed(t *testing.T) {
+	for _, s := range [][]uint32{{0}, {2}, {1, 2}, {2, 2, 1}} {
+		if _, err := Cost(s, 1, 1); err == nil {
+			t.Errorf("malformed strategy %v accepted", s)
+		}
+	}
+}
+
+// Shipped strategies are optimal for the ratio of costs they were generated
+// with.
+func TestShippedStrategies(t *testing.T) {
+	for _, v := range []struct {
+		name      string
+		s         []uint32
+		mul, eval float64
+	}{
+		{"P434 Alice", p434.P434_AliceIsogenyStrategy[:], 1, 1},
+		{"P434 Bob", p434.P434_BobIsogenyStrategy[:], 1, 1},
+		{"P503 Alice", p503.P503_AliceIsogenyStrategy[:], 1, 1},
+		{"P503 Bob", p503.P503_BobIsogenyStrategy[:], 1, 1},
+		{"P610 Alice", p610.P610_AliceIsogenyStrategy[:], 112, 76},
+		{"P610 Bob", p610.P610_BobIsogenyStrategy[:], 110, 36},
+		{"P751 Alice", p751.P751_AliceIsogenyStrategy[:], 1, 1},
+		{"P751 Bob", p751.P751_BobIsogenyStrategy[:], 1, 1},
+		{"P964 Alice", p964.P964_AliceIsogenyStrategy[:], 112, 76},
+		{"P964 Bob", p964.P964_BobIsogenyStrategy[:], 110, 36},
+	} {
+		shipped, err := Cost(v.s, v.mul, v.eval)
+		if err != nil {
+			t.Fatalf("%s: %s", v.name, err)
+		}
+		opt, _ := Cost(Optimal(len(v.s)+1, v.mul, v.eval), v.mul, v.eval)
+		if shipped != opt {
+			t.Errorf("%s: strategy not optimal: %f, expected %f", v.name, shipped, opt)
+		}
+	}
+}
+
+// Output of Write has the same format as tables in p434 package
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	src, err := ioutil.ReadFile("../p434/consts.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Write(&buf, "2-torsion group computation strategy", "P434_AliceIsogenyStrategy",
+		"strategySizeA", p434.P434_AliceIsogenyStrategy[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), buf.String()) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}